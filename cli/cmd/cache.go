@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/neev-kit/neev/core/inspect"
+	"github.com/spf13/cobra"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the inspect analysis cache",
+	Long: `Manage the analysis cache that neev inspect keeps in ` + inspect.DefaultCacheDir + `.
+The cache stores endpoints and function signatures per file, keyed by content hash,
+so repeat inspections only re-analyse files that changed.`,
+}
+
+var cacheCleanCmd = &cobra.Command{
+	Use:   "clean",
+	Short: "Remove the inspect analysis cache",
+	Run: func(cmd *cobra.Command, args []string) {
+		cwd, err := os.Getwd()
+		if err != nil {
+			errorStyle := lipgloss.NewStyle().
+				Bold(true).
+				Foreground(lipgloss.Color("1"))
+			fmt.Println(errorStyle.Render("❌ Failed to determine current working directory: " + err.Error()))
+			return
+		}
+
+		if err := inspect.CleanAnalysisCache(filepath.Join(cwd, inspect.DefaultCacheDir)); err != nil {
+			errorStyle := lipgloss.NewStyle().
+				Bold(true).
+				Foreground(lipgloss.Color("1"))
+			fmt.Println(errorStyle.Render("❌ " + err.Error()))
+			os.Exit(1)
+		}
+
+		successStyle := lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("2"))
+		fmt.Println(successStyle.Render("✅ Removed " + inspect.DefaultCacheDir))
+	},
+}
+
+func init() {
+	cacheCmd.AddCommand(cacheCleanCmd)
+	rootCmd.AddCommand(cacheCmd)
+}
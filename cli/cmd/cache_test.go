@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCacheCmd_IsRegistered(t *testing.T) {
+	found := false
+	for _, cmd := range rootCmd.Commands() {
+		if cmd == cacheCmd {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("cacheCmd should be registered on the root command")
+	}
+
+	if cacheCleanCmd.Parent() != cacheCmd {
+		t.Error("clean should be a subcommand of cache")
+	}
+}
+
+func TestCacheCleanCmd_RemovesCache(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(tmpDir)
+
+	cacheFile := filepath.Join(tmpDir, ".neev", "cache", "inspect.json")
+	os.MkdirAll(filepath.Dir(cacheFile), 0755)
+	os.WriteFile(cacheFile, []byte("{}"), 0644)
+
+	cacheCleanCmd.Run(cacheCleanCmd, []string{})
+
+	if _, err := os.Stat(filepath.Dir(cacheFile)); !os.IsNotExist(err) {
+		t.Error("Expected .neev/cache to be removed")
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, ".neev")); err != nil {
+		t.Error("Expected .neev to be kept")
+	}
+}
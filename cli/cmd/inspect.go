@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/neev-kit/neev/core/config"
@@ -16,12 +17,21 @@ import (
 
 var (
 	jsonOutput      bool
+	outputFormat    string
 	useDescriptors  bool
 	strictMode      bool
+	failOn          string
 	depth           int
 	checkAPI        bool
 	checkSignatures bool
 	checkTests      bool
+	checkDataModel  bool
+	writeBaseline   bool
+	noBaseline      bool
+	noCache         bool
+	since           string
+	watchMode       bool
+	watchDebounce   time.Duration
 )
 
 var inspectCmd = &cobra.Command{
@@ -42,8 +52,39 @@ var inspectCmd = &cobra.Command{
 			cfg = config.DefaultConfig()
 		}
 
-		// Use new structured inspect if descriptors are enabled or JSON output requested
-		if useDescriptors || jsonOutput || depth > 1 || checkAPI || checkSignatures {
+		format, err := resolveOutputFormat()
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		if failOn != "" && inspect.SeverityRank(failOn) < 0 {
+			fmt.Printf("Error: invalid --fail-on '%s' (expected error, warning or info)\n", failOn)
+			os.Exit(1)
+		}
+		rules, err := inspectRules(cfg.Inspect)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		plugins, err := inspectPlugins(cfg.Inspect.Plugins)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		if watchMode && (format != formatText || writeBaseline || since != "") {
+			fmt.Println("Error: --watch cannot be combined with --format, --json, --write-baseline or --since")
+			os.Exit(1)
+		}
+
+		baselinePath := filepath.Join(cwd, inspect.DefaultBaselineFile)
+		_, baselineErr := os.Stat(baselinePath)
+		useBaseline := baselineErr == nil && !noBaseline && !writeBaseline
+
+		// Use new structured inspect if descriptors are enabled, machine-readable output requested,
+		// a baseline is involved or neev.yaml, spec front matter or a stack declaration configures inspection
+		configured := len(cfg.Inspect.Rules) > 0 || len(cfg.Inspect.Plugins) > 0 || len(cfg.Modules) > 0 || len(cfg.ModuleRoots) > 0 ||
+			inspect.HasFrontMatter(filepath.Join(cwd, ".neev", "foundation")) || inspect.HasStackDeclaration(filepath.Join(cwd, ".neev", "foundation"))
+		if useDescriptors || format != formatText || depth > 1 || checkAPI || checkSignatures || checkTests || checkDataModel || since != "" || watchMode || writeBaseline || useBaseline || configured {
 			foundationPath := filepath.Join(cwd, ".neev", "foundation")
 
 			opts := inspect.InspectOptions{
@@ -54,9 +95,34 @@ var inspectCmd = &cobra.Command{
 				Depth:           depth,
 				CheckAPI:        checkAPI,
 				CheckSignatures: checkSignatures,
+				CheckTests:      checkTests,
+				CheckDataModel:  checkDataModel,
+				Rules:           rules,
+				ModuleRoots:     cfg.ModuleRoots,
+				ModulePaths:     cfg.Modules,
+				Plugins:         plugins,
+			}
+			if useBaseline {
+				opts.BaselinePath = baselinePath
+			}
+			if !noCache {
+				opts.CacheDir = filepath.Join(cwd, inspect.DefaultCacheDir)
 			}
 
-			result, err := inspect.Inspect(opts)
+			if watchMode {
+				if err := watchInspect(opts, watchDebounce); err != nil {
+					fmt.Printf("❌ Watch failed: %v\n", err)
+					os.Exit(1)
+				}
+				return
+			}
+
+			var result *inspect.InspectResult
+			if since != "" {
+				result, err = inspect.InspectSince(opts, since)
+			} else {
+				result, err = inspect.Inspect(opts)
+			}
 			if err != nil {
 				errorStyle := lipgloss.NewStyle().
 					Bold(true).
@@ -67,27 +133,50 @@ var inspectCmd = &cobra.Command{
 				return
 			}
 
-			// JSON output mode
-			if jsonOutput {
+			// Record current drift as accepted and stop
+			if writeBaseline {
+				baseline := inspect.NewBaseline(result)
+				if err := inspect.SaveBaseline(baselinePath, baseline); err != nil {
+					fmt.Printf("❌ Failed to write baseline: %v\n", err)
+					os.Exit(1)
+				}
+				fmt.Printf("✅ Baseline written to %s (%d warnings accepted)\n", inspect.DefaultBaselineFile, len(baseline.Entries))
+				return
+			}
+
+			switch format {
+			case formatJSON:
 				jsonData, err := json.MarshalIndent(result, "", "  ")
 				if err != nil {
 					fmt.Printf("Error: Failed to generate JSON: %v\n", err)
 					return
 				}
 				fmt.Println(string(jsonData))
-
-				// Exit with error code if strict mode and drift found
-				if strictMode && (!result.Success || len(result.Warnings) > 0) {
-					os.Exit(1)
+			case formatSARIF:
+				sarifData, err := inspect.MarshalSARIF(result, cwd)
+				if err != nil {
+					fmt.Printf("Error: Failed to generate SARIF: %v\n", err)
+					return
 				}
-				return
+				fmt.Println(string(sarifData))
+			case formatJUnit:
+				junitData, err := inspect.MarshalJUnit(result)
+				if err != nil {
+					fmt.Printf("Error: Failed to generate JUnit XML: %v\n", err)
+					return
+				}
+				fmt.Println(string(junitData))
+			default:
+				// Pretty print structured output
+				printStructuredResult(result)
 			}
 
-			// Pretty print structured output
-			printStructuredResult(result)
-
 			// Exit with error code if strict mode and drift found
-			if strictMode && (!result.Success || len(result.Warnings) > 0) {
+			threshold := cfg.Inspect.FailOn
+			if failOn != "" {
+				threshold = failOn
+			}
+			if strictMode && result.ExceedsThreshold(threshold) {
 				os.Exit(1)
 			}
 			return
@@ -134,6 +223,35 @@ var inspectCmd = &cobra.Command{
 	},
 }
 
+// Output formats supported by neev inspect
+const (
+	formatText  = "text"
+	formatJSON  = "json"
+	formatSARIF = "sarif"
+	formatJUnit = "junit"
+)
+
+// resolveOutputFormat combines --format with the legacy --json flag
+func resolveOutputFormat() (string, error) {
+	format := strings.ToLower(strings.TrimSpace(outputFormat))
+	if format == "" {
+		format = formatText
+	}
+	if jsonOutput {
+		if format != formatText && format != formatJSON {
+			return "", fmt.Errorf("--json cannot be combined with --format %s", format)
+		}
+		format = formatJSON
+	}
+
+	switch format {
+	case formatText, formatJSON, formatSARIF, formatJUnit:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown output format '%s' (expected text, json, sarif or junit)", outputFormat)
+	}
+}
+
 func printStructuredResult(result *inspect.InspectResult) {
 	if result.Success && len(result.Warnings) == 0 {
 		successStyle := lipgloss.NewStyle().
@@ -156,6 +274,12 @@ func printStructuredResult(result *inspect.InspectResult) {
 		}
 		
 		fmt.Printf("📊 Summary: %d modules checked, all in sync\n", result.Summary.TotalModules)
+		if result.Summary.Since != "" {
+			fmt.Printf("   (%d files changed since %s)\n", result.Summary.ChangedFiles, result.Summary.Since)
+		}
+		if result.Summary.Suppressed > 0 || result.Summary.Baselined > 0 {
+			fmt.Printf("   (%d suppressed, %d in baseline)\n", result.Summary.Suppressed, result.Summary.Baselined)
+		}
 		return
 	}
 
@@ -201,7 +325,7 @@ func printStructuredResult(result *inspect.InspectResult) {
 			Foreground(lipgloss.Color("1"))
 		fmt.Println(errorStyle.Render("🔴 Errors:"))
 		for _, w := range errors {
-			fmt.Printf("  [%s] %s: %s\n", w.Type, w.Module, w.Message)
+			fmt.Printf("  %s[%s] %s%s: %s\n", changeMarker(w), w.Type, w.Module, ownersSuffix(w), w.Message)
 			if w.Remediation != "" {
 				fmt.Printf("    💡 %s\n", w.Remediation)
 			}
@@ -216,7 +340,7 @@ func printStructuredResult(result *inspect.InspectResult) {
 			Foreground(lipgloss.Color("3"))
 		fmt.Println(warningStyle.Render("🟡 Warnings:"))
 		for _, w := range warnings {
-			fmt.Printf("  [%s] %s: %s\n", w.Type, w.Module, w.Message)
+			fmt.Printf("  %s[%s] %s%s: %s\n", changeMarker(w), w.Type, w.Module, ownersSuffix(w), w.Message)
 			if w.Remediation != "" {
 				fmt.Printf("    💡 %s\n", w.Remediation)
 			}
@@ -231,7 +355,7 @@ func printStructuredResult(result *inspect.InspectResult) {
 			Foreground(lipgloss.Color("6"))
 		fmt.Println(infoStyle.Render("ℹ️  Info:"))
 		for _, w := range infos {
-			fmt.Printf("  [%s] %s: %s\n", w.Type, w.Module, w.Message)
+			fmt.Printf("  %s[%s] %s%s: %s\n", changeMarker(w), w.Type, w.Module, ownersSuffix(w), w.Message)
 		}
 		fmt.Println()
 	}
@@ -248,23 +372,144 @@ func printStructuredResult(result *inspect.InspectResult) {
 		fmt.Printf("  Missing endpoints: %d\n", result.Summary.MissingEndpoints)
 		fmt.Printf("  Undocumented endpoints: %d\n", result.Summary.UndocumentedEnds)
 	}
+	if result.Summary.SchemaMismatches > 0 {
+		fmt.Printf("  Schema mismatches: %d\n", result.Summary.SchemaMismatches)
+	}
+	if result.Summary.MissingRPCs > 0 || result.Summary.UndocumentedRPCs > 0 {
+		fmt.Printf("  Missing gRPC methods: %d\n", result.Summary.MissingRPCs)
+		fmt.Printf("  Undocumented gRPC methods: %d\n", result.Summary.UndocumentedRPCs)
+	}
+	if result.Summary.UnresolvedFields > 0 || result.Summary.UndocumentedResolvers > 0 {
+		fmt.Printf("  Unresolved GraphQL fields: %d\n", result.Summary.UnresolvedFields)
+		fmt.Printf("  Undocumented GraphQL resolvers: %d\n", result.Summary.UndocumentedResolvers)
+	}
+	if result.Summary.MissingTopics > 0 || result.Summary.UndocumentedTopics > 0 {
+		fmt.Printf("  Unused documented topics: %d\n", result.Summary.MissingTopics)
+		fmt.Printf("  Undocumented topics: %d\n", result.Summary.UndocumentedTopics)
+	}
+	
+	if result.Summary.UndocumentedEnvVars > 0 || result.Summary.UnusedEnvVars > 0 {
+		fmt.Printf("  Undocumented env vars: %d\n", result.Summary.UndocumentedEnvVars)
+		fmt.Printf("  Unused documented env vars: %d\n", result.Summary.UnusedEnvVars)
+	}
+	if result.Summary.ForbiddenDependencies > 0 || result.Summary.UnapprovedDependencies > 0 || result.Summary.DependencyVersionMismatches > 0 {
+		fmt.Printf("  Forbidden dependencies: %d\n", result.Summary.ForbiddenDependencies)
+		fmt.Printf("  Unapproved dependencies: %d\n", result.Summary.UnapprovedDependencies)
+		fmt.Printf("  Dependencies outside approved versions: %d\n", result.Summary.DependencyVersionMismatches)
+	}
+	if result.Summary.MissingTables > 0 || result.Summary.MissingColumns > 0 || result.Summary.ColumnTypeMismatches > 0 {
+		fmt.Printf("  Missing tables: %d\n", result.Summary.MissingTables)
+		fmt.Printf("  Missing columns: %d\n", result.Summary.MissingColumns)
+		fmt.Printf("  Column type mismatches: %d\n", result.Summary.ColumnTypeMismatches)
+	}
 	
 	// Print signature mismatch summary if applicable
 	if result.Summary.SignatureMismatches > 0 {
 		fmt.Printf("  Signature mismatches: %d\n", result.Summary.SignatureMismatches)
 	}
+
+	// Print BDD coverage summary if applicable
+	if result.Summary.TestScenarios > 0 || result.Summary.UntestedEndpoints > 0 || result.Summary.OrphanedScenarios > 0 {
+		fmt.Printf("  BDD scenarios: %d (tested endpoints: %d, untested: %d, orphaned scenarios: %d)\n",
+			result.Summary.TestScenarios, result.Summary.TestedEndpoints, result.Summary.UntestedEndpoints, result.Summary.OrphanedScenarios)
+	}
 	
 	fmt.Printf("  Total warnings: %d (errors: %d, warnings: %d)\n",
 		result.Summary.TotalWarnings, result.Summary.ErrorCount, result.Summary.WarningCount)
+
+	// Print accepted drift that was hidden
+	if result.Summary.Suppressed > 0 || result.Summary.Baselined > 0 {
+		fmt.Printf("  Hidden: %d suppressed, %d in baseline\n", result.Summary.Suppressed, result.Summary.Baselined)
+	}
+
+	// Print how much of the drift the changes introduced
+	if result.Summary.Since != "" {
+		fmt.Printf("  Since %s: %d changed files, %d new, %d pre-existing\n", result.Summary.Since,
+			result.Summary.ChangedFiles, result.Summary.NewWarnings, result.Summary.PreexistingWarnings)
+	}
+}
+
+// changeMarker tags warnings introduced since the --since revision
+func changeMarker(w inspect.Warning) string {
+	if w.Change == inspect.ChangeNew {
+		return "[NEW] "
+	}
+	return ""
+}
+
+// inspectRules converts the rules of neev.yaml for inspect, which checks their
+// warning types and severities, and validates the configured fail_on
+func inspectRules(cfg config.InspectConfig) ([]inspect.Rule, error) {
+	var rules []inspect.Rule
+	for _, rule := range cfg.Rules {
+		converted := inspect.Rule{
+			Type:     inspect.WarningType(rule.Type),
+			Severity: rule.Severity,
+			Disabled: rule.Disabled,
+			Modules:  rule.Modules,
+		}
+		if err := converted.Validate(); err != nil {
+			return nil, err
+		}
+		rules = append(rules, converted)
+	}
+	if cfg.FailOn != "" && inspect.SeverityRank(cfg.FailOn) < 0 {
+		return nil, fmt.Errorf("invalid inspect.fail_on '%s' (expected error, warning or info)", cfg.FailOn)
+	}
+	return rules, nil
+}
+
+// inspectPlugins converts the plugins of neev.yaml for inspect, which checks
+// their fields, and rejects duplicate names
+func inspectPlugins(configured []config.InspectPlugin) ([]inspect.Plugin, error) {
+	var plugins []inspect.Plugin
+	names := make(map[string]bool)
+	for _, plugin := range configured {
+		converted := inspect.Plugin{
+			Name:     plugin.Name,
+			Command:  plugin.Command,
+			Args:     plugin.Args,
+			Language: plugin.Language,
+			Files:    plugin.Files,
+			Version:  plugin.Version,
+			Timeout:  plugin.Timeout,
+		}
+		if err := converted.Validate(); err != nil {
+			return nil, err
+		}
+		if names[plugin.Name] {
+			return nil, fmt.Errorf("duplicate inspect plugin name: %s", plugin.Name)
+		}
+		names[plugin.Name] = true
+		plugins = append(plugins, converted)
+	}
+	return plugins, nil
+}
+
+// ownersSuffix names the owners of the spec a warning concerns, e.g. " (@payments)"
+func ownersSuffix(w inspect.Warning) string {
+	if len(w.Owners) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(w.Owners, ", ") + ")"
 }
 
 func init() {
 	rootCmd.AddCommand(inspectCmd)
-	inspectCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output results in JSON format")
+	inspectCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output results in JSON format (same as --format json)")
+	inspectCmd.Flags().StringVar(&outputFormat, "format", formatText, "Output format: text, json, sarif or junit")
 	inspectCmd.Flags().BoolVar(&useDescriptors, "use-descriptors", false, "Use .module.yaml files for detailed inspection")
 	inspectCmd.Flags().BoolVar(&strictMode, "strict", false, "Exit with code 1 if any drift is detected (for CI pipelines)")
+	inspectCmd.Flags().StringVar(&failOn, "fail-on", "", "Lowest severity that fails --strict: error, warning or info (default: inspect.fail_on in neev.yaml, else info)")
 	inspectCmd.Flags().IntVar(&depth, "depth", 1, "Depth of analysis (1=structure, 2=+API, 3=+signatures)")
 	inspectCmd.Flags().BoolVar(&checkAPI, "check-api", false, "Validate OpenAPI specs (enables Level 2)")
 	inspectCmd.Flags().BoolVar(&checkSignatures, "check-signatures", false, "Validate function signatures (enables Level 3)")
-	inspectCmd.Flags().BoolVar(&checkTests, "check-tests", false, "Validate BDD test coverage (not yet implemented)")
+	inspectCmd.Flags().BoolVar(&checkTests, "check-tests", false, "Validate that documented endpoints are covered by BDD scenarios")
+	inspectCmd.Flags().BoolVar(&checkDataModel, "check-data-model", false, "Validate documented tables against SQL migrations")
+	inspectCmd.Flags().BoolVar(&writeBaseline, "write-baseline", false, "Record current warnings in "+inspect.DefaultBaselineFile+" so later runs report only new drift")
+	inspectCmd.Flags().BoolVar(&noBaseline, "no-baseline", false, "Ignore "+inspect.DefaultBaselineFile+" and report all drift")
+	inspectCmd.Flags().BoolVar(&noCache, "no-cache", false, "Re-analyse every file instead of reusing results cached in "+inspect.DefaultCacheDir)
+	inspectCmd.Flags().StringVar(&since, "since", "", "Only report drift in files changed since this git ref, marking each warning as new or pre-existing")
+	inspectCmd.Flags().BoolVar(&watchMode, "watch", false, "Keep running and re-inspect when code or .neev/ changes, printing warnings that appeared or were resolved")
+	inspectCmd.Flags().DurationVar(&watchDebounce, "debounce", inspect.DefaultWatchDebounce, "Quiet period after the last change before --watch re-inspects")
 }
@@ -2,6 +2,9 @@ package cmd
 
 import (
 	"testing"
+
+	"github.com/neev-kit/neev/core/config"
+	"github.com/neev-kit/neev/core/inspect"
 )
 
 func TestInspectCmd_IsRegistered(t *testing.T) {
@@ -50,3 +53,141 @@ func TestInspectCmd_HasRunFunction(t *testing.T) {
 		t.Error("inspectCmd should have Run or RunE function")
 	}
 }
+
+func TestInspectCmd_HasFormatFlag(t *testing.T) {
+	flag := inspectCmd.Flags().Lookup("format")
+	if flag == nil {
+		t.Fatal("inspectCmd should have a --format flag")
+	}
+
+	if flag.DefValue != "text" {
+		t.Errorf("Expected default format 'text', got '%s'", flag.DefValue)
+	}
+}
+
+func TestResolveOutputFormat(t *testing.T) {
+	defer func() {
+		outputFormat = formatText
+		jsonOutput = false
+	}()
+
+	tests := []struct {
+		format  string
+		json    bool
+		want    string
+		wantErr bool
+	}{
+		{"text", false, "text", false},
+		{"SARIF", false, "sarif", false},
+		{"junit", false, "junit", false},
+		{"text", true, "json", false},
+		{"sarif", true, "", true},
+		{"xml", false, "", true},
+	}
+
+	for _, tt := range tests {
+		outputFormat = tt.format
+		jsonOutput = tt.json
+
+		got, err := resolveOutputFormat()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("resolveOutputFormat(%q, json=%v) error = %v, wantErr %v", tt.format, tt.json, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("resolveOutputFormat(%q, json=%v) = %q, want %q", tt.format, tt.json, got, tt.want)
+		}
+	}
+}
+
+func TestInspectCmd_HasNoCacheFlag(t *testing.T) {
+	flag := inspectCmd.Flags().Lookup("no-cache")
+	if flag == nil {
+		t.Fatal("inspectCmd should have a --no-cache flag")
+	}
+	if flag.DefValue != "false" {
+		t.Errorf("Expected --no-cache to default to false, got '%s'", flag.DefValue)
+	}
+}
+
+func TestInspectCmd_HasSinceFlag(t *testing.T) {
+	flag := inspectCmd.Flags().Lookup("since")
+	if flag == nil {
+		t.Fatal("inspectCmd should have a --since flag")
+	}
+	if flag.DefValue != "" {
+		t.Errorf("Expected --since to default to empty, got '%s'", flag.DefValue)
+	}
+}
+
+func TestInspectCmd_HasWatchFlags(t *testing.T) {
+	if inspectCmd.Flags().Lookup("watch") == nil {
+		t.Fatal("inspectCmd should have a --watch flag")
+	}
+	flag := inspectCmd.Flags().Lookup("debounce")
+	if flag == nil {
+		t.Fatal("inspectCmd should have a --debounce flag")
+	}
+	if flag.DefValue != "500ms" {
+		t.Errorf("Expected --debounce to default to 500ms, got '%s'", flag.DefValue)
+	}
+}
+
+func TestInspectRules(t *testing.T) {
+	rules, err := inspectRules(config.InspectConfig{
+		Rules:  []config.InspectRule{{Type: "EXTRA_CODE", Severity: "error", Modules: []string{"legacy-*"}}},
+		FailOn: "warning",
+	})
+	if err != nil {
+		t.Fatalf("inspectRules failed: %v", err)
+	}
+	if len(rules) != 1 || rules[0].Type != inspect.WarningExtraCode || rules[0].Modules[0] != "legacy-*" {
+		t.Errorf("Unexpected rules: %+v", rules)
+	}
+}
+
+func TestInspectRules_Invalid(t *testing.T) {
+	tests := map[string]config.InspectConfig{
+		"bad severity": {Rules: []config.InspectRule{{Type: "EXTRA_CODE", Severity: "fatal"}}},
+		"empty type":   {Rules: []config.InspectRule{{Severity: "error"}}},
+		"unknown type": {Rules: []config.InspectRule{{Type: "EXTRA_COD", Severity: "error"}}},
+		"bad glob":     {Rules: []config.InspectRule{{Type: "EXTRA_CODE", Modules: []string{"["}}}},
+		"bad fail_on":  {FailOn: "critical"},
+	}
+
+	for name, cfg := range tests {
+		if _, err := inspectRules(cfg); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+}
+
+func TestInspectPlugins(t *testing.T) {
+	plugins, err := inspectPlugins([]config.InspectPlugin{
+		{Name: "rpc", Command: "./tools/rpc-detector", Args: []string{"--json"}, Language: "rpc", Files: []string{"**/*.rpc"}, Timeout: "10s"},
+	})
+	if err != nil {
+		t.Fatalf("inspectPlugins failed: %v", err)
+	}
+	if len(plugins) != 1 || plugins[0].Command != "./tools/rpc-detector" || plugins[0].Args[0] != "--json" || plugins[0].Timeout != "10s" {
+		t.Errorf("Unexpected plugins: %+v", plugins)
+	}
+}
+
+func TestInspectPlugins_Invalid(t *testing.T) {
+	tests := map[string][]config.InspectPlugin{
+		"plugin without command": {{Name: "rpc", Language: "rpc", Files: []string{"*.rpc"}}},
+		"plugin without files":   {{Name: "rpc", Command: "rpc-detector", Language: "rpc"}},
+		"plugin bad timeout":     {{Name: "rpc", Command: "rpc-detector", Language: "rpc", Files: []string{"*.rpc"}, Timeout: "soon"}},
+		"duplicate plugins": {
+			{Name: "rpc", Command: "a", Language: "rpc", Files: []string{"*.rpc"}},
+			{Name: "rpc", Command: "b", Language: "rpc", Files: []string{"*.idl"}},
+		},
+	}
+
+	for name, plugins := range tests {
+		if _, err := inspectPlugins(plugins); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+}
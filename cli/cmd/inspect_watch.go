@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/neev-kit/neev/core/inspect"
+)
+
+// watchInspect inspects once, then re-inspects whenever files change and
+// prints the warnings that appeared or were resolved, until interrupted
+func watchInspect(opts inspect.InspectOptions, debounce time.Duration) error {
+	session, err := inspect.NewSession(opts)
+	if err != nil {
+		return err
+	}
+	printStructuredResult(session.Result())
+	fmt.Println()
+	fmt.Println("👀 Watching for changes (Ctrl+C to stop)...")
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	watcher := &inspect.Watcher{
+		RootDir:    opts.RootDir,
+		IgnoreDirs: opts.IgnoreDirs,
+		Debounce:   debounce,
+	}
+	return watcher.Run(ctx, func(changed []string) {
+		prev := session.Result()
+		next, err := session.Reinspect(changed)
+		if err != nil {
+			fmt.Printf("❌ Inspection failed: %v\n", err)
+			return
+		}
+		added, resolved := inspect.DiffWarnings(prev.Warnings, next.Warnings)
+		printWarningDiff(changed, added, resolved, next)
+	})
+}
+
+// printWarningDiff prints one re-inspection of watch mode
+func printWarningDiff(changed []string, added, resolved []inspect.Warning, result *inspect.InspectResult) {
+	addedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	resolvedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+
+	fmt.Printf("\n[%s] %d file(s) changed", time.Now().Format("15:04:05"), len(changed))
+	if len(changed) == 1 {
+		fmt.Printf(": %s", changed[0])
+	}
+	fmt.Println()
+
+	if len(added) == 0 && len(resolved) == 0 {
+		fmt.Println("  No drift changes")
+	}
+	for _, w := range added {
+		fmt.Println(addedStyle.Render(fmt.Sprintf("  + [%s] %s: %s", w.Type, w.Module, w.Message)))
+	}
+	for _, w := range resolved {
+		fmt.Println(resolvedStyle.Render(fmt.Sprintf("  - [%s] %s: %s", w.Type, w.Module, w.Message)))
+	}
+
+	fmt.Printf("  Total warnings: %d (errors: %d, warnings: %d)\n",
+		result.Summary.TotalWarnings, result.Summary.ErrorCount, result.Summary.WarningCount)
+}
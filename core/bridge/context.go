@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/neev-kit/neev/core/foundation"
+	"github.com/neev-kit/neev/core/frontmatter"
 )
 
 // BuildContext aggregates context from foundation and blueprints.
@@ -92,9 +93,24 @@ func readFilesInDir(dir string, builder *strings.Builder, focus string) error {
 			if err != nil {
 				return fmt.Errorf("failed to read file %s: %w", file.Name(), err)
 			}
-			if focus == "" || strings.Contains(string(content), focus) {
-				builder.WriteString(fmt.Sprintf("## File: %s\n%s\n", file.Name(), content))
+			if focus != "" && !strings.Contains(string(content), focus) {
+				continue
 			}
+
+			// Front matter is rendered as a metadata line; archived specs are left out
+			meta, body, err := frontmatter.Parse(content)
+			if err != nil {
+				meta, body = frontmatter.Metadata{}, content
+			}
+			if meta.Archived() {
+				continue
+			}
+
+			builder.WriteString(fmt.Sprintf("## File: %s\n", file.Name()))
+			if !meta.IsZero() {
+				builder.WriteString(fmt.Sprintf("_%s_\n\n", meta.Summary()))
+			}
+			builder.WriteString(fmt.Sprintf("%s\n", body))
 		}
 	}
 
@@ -364,6 +364,36 @@ func TestReadFilesInDir_WithFocus(t *testing.T) {
 	}
 }
 
+func TestReadFilesInDir_FrontMatter(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	billing := "---\ncode_paths: [services/billing]\nowners: [\"@payments\"]\n---\n# Billing\nbilling content"
+	if err := os.WriteFile(filepath.Join(tmpDir, "billing.md"), []byte(billing), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	legacy := "---\nstatus: archived\n---\n# Legacy\nlegacy content"
+	if err := os.WriteFile(filepath.Join(tmpDir, "legacy.md"), []byte(legacy), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	var builder strings.Builder
+	if err := readFilesInDir(tmpDir, &builder, ""); err != nil {
+		t.Fatalf("readFilesInDir failed: %v", err)
+	}
+
+	result := builder.String()
+	if !strings.Contains(result, "_code: services/billing | owners: @payments_") {
+		t.Errorf("Expected front matter summary in result, got:\n%s", result)
+	}
+	if strings.Contains(result, "code_paths:") {
+		t.Errorf("Expected raw front matter to be stripped")
+	}
+	if strings.Contains(result, "legacy content") {
+		t.Errorf("Did not expect archived spec in result")
+	}
+}
+
 func TestBuildContext_MultipleBlueprintDirs(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 
@@ -16,6 +17,42 @@ type Config struct {
 	IgnoreDirs     []string         `yaml:"ignore_dirs"`
 	FoundationPath string           `yaml:"foundation_path"`
 	Remotes        []remotes.Remote `yaml:"remotes,omitempty"`
+	Inspect        InspectConfig    `yaml:"inspect,omitempty"`
+	// Modules maps foundation modules to code path globs, e.g. billing: services/billing/**
+	Modules map[string]string `yaml:"modules,omitempty"`
+	// ModuleRoots lists directories whose subdirectories are modules, e.g. internal, pkg, packages
+	ModuleRoots []string `yaml:"module_roots,omitempty"`
+}
+
+// InspectConfig holds the drift detection policy used by neev inspect
+type InspectConfig struct {
+	// Rules override severities, disable warning types or scope them to module globs
+	Rules []InspectRule `yaml:"rules,omitempty"`
+	// FailOn is the lowest severity that makes --strict fail: error, warning or info (default)
+	FailOn string `yaml:"fail_on,omitempty"`
+	// Plugins are external detectors that speak JSON over stdio
+	Plugins []InspectPlugin `yaml:"plugins,omitempty"`
+}
+
+// InspectRule adjusts the warnings of one type. Warning types and severities
+// are checked by neev inspect, which defines them.
+type InspectRule struct {
+	Type     string   `yaml:"type"`               // Warning type, or "*" for every type
+	Severity string   `yaml:"severity,omitempty"` // error, warning or info
+	Disabled bool     `yaml:"disabled,omitempty"` // Drop matching warnings entirely
+	Modules  []string `yaml:"modules,omitempty"`  // Module globs the rule is scoped to; empty means all modules
+}
+
+// InspectPlugin is an external detector that speaks JSON over stdio. Its
+// fields are checked by neev inspect, which runs it.
+type InspectPlugin struct {
+	Name     string   `yaml:"name"`
+	Command  string   `yaml:"command"` // Executable; relative paths are resolved from the project root
+	Args     []string `yaml:"args,omitempty"`
+	Language string   `yaml:"language"`          // Language reported for the files it handles, e.g. "rpc"
+	Files    []string `yaml:"files"`             // Globs of files it handles; patterns without "/" match file names
+	Version  string   `yaml:"version,omitempty"` // Bump to invalidate cached results; defaults to the executable's modification time
+	Timeout  string   `yaml:"timeout,omitempty"` // Per-request timeout, e.g. "10s" (default 30s)
 }
 
 // DefaultConfig returns a Config with sensible defaults
@@ -125,6 +162,37 @@ func (c *Config) Validate() error {
 		remoteNames[remote.Name] = true
 	}
 
+	// Validate module mapping
+	for name, pattern := range c.Modules {
+		if err := validateCodePath(pattern); err != nil {
+			return fmt.Errorf("invalid path for module '%s': %w", name, err)
+		}
+	}
+	for _, root := range c.ModuleRoots {
+		if err := validateCodePath(root); err != nil {
+			return fmt.Errorf("invalid module root: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// validateCodePath checks that a module path glob stays inside the project
+func validateCodePath(pattern string) error {
+	if pattern == "" {
+		return fmt.Errorf("path cannot be empty")
+	}
+	if filepath.IsAbs(pattern) || strings.HasPrefix(pattern, "/") {
+		return fmt.Errorf("'%s' must be a relative path", pattern)
+	}
+	for _, segment := range strings.Split(filepath.ToSlash(pattern), "/") {
+		if segment == ".." {
+			return fmt.Errorf("'%s' must not leave the project directory", pattern)
+		}
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return fmt.Errorf("'%s' is not a valid glob: %w", pattern, err)
+	}
 	return nil
 }
 
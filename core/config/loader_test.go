@@ -339,3 +339,118 @@ func TestLoadConfigReadError(t *testing.T) {
 		t.Error("LoadConfig should error when neev.yaml is a directory")
 	}
 }
+
+func TestLoadConfigWithInspectRules(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "neev.yaml")
+
+	configContent := `project_name: TestProject
+foundation_path: .neev
+inspect:
+  fail_on: warning
+  rules:
+    - type: EXTRA_CODE
+      severity: error
+    - type: MISSING_MODULE
+      disabled: true
+      modules: ["legacy-*"]
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to create test config: %v", err)
+	}
+
+	cfg, err := LoadConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if cfg.Inspect.FailOn != "warning" {
+		t.Errorf("Expected fail_on 'warning', got '%s'", cfg.Inspect.FailOn)
+	}
+
+	if len(cfg.Inspect.Rules) != 2 {
+		t.Fatalf("Expected 2 inspect rules, got %d", len(cfg.Inspect.Rules))
+	}
+
+	if !cfg.Inspect.Rules[1].Disabled || cfg.Inspect.Rules[1].Modules[0] != "legacy-*" {
+		t.Errorf("Unexpected second rule: %+v", cfg.Inspect.Rules[1])
+	}
+}
+
+func TestLoadConfigWithInspectPlugins(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "neev.yaml")
+
+	configContent := `project_name: TestProject
+foundation_path: .neev
+inspect:
+  plugins:
+    - name: rpc
+      command: ./tools/rpc-detector
+      args: ["--json"]
+      language: rpc
+      files: ["**/*.rpc", "services/**/handlers.go"]
+      timeout: 10s
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to create test config: %v", err)
+	}
+
+	cfg, err := LoadConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if len(cfg.Inspect.Plugins) != 1 {
+		t.Fatalf("Expected 1 inspect plugin, got %d", len(cfg.Inspect.Plugins))
+	}
+	plugin := cfg.Inspect.Plugins[0]
+	if plugin.Command != "./tools/rpc-detector" || plugin.Language != "rpc" || len(plugin.Files) != 2 || plugin.Args[0] != "--json" {
+		t.Errorf("Unexpected plugin: %+v", plugin)
+	}
+}
+
+func TestLoadConfigWithModuleMapping(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "neev.yaml")
+
+	configContent := `project_name: TestProject
+foundation_path: .neev
+module_roots: [internal, pkg, packages]
+modules:
+  billing: services/billing/**
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to create test config: %v", err)
+	}
+
+	cfg, err := LoadConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if len(cfg.ModuleRoots) != 3 || cfg.ModuleRoots[2] != "packages" {
+		t.Errorf("Unexpected module roots: %v", cfg.ModuleRoots)
+	}
+	if cfg.Modules["billing"] != "services/billing/**" {
+		t.Errorf("Expected billing mapping, got %v", cfg.Modules)
+	}
+}
+
+func TestValidateInvalidModuleMapping(t *testing.T) {
+	tests := map[string]*Config{
+		"absolute path": {Modules: map[string]string{"billing": "/srv/billing"}},
+		"parent path":   {Modules: map[string]string{"billing": "../billing/**"}},
+		"empty path":    {Modules: map[string]string{"billing": ""}},
+		"bad glob":      {Modules: map[string]string{"billing": "services/[billing"}},
+		"escaping root": {ModuleRoots: []string{"internal/../../other"}},
+	}
+
+	for name, cfg := range tests {
+		cfg.ProjectName = "test"
+		cfg.FoundationPath = ".neev"
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+}
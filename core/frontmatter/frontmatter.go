@@ -0,0 +1,135 @@
+package frontmatter
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Spec status values with special meaning
+const (
+	StatusPlanned  = "planned"  // Code is not expected to exist yet
+	StatusDraft    = "draft"    // Same as planned
+	StatusArchived = "archived" // The spec no longer describes active code
+)
+
+// Metadata is the YAML front matter of a foundation or blueprint markdown file
+type Metadata struct {
+	CodePaths []string `yaml:"code_paths,omitempty"` // Code directories or globs the spec covers
+	Owners    []string `yaml:"owners,omitempty"`     // Teams or people responsible for the module
+	Status    string   `yaml:"status,omitempty"`     // e.g. active, planned, draft, archived
+	Languages []string `yaml:"languages,omitempty"`  // Languages the module is implemented in
+}
+
+// IsZero reports whether no front matter field is set
+func (m Metadata) IsZero() bool {
+	return len(m.CodePaths) == 0 && len(m.Owners) == 0 && m.Status == "" && len(m.Languages) == 0
+}
+
+// Archived reports whether the spec is archived and should be ignored
+func (m Metadata) Archived() bool {
+	return strings.EqualFold(m.Status, StatusArchived)
+}
+
+// Planned reports whether the spec describes code that is not expected to exist yet
+func (m Metadata) Planned() bool {
+	return strings.EqualFold(m.Status, StatusPlanned) || strings.EqualFold(m.Status, StatusDraft)
+}
+
+// Summary renders the metadata as a single line, e.g. "code: billing/ | owners: @payments"
+func (m Metadata) Summary() string {
+	var parts []string
+	if len(m.CodePaths) > 0 {
+		parts = append(parts, "code: "+strings.Join(m.CodePaths, ", "))
+	}
+	if len(m.Owners) > 0 {
+		parts = append(parts, "owners: "+strings.Join(m.Owners, ", "))
+	}
+	if m.Status != "" {
+		parts = append(parts, "status: "+m.Status)
+	}
+	if len(m.Languages) > 0 {
+		parts = append(parts, "languages: "+strings.Join(m.Languages, ", "))
+	}
+	return strings.Join(parts, " | ")
+}
+
+// Parse splits markdown content into its front matter and body. Content
+// without a leading "---" block is returned unchanged with empty metadata.
+func Parse(content []byte) (Metadata, []byte, error) {
+	var meta Metadata
+
+	front, body, ok := split(content)
+	if !ok {
+		return meta, content, nil
+	}
+	if err := yaml.Unmarshal(front, &meta); err != nil {
+		return Metadata{}, content, fmt.Errorf("failed to parse front matter: %w", err)
+	}
+	return meta, body, nil
+}
+
+// Decode unmarshals the front matter of markdown content into out, which lets
+// specs such as stack.md carry fields of their own. Content without front
+// matter leaves out unchanged.
+func Decode(content []byte, out interface{}) error {
+	front, _, ok := split(content)
+	if !ok {
+		return nil
+	}
+	if err := yaml.Unmarshal(front, out); err != nil {
+		return fmt.Errorf("failed to parse front matter: %w", err)
+	}
+	return nil
+}
+
+// split separates the front matter block from the body
+func split(content []byte) (front, body []byte, ok bool) {
+	rest, ok := cutDelimiter(content)
+	if !ok {
+		return nil, content, false
+	}
+
+	// Find the closing delimiter
+	offset := 0
+	for offset < len(rest) {
+		end := bytes.IndexByte(rest[offset:], '\n')
+		line := rest[offset:]
+		next := len(rest)
+		if end >= 0 {
+			line = rest[offset : offset+end]
+			next = offset + end + 1
+		}
+
+		if strings.TrimRight(string(line), " \t\r") == "---" {
+			return rest[:offset], rest[next:], true
+		}
+		offset = next
+	}
+
+	// No closing delimiter: treat the file as plain markdown
+	return nil, content, false
+}
+
+// ParseFile reads a markdown file and parses its front matter
+func ParseFile(path string) (Metadata, []byte, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return Metadata{}, nil, err
+	}
+	return Parse(content)
+}
+
+// cutDelimiter strips the opening "---" line, if present
+func cutDelimiter(content []byte) ([]byte, bool) {
+	content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf")) // UTF-8 BOM
+	for _, delim := range []string{"---\n", "---\r\n"} {
+		if bytes.HasPrefix(content, []byte(delim)) {
+			return content[len(delim):], true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,101 @@
+package frontmatter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	content := []byte(`---
+code_paths: [services/billing, libs/invoices]
+owners: ["@payments"]
+status: active
+languages: [go]
+---
+# Billing
+
+Handles invoices.
+`)
+
+	meta, body, err := Parse(content)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if len(meta.CodePaths) != 2 || meta.CodePaths[1] != "libs/invoices" {
+		t.Errorf("Unexpected code paths: %v", meta.CodePaths)
+	}
+	if len(meta.Owners) != 1 || meta.Owners[0] != "@payments" {
+		t.Errorf("Unexpected owners: %v", meta.Owners)
+	}
+	if meta.Status != "active" || meta.Archived() || meta.Planned() {
+		t.Errorf("Unexpected status: %q", meta.Status)
+	}
+	if !strings.HasPrefix(string(body), "# Billing") {
+		t.Errorf("Expected body to start after front matter, got %q", body)
+	}
+}
+
+func TestParse_NoFrontMatter(t *testing.T) {
+	tests := map[string]string{
+		"plain markdown":     "# Billing\n\n---\n\nA horizontal rule.\n",
+		"unclosed delimiter": "---\ncode_paths: [billing]\n# Billing\n",
+	}
+
+	for name, content := range tests {
+		meta, body, err := Parse([]byte(content))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+		if !meta.IsZero() {
+			t.Errorf("%s: expected empty metadata, got %+v", name, meta)
+		}
+		if string(body) != content {
+			t.Errorf("%s: expected content unchanged, got %q", name, body)
+		}
+	}
+}
+
+func TestParse_InvalidYAML(t *testing.T) {
+	content := "---\ncode_paths: [billing\n---\n# Billing\n"
+
+	if _, body, err := Parse([]byte(content)); err == nil {
+		t.Error("Expected an error for malformed front matter")
+	} else if string(body) != content {
+		t.Errorf("Expected content unchanged on error, got %q", body)
+	}
+}
+
+func TestMetadata_Status(t *testing.T) {
+	if !(Metadata{Status: "Archived"}).Archived() {
+		t.Error("Expected status to be case-insensitive")
+	}
+	if !(Metadata{Status: "draft"}).Planned() {
+		t.Error("Expected draft specs to count as planned")
+	}
+}
+
+func TestMetadata_Summary(t *testing.T) {
+	meta := Metadata{CodePaths: []string{"billing/"}, Owners: []string{"@payments"}}
+
+	if got := meta.Summary(); got != "code: billing/ | owners: @payments" {
+		t.Errorf("Summary() = %q", got)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	var stack struct {
+		Allowed []string `yaml:"allowed"`
+	}
+
+	if err := Decode([]byte("---\nallowed: [chi]\nowners: [\"@platform\"]\n---\n# Stack\n"), &stack); err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if len(stack.Allowed) != 1 || stack.Allowed[0] != "chi" {
+		t.Errorf("Unexpected allowed libraries: %v", stack.Allowed)
+	}
+
+	if err := Decode([]byte("# Stack\n"), &stack); err != nil || len(stack.Allowed) != 1 {
+		t.Errorf("Expected content without front matter to leave out unchanged, got %v (%v)", stack.Allowed, err)
+	}
+}
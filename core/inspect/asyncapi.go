@@ -0,0 +1,309 @@
+package inspect
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Topic is a message channel, either documented by an AsyncAPI spec or
+// produced or consumed in code
+type Topic struct {
+	Name string
+	Role string // "produce" or "consume" for code; empty for documented channels
+	File string
+	Line int
+}
+
+var (
+	// Go struct literals whose Topic field names the channel
+	goTopicStructPattern = regexp.MustCompile(`\b(kafka\.Writer|kafka\.Message|kafka\.ReaderConfig|sarama\.ProducerMessage)\s*\{`)
+	goTopicFieldPattern  = regexp.MustCompile(`\b(?:Topic\s*:\s*("[^"]*")|GroupTopics\s*:\s*\[\]string\s*\{([^}]*)\})`)
+
+	// String literals naming topics inside a matched call
+	topicLiteralPattern = regexp.MustCompile(`"([^"]*)"|'([^']*)'`)
+
+	// Imports of NATS, Kafka and Redis clients: Python, Java and C# import
+	// statements, and the quoted module paths of Go, JavaScript and Ruby
+	messagingImportPattern = regexp.MustCompile(`(?im)^\s*(?:import|from|using)\s+[\w.]*(?:nats|kafka|redis)|["'][\w@./-]*(?:nats|kafka|sarama|redis)[\w./-]*["']`)
+)
+
+// topicCallPatterns find producers and consumers by call. The last group holds
+// one or more string literals naming topics. Generic patterns name methods that
+// many unrelated libraries have, so they only apply in files that import a
+// messaging client.
+var topicCallPatterns = []struct {
+	role    string
+	pattern *regexp.Regexp
+	generic bool
+}{
+	// NATS nc.Publish("orders.created", data), Redis and Python clients
+	{"produce", regexp.MustCompile(`\.(?:Publish|PublishMsg|PublishAsync|publish)\(\s*("[^"]*"|'[^']*')`), true},
+	// Spring kafkaTemplate.send("orders", event)
+	{"produce", regexp.MustCompile(`[Tt]emplate\.send\(\s*("[^"]*")`), false},
+	// KafkaJS producer.send({ topic: 'orders', messages })
+	{"produce", regexp.MustCompile(`\.send\(\s*\{[^}]*?\btopic\s*:\s*("[^"]*"|'[^']*')`), false},
+	// kafka-python and confluent-kafka producer.send('orders', ...) / producer.produce('orders', ...)
+	{"produce", regexp.MustCompile(`[Pp]roducer\.(?:send|produce)\(\s*("[^"]*"|'[^']*')`), false},
+	// NATS nc.Subscribe("orders.*", handler), confluent Subscribe("orders", nil), consumer.subscribe(['orders'])
+	{"consume", regexp.MustCompile(`\.(?:Subscribe|QueueSubscribe|ChanSubscribe|SubscribeSync|QueueSubscribeSync|subscribe)\(\s*("[^"]*"|'[^']*'|\[[^\]]*\])`), true},
+	// confluent-kafka-go c.SubscribeTopics([]string{"orders"}, nil)
+	{"consume", regexp.MustCompile(`\.SubscribeTopics\(\s*\[\]string\s*\{([^}]*)\}`), false},
+	// KafkaJS consumer.subscribe({ topics: ['orders'] })
+	{"consume", regexp.MustCompile(`\.subscribe\(\s*\{[^}]*?\btopics?\s*:\s*(\[[^\]]*\]|"[^"]*"|'[^']*')`), false},
+	// Spring @KafkaListener(topics = "orders") or topics = {"a", "b"}
+	{"consume", regexp.MustCompile(`@KafkaListener\([^)]*?\btopics\s*=\s*(\{[^}]*\}|\[[^\]]*\]|"[^"]*")`), false},
+	// sarama consumer.ConsumePartition("orders", 0, offset)
+	{"consume", regexp.MustCompile(`\.ConsumePartition\(\s*("[^"]*")`), false},
+	// kafka-python KafkaConsumer('orders', 'payments', bootstrap_servers=...)
+	{"consume", regexp.MustCompile(`\bKafkaConsumer\(\s*((?:(?:"[^"]*"|'[^']*')\s*,?\s*)+)`), false},
+}
+
+// ValidateAsyncAPIContracts compares the channels of asyncapi.yaml files in
+// blueprints against the topics that code found by a repository scan produces
+// and consumes
+func ValidateAsyncAPIContracts(opts InspectOptions, scan *ScanResult) ([]Warning, error) {
+	var codeTopics []Topic
+	for _, file := range scan.Facts[topicExtractor.Name] {
+		for _, topic := range file.Facts.([]Topic) {
+			topic.File = file.Path
+			codeTopics = append(codeTopics, topic)
+		}
+	}
+
+	return compareTopics(collectSpecTopics(opts), codeTopics), nil
+}
+
+// collectSpecTopics gathers channels from asyncapi.yaml files in blueprints
+func collectSpecTopics(opts InspectOptions) []Topic {
+	var topics []Topic
+
+	files := specFiles(opts, []string{blueprintsDir(opts)}, func(path string) bool {
+		name := filepath.Base(path)
+		return name == "asyncapi.yaml" || name == "asyncapi.yml"
+	})
+	for _, path := range files {
+		if channels, err := parseAsyncAPIFile(path); err == nil {
+			topics = append(topics, channels...)
+		}
+	}
+
+	return topics
+}
+
+// parseAsyncAPIFile extracts the channels of an AsyncAPI 2.x or 3.x document.
+// A 3.x channel is named by its address when it has one.
+func parseAsyncAPIFile(filePath string) ([]Topic, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+
+	channels := yamlMapValue(doc.Content[0], "channels")
+	if channels == nil || channels.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+
+	var topics []Topic
+	for i := 0; i+1 < len(channels.Content); i += 2 {
+		key, channel := channels.Content[i], channels.Content[i+1]
+		name := key.Value
+		if address := yamlMapValue(channel, "address"); address != nil && address.Kind == yaml.ScalarNode && address.Tag != "!!null" {
+			name = address.Value
+		}
+		topics = append(topics, Topic{Name: name, File: filePath, Line: key.Line})
+	}
+	return topics, nil
+}
+
+// yamlMapValue returns the value of key in a mapping node, or nil
+func yamlMapValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// topicExtractor collects Kafka and NATS producers and consumers during the
+// repository scan
+var topicExtractor = FileExtractor{
+	Name:    "topics",
+	Version: "2",
+	Match: func(path string) bool {
+		return DetectLanguageByExtension(path) != ""
+	},
+	Extract: func(path string, content []byte) interface{} {
+		return ExtractTopics(path, string(content))
+	},
+	Decode: decodeFacts[[]Topic],
+}
+
+// ExtractTopics finds the topics a source file produces to or consumes from.
+// Topics built at runtime or taken from configuration placeholders are skipped.
+func ExtractTopics(filePath, text string) []Topic {
+	var topics []Topic
+
+	add := func(role, literals string, offset int) {
+		for _, literal := range topicLiteralPattern.FindAllStringSubmatch(literals, -1) {
+			name := literal[1] + literal[2]
+			if name == "" || strings.ContainsAny(name, "$%{}") {
+				continue
+			}
+			topics = append(topics, Topic{
+				Name: name,
+				Role: role,
+				File: filePath,
+				Line: strings.Count(text[:offset], "\n") + 1,
+			})
+		}
+	}
+
+	for _, match := range goTopicStructPattern.FindAllStringSubmatchIndex(text, -1) {
+		role := "produce"
+		if text[match[2]:match[3]] == "kafka.ReaderConfig" {
+			role = "consume"
+		}
+		open := match[1] - 1
+		end := matchingBrace(text, open)
+		if end < 0 {
+			continue
+		}
+		for _, field := range goTopicFieldPattern.FindAllStringSubmatchIndex(text[open:end], -1) {
+			for group := 2; group < 6; group += 2 {
+				if field[group] >= 0 {
+					add(role, text[open+field[group]:open+field[group+1]], open+field[0])
+				}
+			}
+		}
+	}
+
+	messaging := messagingImportPattern.MatchString(text)
+	for _, call := range topicCallPatterns {
+		if call.generic && !messaging {
+			continue
+		}
+		for _, match := range call.pattern.FindAllStringSubmatchIndex(text, -1) {
+			last := len(match) - 2
+			add(call.role, text[match[last]:match[last+1]], match[0])
+		}
+	}
+
+	sort.SliceStable(topics, func(i, j int) bool { return topics[i].Line < topics[j].Line })
+	return topics
+}
+
+// topicPattern compiles a topic into a regular expression. AsyncAPI
+// parameters ({userId}) and NATS wildcards (* for one token, > for the rest)
+// match any value. Topics without any are matched literally and yield nil.
+func topicPattern(topic string) *regexp.Regexp {
+	if !strings.ContainsAny(topic, "{*>") {
+		return nil
+	}
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(topic); i++ {
+		switch c := topic[i]; {
+		case c == '{':
+			if end := strings.IndexByte(topic[i:], '}'); end > 0 {
+				b.WriteString(`[^./]+`)
+				i += end
+				continue
+			}
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		case c == '*' && (i == 0 || topic[i-1] == '.') && (i+1 == len(topic) || topic[i+1] == '.'):
+			b.WriteString(`[^.]+`)
+		case c == '>' && i+1 == len(topic) && (i == 0 || topic[i-1] == '.'):
+			b.WriteString(`.+`)
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+// topicMatcher matches documented channels with code topics, compiling the
+// pattern of each topic once
+type topicMatcher map[string]*regexp.Regexp
+
+// pattern returns the compiled pattern of a topic
+func (m topicMatcher) pattern(topic string) *regexp.Regexp {
+	pattern, ok := m[topic]
+	if !ok {
+		pattern = topicPattern(topic)
+		m[topic] = pattern
+	}
+	return pattern
+}
+
+// match reports whether a documented channel and a code topic name the same
+// channel, allowing for parameters and wildcards on either side
+func (m topicMatcher) match(spec, code string) bool {
+	if spec == code {
+		return true
+	}
+	if pattern := m.pattern(spec); pattern != nil && pattern.MatchString(code) {
+		return true
+	}
+	pattern := m.pattern(code)
+	return pattern != nil && pattern.MatchString(spec)
+}
+
+// compareTopics reports documented channels no code produces or consumes, and
+// topics used in code that no spec documents
+func compareTopics(specTopics, codeTopics []Topic) []Warning {
+	var warnings []Warning
+	unused, undocumented := contractDiff(specTopics, codeTopics, func(t Topic) string { return t.Name }, topicMatcher{}.match)
+
+	// Documented but never produced or consumed
+	for _, spec := range unused {
+		warnings = append(warnings, Warning{
+			Type:        WarningMissingTopic,
+			Module:      "api",
+			Subject:     spec.Name,
+			Message:     fmt.Sprintf("Topic '%s' is documented in %s but never produced or consumed", spec.Name, filepath.Base(spec.File)),
+			Severity:    "error",
+			Remediation: fmt.Sprintf("Produce or consume '%s' or remove the channel from the AsyncAPI spec", spec.Name),
+			File:        spec.File,
+			Line:        spec.Line,
+		})
+	}
+
+	// Produced or consumed but not documented
+	for _, code := range undocumented {
+		verb := "produced"
+		if code.Role == "consume" {
+			verb = "consumed"
+		}
+		warnings = append(warnings, Warning{
+			Type:        WarningUndocumentedTopic,
+			Module:      "api",
+			Subject:     code.Name,
+			Message:     fmt.Sprintf("Topic '%s' is %s but not documented (found in %s:%d)", code.Name, verb, filepath.Base(code.File), code.Line),
+			Severity:    "warning",
+			Remediation: fmt.Sprintf("Add a channel for '%s' to the blueprint's asyncapi.yaml", code.Name),
+			File:        code.File,
+			Line:        code.Line,
+		})
+	}
+
+	return warnings
+}
@@ -0,0 +1,172 @@
+package inspect
+
+import (
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestExtractTopics(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want []string
+	}{
+		{
+			name: "kafka-go",
+			code: `w := &kafka.Writer{
+	Addr:     kafka.TCP("localhost:9092"),
+	Balancer: &kafka.LeastBytes{},
+	Topic:    "orders.created",
+}
+r := kafka.NewReader(kafka.ReaderConfig{
+	Brokers:     brokers,
+	GroupTopics: []string{"payments.settled", "payments.failed"},
+})
+msg := kafka.Message{Topic: topicFor(order), Value: body}`,
+			want: []string{"produce orders.created 4", "consume payments.settled 8", "consume payments.failed 8"},
+		},
+		{
+			name: "nats",
+			code: `import "github.com/nats-io/nats.go"
+
+nc.Publish("users.created", data)
+sub, _ := nc.QueueSubscribe("users.*", "workers", handle)
+js.Subscribe(subject, handle)`,
+			want: []string{"produce users.created 3", "consume users.* 4"},
+		},
+		{
+			name: "spring",
+			code: `@KafkaListener(topics = {"invoices", "refunds"}, groupId = "billing")
+public void onInvoice(Invoice invoice) {
+    kafkaTemplate.send("invoices.processed", invoice.getId());
+}
+
+@KafkaListener(topics = "${app.topics.audit}")
+public void onAudit(String event) {}`,
+			want: []string{"consume invoices 1", "consume refunds 1", "produce invoices.processed 3"},
+		},
+		{
+			name: "kafkajs",
+			code: `await producer.send({ topic: 'signups', messages: [{ value: body }] })
+await consumer.subscribe({ topics: ['emails', 'sms'], fromBeginning: true })`,
+			want: []string{"produce signups 1", "consume emails 2", "consume sms 2"},
+		},
+		{
+			name: "python",
+			code: `import nats
+consumer = KafkaConsumer('clicks', 'views', bootstrap_servers=servers)
+producer.send('clicks.enriched', value=event)
+await nc.subscribe("metrics.>", cb=handler)`,
+			want: []string{"consume clicks 2", "consume views 2", "produce clicks.enriched 3", "consume metrics.> 4"},
+		},
+		{
+			name: "no messaging client",
+			code: `import { EventEmitter } from 'events'
+bus.publish('clicked', event)
+store.subscribe('cart', render)`,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, topic := range ExtractTopics("code", tt.code) {
+				got = append(got, topic.Role+" "+topic.Name+" "+strconv.Itoa(topic.Line))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTopicsMatch(t *testing.T) {
+	tests := []struct {
+		spec, code string
+		want       bool
+	}{
+		{"orders.created", "orders.created", true},
+		{"users.{userId}.events", "users.42.events", true},
+		{"orders.created", "orders.*", true},
+		{"metrics.cpu.load", "metrics.>", true},
+		{"orders.created", "orders.updated", false},
+		{"orders.created", "orders", false},
+		{"users.{userId}.events", "users.events", false},
+	}
+	for _, tt := range tests {
+		if got := (topicMatcher{}).match(tt.spec, tt.code); got != tt.want {
+			t.Errorf("match(%q, %q) = %v, want %v", tt.spec, tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestInspect_AsyncAPIContracts(t *testing.T) {
+	rootDir := t.TempDir()
+	writeFiles(t, rootDir, map[string]string{
+		".neev/foundation/events.md": "# Events\n",
+		".neev/blueprints/orders/asyncapi.yaml": `asyncapi: 3.0.0
+info:
+  title: Orders
+  version: 1.0.0
+channels:
+  orderCreated:
+    address: orders.created
+  orderShipped:
+    address: orders.{orderId}.shipped
+  legacy:
+    address: null
+`,
+		"orders/events.go": `package orders
+
+import "github.com/nats-io/nats.go"
+
+func publish(nc *nats.Conn) {
+	nc.Publish("orders.created", nil)
+	nc.Publish("orders.cancelled", nil)
+}
+`,
+	})
+
+	result, err := Inspect(InspectOptions{
+		RootDir:        rootDir,
+		FoundationPath: filepath.Join(rootDir, ".neev", "foundation"),
+		IgnoreDirs:     map[string]bool{},
+		Depth:          2,
+	})
+	if err != nil {
+		t.Fatalf("Inspect failed: %v", err)
+	}
+
+	bySubject := make(map[string]Warning)
+	for _, w := range result.Warnings {
+		if w.Type.Check() == CheckEvents {
+			bySubject[w.Subject] = w
+		}
+	}
+	if w, ok := bySubject["orders.{orderId}.shipped"]; !ok || w.Type != WarningMissingTopic || w.Line != 8 {
+		t.Errorf("Expected orders.{orderId}.shipped to be a missing topic, got %+v", w)
+	}
+	if w, ok := bySubject["legacy"]; !ok || w.Type != WarningMissingTopic {
+		t.Errorf("Expected a channel without address to be named by its key, got %+v", w)
+	}
+	if w, ok := bySubject["orders.cancelled"]; !ok || w.Type != WarningUndocumentedTopic || w.Severity != "warning" {
+		t.Errorf("Expected orders.cancelled to be undocumented, got %+v", w)
+	}
+	if len(bySubject) != 3 {
+		t.Errorf("Expected 3 topic warnings, got %v", bySubject)
+	}
+	if result.Summary.MissingTopics != 2 || result.Summary.UndocumentedTopics != 1 {
+		t.Errorf("Expected 2 missing and 1 undocumented topics, got %+v", result.Summary)
+	}
+
+	ran := false
+	for _, check := range result.Checks {
+		ran = ran || check == CheckEvents
+	}
+	if !ran || CheckEvents.PerModule() {
+		t.Errorf("Expected the API-level events check to run, got %v", result.Checks)
+	}
+}
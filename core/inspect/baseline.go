@@ -0,0 +1,144 @@
+package inspect
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+)
+
+// DefaultBaselineFile is the baseline location relative to the project root
+const DefaultBaselineFile = ".neev/inspect-baseline.json"
+
+// baselineVersion is the current baseline file format version
+const baselineVersion = 1
+
+// Baseline records accepted drift so later inspections only report new warnings
+type Baseline struct {
+	Version int             `json:"version"`
+	Entries []BaselineEntry `json:"entries"`
+}
+
+// BaselineEntry is a single accepted warning
+type BaselineEntry struct {
+	Fingerprint string      `json:"fingerprint"`
+	Type        WarningType `json:"type"`
+	Module      string      `json:"module"`
+	Subject     string      `json:"subject"`
+}
+
+// locationSuffix matches the "(in file.go:12)" / "(found in file.go:12)" suffix of messages
+var locationSuffix = regexp.MustCompile(`\s*\((?:found )?in [^)]*:\d+\)`)
+
+// Fingerprint returns a stable identifier for a warning built from its type,
+// module and subject. Warnings without a subject fall back to their message
+// with any file:line location removed, so moving code does not change it.
+func Fingerprint(w Warning) string {
+	h := sha256.Sum256([]byte(string(w.Type) + "\x00" + w.Module + "\x00" + warningSubject(w)))
+	return hex.EncodeToString(h[:8])
+}
+
+// warningSubject returns the subject used for fingerprints and suppressions
+func warningSubject(w Warning) string {
+	if w.Subject != "" {
+		return w.Subject
+	}
+	return locationSuffix.ReplaceAllString(w.Message, "")
+}
+
+// NewBaseline records the warnings of an inspection result. Invalid
+// suppressions and drift whose suppression expired are left out, so an
+// expiry date cannot be bypassed by re-writing the baseline.
+func NewBaseline(result *InspectResult) *Baseline {
+	baseline := &Baseline{Version: baselineVersion, Entries: []BaselineEntry{}}
+	seen := make(map[string]bool)
+
+	for _, w := range result.Warnings {
+		if w.Type == WarningInvalidSuppression || w.suppressionExpired {
+			continue
+		}
+		fingerprint := Fingerprint(w)
+		if seen[fingerprint] {
+			continue
+		}
+		seen[fingerprint] = true
+
+		baseline.Entries = append(baseline.Entries, BaselineEntry{
+			Fingerprint: fingerprint,
+			Type:        w.Type,
+			Module:      w.Module,
+			Subject:     warningSubject(w),
+		})
+	}
+
+	// Keep the file diff-friendly
+	sort.Slice(baseline.Entries, func(i, j int) bool {
+		a, b := baseline.Entries[i], baseline.Entries[j]
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		if a.Module != b.Module {
+			return a.Module < b.Module
+		}
+		return a.Subject < b.Subject
+	})
+
+	return baseline
+}
+
+// LoadBaseline reads a baseline file
+func LoadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var baseline Baseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline: %w", err)
+	}
+
+	return &baseline, nil
+}
+
+// SaveBaseline writes a baseline file, creating its directory if needed
+func SaveBaseline(path string, baseline *Baseline) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create baseline directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal baseline: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write baseline: %w", err)
+	}
+
+	return nil
+}
+
+// Filter returns the warnings of result that are not in the baseline and
+// records how many were hidden in result.Summary.Baselined
+func (b *Baseline) Filter(result *InspectResult) []Warning {
+	known := make(map[string]bool, len(b.Entries))
+	for _, entry := range b.Entries {
+		known[entry.Fingerprint] = true
+	}
+
+	remaining := []Warning{}
+	for _, w := range result.Warnings {
+		if known[Fingerprint(w)] && !w.suppressionExpired {
+			result.Summary.Baselined++
+			continue
+		}
+		remaining = append(remaining, w)
+	}
+
+	return remaining
+}
@@ -0,0 +1,168 @@
+package inspect
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestFingerprint_IgnoresLocation(t *testing.T) {
+	a := Warning{
+		Type:    WarningSignatureMismatch,
+		Module:  "users",
+		Message: "Function 'Create' has 2 parameters but expected 3 (in service.go:10)",
+	}
+	b := a
+	b.Message = "Function 'Create' has 2 parameters but expected 3 (in service.go:42)"
+
+	if Fingerprint(a) != Fingerprint(b) {
+		t.Errorf("Expected fingerprints to ignore line numbers")
+	}
+
+	c := a
+	c.Module = "billing"
+	if Fingerprint(a) == Fingerprint(c) {
+		t.Errorf("Expected fingerprints to differ by module")
+	}
+}
+
+func TestBaseline_RoundTripAndFilter(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, ".neev", "inspect-baseline.json")
+
+	known := Warning{Type: WarningExtraCode, Module: "legacy", Subject: "legacy", Severity: "info"}
+	result := &InspectResult{Warnings: []Warning{known}}
+
+	if err := SaveBaseline(path, NewBaseline(result)); err != nil {
+		t.Fatalf("SaveBaseline failed: %v", err)
+	}
+
+	baseline, err := LoadBaseline(path)
+	if err != nil {
+		t.Fatalf("LoadBaseline failed: %v", err)
+	}
+	if len(baseline.Entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(baseline.Entries))
+	}
+
+	fresh := Warning{Type: WarningMissingModule, Module: "auth", Subject: "auth", Severity: "warning"}
+	next := &InspectResult{Warnings: []Warning{known, fresh}}
+	remaining := baseline.Filter(next)
+
+	if len(remaining) != 1 || remaining[0].Module != "auth" {
+		t.Errorf("Expected only the new warning to remain, got %+v", remaining)
+	}
+	if next.Summary.Baselined != 1 {
+		t.Errorf("Baselined = %d, want 1", next.Summary.Baselined)
+	}
+}
+
+func TestInspect_BaselineAndSuppressions(t *testing.T) {
+	tmpDir := t.TempDir()
+	foundationDir := filepath.Join(tmpDir, ".neev", "foundation")
+	os.MkdirAll(foundationDir, 0755)
+	os.MkdirAll(filepath.Join(tmpDir, "legacy"), 0755)
+	os.MkdirAll(filepath.Join(tmpDir, "scripts"), 0755)
+	os.MkdirAll(filepath.Join(tmpDir, "tools"), 0755)
+
+	os.WriteFile(filepath.Join(foundationDir, "legacy.module.yaml"), []byte(`name: legacy
+suppressions:
+  - type: EXTRA_CODE
+    reason: Being removed in Q3
+    expires: "2026-12-31"
+`), 0644)
+	os.WriteFile(filepath.Join(foundationDir, "scripts.module.yaml"), []byte(`name: scripts
+suppressions:
+  - type: EXTRA_CODE
+    reason: Old exemption
+    expires: "2026-01-01"
+`), 0644)
+
+	original := timeNow
+	timeNow = func() time.Time { return time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC) }
+	defer func() { timeNow = original }()
+
+	baselinePath := filepath.Join(tmpDir, DefaultBaselineFile)
+	opts := InspectOptions{
+		RootDir:        tmpDir,
+		FoundationPath: foundationDir,
+		IgnoreDirs:     map[string]bool{},
+		BaselinePath:   baselinePath,
+	}
+
+	result, err := Inspect(opts)
+	if err != nil {
+		t.Fatalf("Inspect failed: %v", err)
+	}
+
+	// legacy is suppressed; scripts' suppression expired; tools has no suppression
+	if result.Summary.Suppressed != 1 {
+		t.Errorf("Suppressed = %d, want 1", result.Summary.Suppressed)
+	}
+	var extra, invalid int
+	for _, w := range result.Warnings {
+		switch w.Type {
+		case WarningExtraCode:
+			extra++
+			if w.Module == "legacy" {
+				t.Errorf("Expected legacy EXTRA_CODE to be suppressed")
+			}
+		case WarningInvalidSuppression:
+			invalid++
+		}
+	}
+	if extra != 2 || invalid != 1 {
+		t.Errorf("Got %d EXTRA_CODE and %d INVALID_SUPPRESSION warnings, want 2 and 1", extra, invalid)
+	}
+
+	if err := SaveBaseline(baselinePath, NewBaseline(result)); err != nil {
+		t.Fatalf("SaveBaseline failed: %v", err)
+	}
+
+	os.MkdirAll(filepath.Join(tmpDir, "newcode"), 0755)
+
+	result, err = Inspect(opts)
+	if err != nil {
+		t.Fatalf("Inspect failed: %v", err)
+	}
+	// scripts' drift and its expired suppression are never accepted by the baseline
+	var modules []string
+	for _, w := range result.Warnings {
+		modules = append(modules, string(w.Type)+" "+w.Module)
+	}
+	sort.Strings(modules)
+	want := []string{"EXTRA_CODE newcode", "EXTRA_CODE scripts", "INVALID_SUPPRESSION scripts"}
+	if !reflect.DeepEqual(modules, want) {
+		t.Errorf("Expected %v after baseline, got %v", want, modules)
+	}
+	if result.Summary.Baselined != 1 || result.Summary.TotalWarnings != 3 {
+		t.Errorf("Baselined = %d, TotalWarnings = %d, want 1 and 3", result.Summary.Baselined, result.Summary.TotalWarnings)
+	}
+}
+
+func TestApplySuppressions_RepositoryWideChecks(t *testing.T) {
+	result := &InspectResult{Warnings: []Warning{
+		{Type: WarningUndocumentedEndpoint, Module: "api", Subject: "GET /internal/health"},
+		{Type: WarningUndocumentedEndpoint, Module: "api", Subject: "GET /users"},
+		{Type: WarningMissingFile, Module: "api", Subject: "api/openapi.yaml"},
+	}}
+	suppressions := []descriptorSuppression{
+		// Defaults to the ops module, but endpoints report against "api"
+		{Suppression: Suppression{Type: WarningUndocumentedEndpoint, Module: "ops", Match: "GET /internal/*", Reason: "Probes", Expires: "2026-12-31"}, defaultModule: true},
+		// Without a type, a defaulted module only covers its own warnings
+		{Suppression: Suppression{Module: "ops", Reason: "Legacy", Expires: "2026-12-31"}, defaultModule: true},
+	}
+
+	remaining := applySuppressions(result, suppressions, time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC))
+	var subjects []string
+	for _, w := range remaining {
+		subjects = append(subjects, w.Subject)
+	}
+	want := []string{"GET /users", "api/openapi.yaml"}
+	if !reflect.DeepEqual(subjects, want) {
+		t.Errorf("Expected %v, got %v", want, subjects)
+	}
+}
@@ -0,0 +1,253 @@
+package inspect
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/neev-kit/neev/core/openapi"
+)
+
+// Scenario is a Gherkin scenario and the endpoints its title and steps reference
+type Scenario struct {
+	Name      string
+	File      string
+	Line      int
+	Endpoints []Endpoint // Method and Path only
+}
+
+// TestCoverage summarizes how documented endpoints map to BDD scenarios
+type TestCoverage struct {
+	Scenarios       int
+	TestedEndpoints int
+}
+
+// scenarioEndpointRe matches endpoint references such as `When I GET to "/api/users"`
+// or a scenario titled "POST /api/users"
+var scenarioEndpointRe = regexp.MustCompile(`\b(GET|POST|PUT|DELETE|PATCH|HEAD|OPTIONS)\b\s+(?:(?:request\s+)?to\s+)?["'\x60]?(/[^\s"'\x60]*)`)
+
+// ValidateTestCoverage maps scenarios in .feature files to documented endpoints.
+// Feature files are those found by a repository scan and those in
+// .neev/blueprints/*/tests, where `neev cucumber` writes them.
+func ValidateTestCoverage(opts InspectOptions, scan *ScanResult) ([]Warning, TestCoverage, error) {
+	var warnings []Warning
+	var coverage TestCoverage
+
+	scenarios := findScenarios(opts, scan)
+	coverage.Scenarios = len(scenarios)
+
+	specEndpoints := collectSpecEndpoints(opts)
+
+	// Documented endpoints without any scenario
+	seen := make(map[string]bool)
+	for _, specEp := range specEndpoints {
+		key := fmt.Sprintf("%s %s", specEp.Method, normalizePath(specEp.Path))
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if scenarioCovers(scenarios, specEp) {
+			coverage.TestedEndpoints++
+			continue
+		}
+
+		warnings = append(warnings, Warning{
+			Type:        WarningUntestedEndpoint,
+			Module:      "api",
+			Subject:     key,
+			Message:     fmt.Sprintf("API endpoint %s %s is documented but no BDD scenario exercises it", specEp.Method, specEp.Path),
+			Severity:    "warning",
+			Remediation: "Add a scenario for the endpoint or regenerate tests with `neev cucumber <blueprint>`",
+		})
+	}
+
+	// Scenarios exercising endpoints that are not documented
+	for _, scenario := range scenarios {
+		for _, ep := range scenario.Endpoints {
+			if specDocuments(specEndpoints, ep) {
+				continue
+			}
+			warnings = append(warnings, Warning{
+				Type:        WarningOrphanedScenario,
+				Module:      "api",
+				Subject:     fmt.Sprintf("%s %s", ep.Method, normalizePath(ep.Path)),
+				Message:     fmt.Sprintf("Scenario '%s' references undocumented endpoint %s %s (in %s:%d)", scenario.Name, ep.Method, ep.Path, filepath.Base(scenario.File), scenario.Line),
+				Severity:    "warning",
+				Remediation: fmt.Sprintf("Document %s %s in the API spec or remove the scenario", ep.Method, ep.Path),
+				File:        scenario.File,
+				Line:        scenario.Line,
+			})
+		}
+	}
+
+	return warnings, coverage, nil
+}
+
+// featureExtractor reads the scenarios of .feature files during the repository scan
+var featureExtractor = FileExtractor{
+	Name:    "features",
+	Version: "1",
+	Match:   isFeatureFile,
+	Extract: func(path string, content []byte) interface{} {
+		return ParseFeatureScenarios(path, content)
+	},
+	Decode: decodeFacts[[]Scenario],
+}
+
+// isFeatureFile reports whether a file is a Gherkin .feature file
+func isFeatureFile(path string) bool {
+	return strings.HasSuffix(strings.ToLower(path), ".feature")
+}
+
+// findScenarios returns the scenarios of .feature files in the code tree and
+// blueprint test directories, ordered by file
+func findScenarios(opts InspectOptions, scan *ScanResult) []Scenario {
+	byFile := make(map[string][]Scenario)
+	for _, file := range scan.Facts[featureExtractor.Name] {
+		for _, scenario := range file.Facts.([]Scenario) {
+			scenario.File = file.Path
+			byFile[file.Path] = append(byFile[file.Path], scenario)
+		}
+	}
+	for _, path := range specFiles(opts, []string{blueprintsDir(opts)}, isFeatureFile) {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue // Skip files we can't read
+		}
+		byFile[path] = ParseFeatureScenarios(path, content)
+	}
+
+	var files []string
+	for file := range byFile {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	var scenarios []Scenario
+	for _, file := range files {
+		scenarios = append(scenarios, byFile[file]...)
+	}
+	return scenarios
+}
+
+// ParseFeatureScenarios extracts scenarios from Gherkin content. Endpoints are
+// taken from the scenario title and its steps; Background steps are ignored.
+func ParseFeatureScenarios(filePath string, content []byte) []Scenario {
+	var scenarios []Scenario
+	var current *Scenario
+	seen := make(map[string]bool)
+
+	flush := func() {
+		if current != nil {
+			scenarios = append(scenarios, *current)
+		}
+		current = nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	lineNum := 0
+	inDocString := false
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+
+		// Skip doc string payloads
+		if strings.HasPrefix(line, `"""`) || strings.HasPrefix(line, "```") {
+			inDocString = !inDocString
+			continue
+		}
+		if inDocString || line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		keyword, rest, hasKeyword := strings.Cut(line, ":")
+		if hasKeyword {
+			switch strings.TrimSpace(keyword) {
+			case "Scenario", "Scenario Outline", "Scenario Template", "Example":
+				flush()
+				current = &Scenario{Name: strings.TrimSpace(rest), File: filePath, Line: lineNum}
+				seen = make(map[string]bool)
+				addScenarioEndpoints(current, rest, seen)
+				continue
+			case "Feature", "Background", "Rule":
+				flush()
+				continue
+			case "Examples", "Scenarios":
+				continue
+			}
+		}
+
+		if current != nil {
+			addScenarioEndpoints(current, line, seen)
+		}
+	}
+	flush()
+
+	return scenarios
+}
+
+// addScenarioEndpoints records endpoint references found in text
+func addScenarioEndpoints(scenario *Scenario, text string, seen map[string]bool) {
+	for _, match := range scenarioEndpointRe.FindAllStringSubmatch(text, -1) {
+		ep := Endpoint{Method: match[1], Path: strings.TrimRight(match[2], ".,;")}
+		key := ep.Method + " " + ep.Path
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		scenario.Endpoints = append(scenario.Endpoints, ep)
+	}
+}
+
+// scenarioCovers reports whether any scenario exercises the documented endpoint
+func scenarioCovers(scenarios []Scenario, specEp openapi.Endpoint) bool {
+	for _, scenario := range scenarios {
+		for _, ep := range scenario.Endpoints {
+			if ep.Method == specEp.Method && pathMatchesTemplate(specEp.Path, ep.Path) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// specDocuments reports whether a scenario endpoint is documented
+func specDocuments(specEndpoints []openapi.Endpoint, ep Endpoint) bool {
+	for _, specEp := range specEndpoints {
+		if specEp.Method == ep.Method && pathMatchesTemplate(specEp.Path, ep.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+// pathMatchesTemplate matches a concrete or templated path against a documented
+// path template; any parameter segment ({id}, :id, <id>) matches any value
+func pathMatchesTemplate(template, path string) bool {
+	templateParts := strings.Split(strings.Trim(normalizePath(template), "/"), "/")
+	pathParts := strings.Split(strings.Trim(normalizePath(strings.SplitN(path, "?", 2)[0]), "/"), "/")
+	if len(templateParts) != len(pathParts) {
+		return false
+	}
+
+	for i := range templateParts {
+		if isPathParam(templateParts[i]) || isPathParam(pathParts[i]) {
+			continue
+		}
+		if templateParts[i] != pathParts[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// isPathParam reports whether a normalized path segment is a parameter
+func isPathParam(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
@@ -0,0 +1,143 @@
+package inspect
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseFeatureScenarios(t *testing.T) {
+	content := []byte(`Feature: Users API
+
+  Background:
+    Given the API is available
+    When I GET to "/health"
+
+  Scenario: GET /api/users
+    When I GET to "/api/users"
+    Then the response status should be 200
+
+  Scenario Outline: Delete a user
+    When I DELETE to "/api/users/<id>"
+    And I send the following JSON payload:
+      """
+      {"note": "POST /ignored"}
+      """
+
+    Examples:
+      | id |
+      | 1  |
+`)
+
+	scenarios := ParseFeatureScenarios("api.feature", content)
+	if len(scenarios) != 2 {
+		t.Fatalf("Expected 2 scenarios, got %d: %+v", len(scenarios), scenarios)
+	}
+
+	first := scenarios[0]
+	if first.Name != "GET /api/users" || first.Line != 7 {
+		t.Errorf("Unexpected first scenario %q at line %d", first.Name, first.Line)
+	}
+	if len(first.Endpoints) != 1 || first.Endpoints[0].Path != "/api/users" {
+		t.Errorf("Expected title and step to yield one endpoint, got %+v", first.Endpoints)
+	}
+
+	second := scenarios[1]
+	if len(second.Endpoints) != 1 || second.Endpoints[0].Method != "DELETE" || second.Endpoints[0].Path != "/api/users/<id>" {
+		t.Errorf("Expected DELETE /api/users/<id> only, got %+v", second.Endpoints)
+	}
+}
+
+func TestPathMatchesTemplate(t *testing.T) {
+	tests := []struct {
+		template string
+		path     string
+		want     bool
+	}{
+		{"/api/users/{id}", "/api/users/42", true},
+		{"/api/users/:id", "/api/users/<id>", true},
+		{"/api/users", "/api/users?page=2", true},
+		{"/api/users/{id}", "/api/users", false},
+		{"/api/users/{id}/orders", "/api/users/1/items", false},
+	}
+
+	for _, tt := range tests {
+		if got := pathMatchesTemplate(tt.template, tt.path); got != tt.want {
+			t.Errorf("pathMatchesTemplate(%q, %q) = %v, want %v", tt.template, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestValidateTestCoverage(t *testing.T) {
+	tmpDir := t.TempDir()
+	blueprintDir := filepath.Join(tmpDir, ".neev", "blueprints", "users")
+	testsDir := filepath.Join(blueprintDir, "tests")
+	if err := os.MkdirAll(testsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	architecture := `# Users
+
+### GET /api/users
+List users.
+
+### GET /api/users/{id}
+Get a user.
+
+### DELETE /api/users/{id}
+Delete a user.
+`
+	if err := os.WriteFile(filepath.Join(blueprintDir, "architecture.md"), []byte(architecture), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	feature := `Feature: Users
+
+  Scenario: GET /api/users
+    When I GET to "/api/users"
+
+  Scenario: Fetch one user
+    When I GET to "/api/users/7"
+
+  Scenario: Export users
+    When I POST to "/api/users/export"
+`
+	if err := os.WriteFile(filepath.Join(testsDir, "api.feature"), []byte(feature), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := InspectOptions{
+		RootDir:        tmpDir,
+		FoundationPath: filepath.Join(tmpDir, ".neev", "foundation"),
+		IgnoreDirs:     map[string]bool{},
+	}
+
+	warnings, coverage, err := ValidateTestCoverage(opts, scanFacts(t, tmpDir, opts.IgnoreDirs, featureExtractor))
+	if err != nil {
+		t.Fatalf("ValidateTestCoverage failed: %v", err)
+	}
+
+	if coverage.Scenarios != 3 {
+		t.Errorf("Scenarios = %d, want 3", coverage.Scenarios)
+	}
+	if coverage.TestedEndpoints != 2 {
+		t.Errorf("TestedEndpoints = %d, want 2", coverage.TestedEndpoints)
+	}
+
+	var untested, orphaned []Warning
+	for _, w := range warnings {
+		switch w.Type {
+		case WarningUntestedEndpoint:
+			untested = append(untested, w)
+		case WarningOrphanedScenario:
+			orphaned = append(orphaned, w)
+		}
+	}
+
+	if len(untested) != 1 || untested[0].Subject != "DELETE /api/users/{id}" {
+		t.Errorf("Expected DELETE /api/users/{id} to be untested, got %+v", untested)
+	}
+	if len(orphaned) != 1 || orphaned[0].Subject != "POST /api/users/export" || orphaned[0].Line != 9 {
+		t.Errorf("Expected orphaned POST /api/users/export at line 9, got %+v", orphaned)
+	}
+}
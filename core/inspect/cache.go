@@ -0,0 +1,168 @@
+package inspect
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// DefaultCacheDir is the analysis cache location relative to the project root
+const DefaultCacheDir = ".neev/cache"
+
+// cacheFileName is the analysis cache file inside the cache directory
+const cacheFileName = "inspect.json"
+
+// cacheVersion is the current cache file format version. Bump it, or
+// builtinDetectorVersion, when cached extraction results would change.
+const cacheVersion = 2
+
+// builtinDetectorVersion versions the extraction logic of the built-in detectors
+const builtinDetectorVersion = "5"
+
+// VersionedDetector is implemented by detectors that version their extraction
+// logic; cached results from another version are discarded
+type VersionedDetector interface {
+	Version() string
+}
+
+// AnalysisCache stores extracted endpoints and functions per file, keyed by
+// path and content hash, so unchanged files are not parsed again
+type AnalysisCache struct {
+	path    string
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	seen    map[string]bool
+	dirty   bool
+}
+
+// cacheFile is the on-disk format of the analysis cache
+type cacheFile struct {
+	Version int                   `json:"version"`
+	Entries map[string]cacheEntry `json:"entries"`
+}
+
+// cacheEntry holds the results of one detector for one file
+type cacheEntry struct {
+	Hash         string                     `json:"hash"`
+	Detector     string                     `json:"detector"`            // Language and detector version; empty for files only extractors read
+	Endpoints    []Endpoint                 `json:"endpoints,omitempty"` // Valid if HasEndpoints
+	Includes     []djangoInclude            `json:"includes,omitempty"`  // Valid if HasEndpoints
+	Facts        map[string]json.RawMessage `json:"facts,omitempty"`     // FileExtractor name and version -> facts
+	Functions    []FunctionSignature        `json:"functions,omitempty"` // Valid if HasFunctions
+	HasEndpoints bool                       `json:"has_endpoints,omitempty"`
+	HasFunctions bool                       `json:"has_functions,omitempty"`
+}
+
+// LoadAnalysisCache reads the cache in dir. A missing, unreadable or outdated
+// cache yields an empty one, since the cache can always be rebuilt.
+func LoadAnalysisCache(dir string) *AnalysisCache {
+	cache := &AnalysisCache{
+		path:    filepath.Join(dir, cacheFileName),
+		entries: make(map[string]cacheEntry),
+		seen:    make(map[string]bool),
+	}
+
+	data, err := os.ReadFile(cache.path)
+	if err != nil {
+		return cache
+	}
+
+	var file cacheFile
+	if err := json.Unmarshal(data, &file); err != nil || file.Version != cacheVersion || file.Entries == nil {
+		return cache
+	}
+
+	cache.entries = file.Entries
+	return cache
+}
+
+// Save writes the cache if anything changed. Entries for files that were not
+// seen since loading are dropped, so deleted files do not accumulate.
+func (c *AnalysisCache) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.entries {
+		if !c.seen[key] {
+			delete(c.entries, key)
+			c.dirty = true
+		}
+	}
+	if !c.dirty {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	data, err := json.Marshal(cacheFile{Version: cacheVersion, Entries: c.entries})
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache: %w", err)
+	}
+
+	if err := os.WriteFile(c.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+
+	c.dirty = false
+	return nil
+}
+
+// lookup returns the cached entry for a file if its content and detector are unchanged
+func (c *AnalysisCache) lookup(key, hash, detector string) (cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.seen[key] = true
+	entry, ok := c.entries[key]
+	if !ok || entry.Hash != hash || entry.Detector != detector {
+		return cacheEntry{}, false
+	}
+	return entry, true
+}
+
+// keep marks a file as present without looking it up, so Save keeps its entry
+func (c *AnalysisCache) keep(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.seen[key] = true
+}
+
+// store records the results for a file
+func (c *AnalysisCache) store(key string, entry cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.seen[key] = true
+	c.entries[key] = entry
+	c.dirty = true
+}
+
+// CleanAnalysisCache removes the cache directory
+func CleanAnalysisCache(dir string) error {
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to remove cache: %w", err)
+	}
+	return nil
+}
+
+// contentHash returns the hex SHA-256 of file content
+func contentHash(content []byte) string {
+	h := sha256.Sum256(content)
+	return hex.EncodeToString(h[:])
+}
+
+// detectorKey identifies a detector and the version of its extraction logic
+func detectorKey(detector LanguageDetector) string {
+	version := builtinDetectorVersion
+	if versioned, ok := detector.(VersionedDetector); ok {
+		version = versioned.Version()
+	}
+	return string(detector.Language()) + "@" + version
+}
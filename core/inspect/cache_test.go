@@ -0,0 +1,188 @@
+package inspect
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+// countingDetector wraps GoDetector and counts how often files are parsed
+type countingDetector struct {
+	GoDetector
+	version string
+	parsed  int32
+}
+
+func (d *countingDetector) ExtractEndpoints(filePath string, content []byte) ([]Endpoint, error) {
+	atomic.AddInt32(&d.parsed, 1)
+	return d.GoDetector.ExtractEndpoints(filePath, content)
+}
+
+func (d *countingDetector) Version() string {
+	return d.version
+}
+
+func TestAnalysisCache_ReusesUnchangedFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	cacheDir := filepath.Join(tmpDir, ".neev", "cache")
+
+	users := filepath.Join(tmpDir, "users.go")
+	orders := filepath.Join(tmpDir, "orders.go")
+	os.WriteFile(users, []byte("package api\n\nfunc Routes(r *gin.Engine) {\n\tr.GET(\"/users\", ListUsers)\n}\n"), 0644)
+	os.WriteFile(orders, []byte("package api\n\nfunc Routes(r *gin.Engine) {\n\tr.GET(\"/orders\", ListOrders)\n}\n"), 0644)
+
+	detector := &countingDetector{version: "1"}
+	analyzer := NewPolyglotAnalyzer()
+	analyzer.RegisterDetector(detector)
+
+	scan := func() *ScanResult {
+		t.Helper()
+		cache := LoadAnalysisCache(cacheDir)
+		result, err := analyzer.Scan(tmpDir, map[string]bool{}, ScanOptions{Endpoints: true, Cache: cache})
+		if err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+		if err := cache.Save(); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+		return result
+	}
+
+	first := scan()
+	if detector.parsed != 2 {
+		t.Fatalf("Expected 2 files parsed on a cold cache, got %d", detector.parsed)
+	}
+
+	second := scan()
+	if detector.parsed != 2 {
+		t.Errorf("Expected no files parsed on a warm cache, got %d", detector.parsed-2)
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("Cached scan differs:\n%+v\n%+v", first, second)
+	}
+
+	// Only the modified file is parsed again
+	os.WriteFile(orders, []byte("package api\n\nfunc Routes(r *gin.Engine) {\n\tr.POST(\"/orders\", CreateOrder)\n}\n"), 0644)
+	third := scan()
+	if detector.parsed != 3 {
+		t.Errorf("Expected 1 file re-parsed after a change, got %d", detector.parsed-2)
+	}
+	if len(third.Endpoints) != 2 || third.Endpoints[0].Method != "POST" {
+		t.Errorf("Expected the changed endpoint, got %+v", third.Endpoints)
+	}
+
+	// A new detector version invalidates every entry
+	detector.version = "2"
+	scan()
+	if detector.parsed != 5 {
+		t.Errorf("Expected all files re-parsed after a detector version change, got %d", detector.parsed-3)
+	}
+}
+
+func TestAnalysisCache_ReusesExtractorFacts(t *testing.T) {
+	tmpDir := t.TempDir()
+	cacheDir := filepath.Join(tmpDir, ".neev", "cache")
+	os.WriteFile(filepath.Join(tmpDir, "producer.go"), []byte("package events\n\nfunc Publish(w *kafka.Writer) {\n\tw.WriteMessages(ctx, kafka.Message{Topic: \"orders.created\"})\n}\n"), 0644)
+	os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module example.com/events\n\nrequire github.com/segmentio/kafka-go v0.4.47\n"), 0644)
+
+	var extracted int32
+	topics := topicExtractor
+	topics.Extract = func(path string, content []byte) interface{} {
+		atomic.AddInt32(&extracted, 1)
+		return topicExtractor.Extract(path, content)
+	}
+
+	scan := func() *ScanResult {
+		t.Helper()
+		cache := LoadAnalysisCache(cacheDir)
+		result, err := NewPolyglotAnalyzer().Scan(tmpDir, map[string]bool{}, ScanOptions{
+			Cache:      cache,
+			Extractors: []FileExtractor{topics, manifestExtractor},
+		})
+		if err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+		if err := cache.Save(); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+		return result
+	}
+
+	first := scan()
+	if extracted != 1 {
+		t.Fatalf("Expected 1 file extracted on a cold cache, got %d", extracted)
+	}
+	if len(first.Facts["topics"]) != 1 || len(first.Facts["manifests"]) != 1 {
+		t.Fatalf("Expected topic and manifest facts, got %+v", first.Facts)
+	}
+
+	second := scan()
+	if extracted != 1 {
+		t.Errorf("Expected no files extracted on a warm cache, got %d", extracted-1)
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("Cached scan differs:\n%+v\n%+v", first, second)
+	}
+
+	// A new extractor version discards its cached facts
+	topics.Version = topicExtractor.Version + ".1"
+	scan()
+	if extracted != 2 {
+		t.Errorf("Expected the file re-extracted after a version change, got %d", extracted-1)
+	}
+}
+
+func TestAnalysisCache_KeepsEntriesOutsideScopedScan(t *testing.T) {
+	tmpDir := t.TempDir()
+	cacheDir := filepath.Join(tmpDir, ".neev", "cache")
+	os.WriteFile(filepath.Join(tmpDir, "users.go"), []byte("package api\n\nfunc Register(r *gin.Engine) {\n\tr.GET(\"/api/users\", ListUsers)\n}\n"), 0644)
+	os.WriteFile(filepath.Join(tmpDir, "orders.go"), []byte("package api\n\nfunc Register(r *gin.Engine) {\n\tr.GET(\"/api/orders\", ListOrders)\n}\n"), 0644)
+
+	analyzer := NewPolyglotAnalyzer()
+	analyzer.RegisterDetector(NewGoDetector())
+
+	scan := func(only func(path string) bool) {
+		t.Helper()
+		cache := LoadAnalysisCache(cacheDir)
+		if _, err := analyzer.Scan(tmpDir, map[string]bool{}, ScanOptions{Endpoints: true, Cache: cache, Only: only}); err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+		if err := cache.Save(); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+
+	scan(nil)
+	// A scoped scan, as with --since, extracts only the changed file
+	scan(func(path string) bool { return filepath.Base(path) == "users.go" })
+
+	entries := LoadAnalysisCache(cacheDir).entries
+	if _, ok := entries["orders.go"]; !ok || len(entries) != 2 {
+		t.Errorf("Expected the scoped scan to keep both entries, got %v", entries)
+	}
+}
+
+func TestAnalysisCache_PrunesAndCleans(t *testing.T) {
+	tmpDir := t.TempDir()
+	cacheDir := filepath.Join(tmpDir, ".neev", "cache")
+
+	cache := LoadAnalysisCache(cacheDir)
+	cache.store("gone.go", cacheEntry{Hash: "x", Detector: "go@1", HasEndpoints: true})
+	cache.seen = map[string]bool{}
+	if err := cache.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	if reloaded := LoadAnalysisCache(cacheDir); len(reloaded.entries) != 0 {
+		t.Errorf("Expected unseen entries to be pruned, got %v", reloaded.entries)
+	}
+
+	if err := CleanAnalysisCache(cacheDir); err != nil {
+		t.Fatalf("CleanAnalysisCache failed: %v", err)
+	}
+	if _, err := os.Stat(cacheDir); !os.IsNotExist(err) {
+		t.Errorf("Expected cache directory to be removed")
+	}
+}
@@ -24,64 +24,87 @@ func (d *CSharpDetector) Language() Language {
 }
 
 // ExtractEndpoints finds HTTP endpoints in C# code
-// Supports: ASP.NET Core attributes
+// Supports: ASP.NET Core attributes, including controller-level [Route] prefixes
+// with [controller] and [action] tokens
 func (d *CSharpDetector) ExtractEndpoints(filePath string, content []byte) ([]Endpoint, error) {
 	var endpoints []Endpoint
 	contentStr := string(content)
 	lines := strings.Split(contentStr, "\n")
 	
 	// ASP.NET Core attribute patterns
-	annotationPattern := regexp.MustCompile(`\[(HttpGet|HttpPost|HttpPut|HttpDelete|HttpPatch)\s*(?:\(["']([^"']+)["']\))?`)
-	methodPattern := regexp.MustCompile(`^\s*(?:public|private|protected|internal)?\s+(?:async\s+)?(?:Task<)?(\w+)`)
+	verbPattern := regexp.MustCompile(`\bHttp(Get|Post|Put|Delete|Patch|Head|Options)\b(?:\s*\(\s*"([^"]*)")?`)
+	routePattern := regexp.MustCompile(`\bRoute\s*\(\s*"([^"]*)"`)
+	classPattern := regexp.MustCompile(`\bclass\s+(\w+)`)
+	methodPattern := regexp.MustCompile(`^[^=(\[;]*\s(\w+)\s*\(`)
 	
-	var currentMethod string
-	var currentPath string
+	prefix := ""
+	controller := ""
+	var methods []string // Verbs of the pending attributes
+	route := ""          // Route template of the pending attributes
+	hasRoute := false
 	
 	for lineNum, line := range lines {
 		trimmedLine := strings.TrimSpace(line)
 		
-		// Check for HTTP attribute
-		annotationMatch := annotationPattern.FindStringSubmatch(trimmedLine)
-		if annotationMatch != nil {
-			attr := annotationMatch[1]
-			path := ""
-			if len(annotationMatch) > 2 {
-				path = annotationMatch[2]
+		// Check for attributes, possibly several in one list: [HttpGet("{id}"), Authorize]
+		if strings.HasPrefix(trimmedLine, "[") {
+			for _, match := range verbPattern.FindAllStringSubmatch(trimmedLine, -1) {
+				methods = append(methods, strings.ToUpper(match[1]))
+				if match[2] != "" {
+					route = match[2]
+				}
+			}
+			if match := routePattern.FindStringSubmatch(trimmedLine); match != nil {
+				route = match[1]
+				hasRoute = true
 			}
-			
-			// Convert attribute to HTTP method
-			method := strings.ToUpper(strings.TrimPrefix(attr, "Http"))
-			
-			currentMethod = method
-			currentPath = path
 			continue
 		}
 		
-		// If we have a pending attribute, look for the method definition
-		if currentMethod != "" {
+		// [Route] on the class prefixes every action in it
+		if classMatch := classPattern.FindStringSubmatch(trimmedLine); classMatch != nil {
+			controller = strings.TrimSuffix(classMatch[1], "Controller")
+			prefix = ""
+			if hasRoute {
+				prefix = route
+			}
+			methods, route, hasRoute = nil, "", false
+			continue
+		}
+		
+		// If we have pending attributes, look for the action definition
+		if len(methods) > 0 || hasRoute {
 			methodMatch := methodPattern.FindStringSubmatch(trimmedLine)
-			if methodMatch != nil && strings.Contains(trimmedLine, "(") {
-				// Extract method name
-				funcNamePattern := regexp.MustCompile(`\s+(\w+)\s*\(`)
-				funcMatch := funcNamePattern.FindStringSubmatch(trimmedLine)
-				var handler string
-				if funcMatch != nil {
-					handler = funcMatch[1]
+			if methodMatch != nil {
+				handler := methodMatch[1]
+				
+				// An action with [Route] but no verb answers any method
+				if len(methods) == 0 {
+					methods = []string{"ANY"}
+				}
+				
+				// Templates starting with "/" or "~/" ignore the controller route
+				path := controllerRoutePath(prefix, route)
+				if strings.HasPrefix(route, "/") || strings.HasPrefix(route, "~/") {
+					path = controllerRoutePath("", strings.TrimPrefix(route, "~"))
 				}
+				path = strings.ReplaceAll(path, "[controller]", controller)
+				path = strings.ReplaceAll(path, "[action]", handler)
 				
-				endpoint := Endpoint{
-					Method:   currentMethod,
-					Path:     currentPath,
-					Handler:  handler,
-					File:     filePath,
-					Line:     lineNum + 1,
-					Language: string(LangCSharp),
+				for _, method := range methods {
+					endpoint := Endpoint{
+						Method:   method,
+						Path:     path,
+						Handler:  handler,
+						File:     filePath,
+						Line:     lineNum + 1,
+						Language: string(LangCSharp),
+					}
+					endpoints = append(endpoints, endpoint)
 				}
-				endpoints = append(endpoints, endpoint)
 				
 				// Reset
-				currentMethod = ""
-				currentPath = ""
+				methods, route, hasRoute = nil, "", false
 			}
 		}
 	}
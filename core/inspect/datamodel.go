@@ -0,0 +1,729 @@
+package inspect
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Table is a database table, either documented by a spec or built by migrations
+type Table struct {
+	Name    string
+	Columns []Column
+	File    string
+	Line    int
+}
+
+// Column is a column of a table
+type Column struct {
+	Name string
+	Type string // Declared SQL type, e.g. "VARCHAR(255)"
+	File string
+	Line int
+}
+
+// column returns the column named name, ignoring case
+func (t *Table) column(name string) *Column {
+	for i := range t.Columns {
+		if strings.EqualFold(t.Columns[i].Name, name) {
+			return &t.Columns[i]
+		}
+	}
+	return nil
+}
+
+var (
+	// Fenced code blocks in Markdown and the headings that introduce them
+	fencePattern   = regexp.MustCompile("^\\s*(```|~~~)\\s*(\\w*)")
+	headingPattern = regexp.MustCompile(`^#{1,6}\s+(.*)$`)
+
+	// Statements that shape tables
+	createTablePattern = regexp.MustCompile(`(?is)^CREATE\s+(?:(?:GLOBAL\s+|LOCAL\s+)?(?:TEMP|TEMPORARY)\s+|UNLOGGED\s+)?TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?([\w."\x60\[\]]+)\s*\(`)
+	alterTablePattern  = regexp.MustCompile(`(?is)^ALTER\s+TABLE\s+(?:IF\s+EXISTS\s+)?(?:ONLY\s+)?([\w."\x60\[\]]+)\s+(.*)$`)
+	dropTablePattern   = regexp.MustCompile(`(?is)^DROP\s+TABLE\s+(?:IF\s+EXISTS\s+)?(.*?)(?:\s+(?:CASCADE|RESTRICT))?$`)
+
+	// Actions of an ALTER TABLE statement
+	addColumnPattern    = regexp.MustCompile(`(?is)^ADD\s+(?:COLUMN\s+)?(?:IF\s+NOT\s+EXISTS\s+)?(.*)$`)
+	dropColumnPattern   = regexp.MustCompile(`(?is)^DROP\s+(?:COLUMN\s+)?(?:IF\s+EXISTS\s+)?([\w"\x60\[\]]+)`)
+	renameColumnPattern = regexp.MustCompile(`(?is)^RENAME\s+(?:COLUMN\s+)?([\w"\x60\[\]]+)\s+TO\s+([\w"\x60\[\]]+)$`)
+	renameTablePattern  = regexp.MustCompile(`(?is)^RENAME\s+(?:TO|AS)\s+([\w."\x60\[\]]+)$`)
+	alterTypePattern    = regexp.MustCompile(`(?is)^ALTER\s+(?:COLUMN\s+)?([\w"\x60\[\]]+)\s+(?:SET\s+DATA\s+)?TYPE\s+(.*?)(?:\s+USING\s+.*)?$`)
+	modifyColumnPattern = regexp.MustCompile(`(?is)^MODIFY\s+(?:COLUMN\s+)?(.*)$`)
+	changeColumnPattern = regexp.MustCompile(`(?is)^CHANGE\s+(?:COLUMN\s+)?([\w"\x60\[\]]+)\s+(.*)$`)
+
+	// Migration file names: golang-migrate 0001_init.up.sql, Flyway V1.2__init.sql,
+	// Django 0001_initial.sql and Rails 20240101120000_create_users.sql
+	migrationVersionPattern = regexp.MustCompile(`^[Vv]?(\d+(?:\.\d+)*)(?:__|_|-|\.)`)
+)
+
+// tableConstraintKeywords start entries of a CREATE TABLE that are not columns
+var tableConstraintKeywords = map[string]bool{
+	"CONSTRAINT": true, "PRIMARY": true, "FOREIGN": true, "UNIQUE": true, "CHECK": true,
+	"INDEX": true, "KEY": true, "EXCLUDE": true, "FULLTEXT": true, "SPATIAL": true, "LIKE": true,
+	"PERIOD": true,
+}
+
+// columnConstraintKeywords end the type of a column definition
+var columnConstraintKeywords = map[string]bool{
+	"NOT": true, "NULL": true, "DEFAULT": true, "PRIMARY": true, "REFERENCES": true, "UNIQUE": true,
+	"CHECK": true, "CONSTRAINT": true, "GENERATED": true, "COLLATE": true, "AUTO_INCREMENT": true,
+	"AUTOINCREMENT": true, "IDENTITY": true, "ON": true, "COMMENT": true, "FIRST": true, "AFTER": true,
+	"CHARACTER": true, "CHARSET": true,
+}
+
+// ValidateDataModel compares the tables documented in specs with the schemas
+// that the SQL migrations found by a repository scan build, one per migration
+// root. Tables documented for a module are compared with the schemas of the
+// migrations in its code, if it has any, and other tables with every schema.
+func ValidateDataModel(opts InspectOptions, scan *ScanResult, codeModules map[string][]string) ([]Warning, error) {
+	return compareTables(collectSpecTables(opts), migrateSchemas(opts.RootDir, scan), codeModules), nil
+}
+
+// specModule returns the module a spec document belongs to: that of its
+// foundation spec, or that a blueprint is named after
+func specModule(opts InspectOptions, file string) string {
+	if filepath.Dir(file) == filepath.Clean(opts.FoundationPath) {
+		return strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	}
+	blueprints := blueprintsDir(opts)
+	if !pathContains(blueprints, file) {
+		return ""
+	}
+	rel, err := filepath.Rel(blueprints, file)
+	if err != nil {
+		return ""
+	}
+	name, _, nested := strings.Cut(filepath.ToSlash(rel), "/")
+	if !nested {
+		return ""
+	}
+	return name
+}
+
+// collectSpecTables gathers the tables defined by `sql` blocks (or CREATE TABLE
+// statements in a "Data Model" section) of blueprint and foundation documents,
+// and by .sql files in blueprints, by the module documenting them
+func collectSpecTables(opts InspectOptions) map[string]map[string]*Table {
+	tables := make(map[string]map[string]*Table)
+
+	files := specFiles(opts, []string{blueprintsDir(opts), opts.FoundationPath}, func(path string) bool {
+		return strings.HasSuffix(path, ".md") || strings.HasSuffix(path, ".sql")
+	})
+	sort.Strings(files)
+
+	for _, path := range files {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		text := string(content)
+		if strings.HasSuffix(path, ".md") {
+			text = markdownSQL(text)
+		}
+		module := specModule(opts, path)
+		if tables[module] == nil {
+			tables[module] = make(map[string]*Table)
+		}
+		ApplySQL(tables[module], path, text)
+	}
+
+	return tables
+}
+
+// markdownSQL keeps the SQL of a Markdown document: `sql` fenced blocks, and
+// unlabelled blocks under a "Data Model" heading. Everything else is blanked so
+// that line numbers still refer to the document.
+func markdownSQL(text string) string {
+	lines := strings.Split(text, "\n")
+	dataModel, inBlock, keep := false, false, false
+	var fence string
+
+	for i, line := range lines {
+		if inBlock {
+			if strings.HasPrefix(strings.TrimSpace(line), fence) {
+				inBlock = false
+				lines[i] = ""
+			} else if !keep {
+				lines[i] = ""
+			}
+			continue
+		}
+
+		if match := fencePattern.FindStringSubmatch(line); match != nil {
+			inBlock, fence = true, match[1]
+			language := strings.ToLower(match[2])
+			keep = language == "sql" || language == "postgresql" || language == "mysql" ||
+				(language == "" && dataModel)
+		} else if match := headingPattern.FindStringSubmatch(line); match != nil {
+			heading := strings.ToLower(match[1])
+			dataModel = strings.Contains(heading, "data model") || strings.Contains(heading, "database schema")
+		}
+		lines[i] = ""
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// migrationSchema is the schema the migrations under one root build
+type migrationSchema struct {
+	root   string
+	tables map[string]*Table
+}
+
+// migrateSchemas applies the SQL migrations found by a repository scan in
+// order, building one schema per migration root
+func migrateSchemas(rootDir string, scan *ScanResult) []migrationSchema {
+	var schemas []migrationSchema
+	roots := make(map[string]int)
+	for _, migration := range findMigrations(scan) {
+		root := migrationRoot(rootDir, migration.Path)
+		i, ok := roots[root]
+		if !ok {
+			i = len(schemas)
+			roots[root] = i
+			schemas = append(schemas, migrationSchema{root: root, tables: make(map[string]*Table)})
+		}
+		ApplySQL(schemas[i].tables, migration.Path, migration.Facts.(string))
+	}
+	return schemas
+}
+
+// migrationRoot returns the directory holding the migrations directory of a
+// migration, or the db/ directory of Rails' structure.sql. Migrations under
+// different roots, such as those of two services, build separate schemas.
+func migrationRoot(rootDir, path string) string {
+	dir := filepath.Dir(path)
+	if filepath.Base(path) == "structure.sql" && filepath.Base(dir) == "db" {
+		return dir
+	}
+	rel, err := filepath.Rel(rootDir, dir)
+	if err != nil {
+		return dir
+	}
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	for i, part := range parts {
+		if migrationDirs[strings.ToLower(part)] {
+			return filepath.Join(rootDir, filepath.FromSlash(strings.Join(parts[:i], "/")))
+		}
+	}
+	return dir
+}
+
+// migrationExtractorName is the name of the facts of migrationExtractor
+const migrationExtractorName = "migrations"
+
+// migrationExtractor reads the .sql files of migration directories (migrations/,
+// db/migrate/, Flyway's db/migration/) and Rails' db/structure.sql during the
+// repository scan. Down and undo migrations are skipped. Scripts are applied
+// after the scan, so their text is kept rather than cached.
+func migrationExtractor(rootDir string) FileExtractor {
+	return FileExtractor{
+		Name: migrationExtractorName,
+		Match: func(path string) bool {
+			name := filepath.Base(path)
+			if !strings.HasSuffix(name, ".sql") || strings.HasSuffix(name, ".down.sql") || strings.HasPrefix(name, "U") && strings.Contains(name, "__") {
+				return false
+			}
+			return name == "structure.sql" && filepath.Base(filepath.Dir(path)) == "db" || inMigrationDir(rootDir, path)
+		},
+		Extract: func(path string, content []byte) interface{} {
+			return string(content)
+		},
+	}
+}
+
+// findMigrations returns the migrations found by a repository scan in the
+// order they apply. Flyway's repeatable migrations run last.
+func findMigrations(scan *ScanResult) []FileFacts {
+	migrations := append([]FileFacts(nil), scan.Facts[migrationExtractorName]...)
+	sort.SliceStable(migrations, func(i, j int) bool {
+		return migrationLess(migrations[i].Path, migrations[j].Path)
+	})
+	return migrations
+}
+
+// migrationDirs are the names of directories holding migrations
+var migrationDirs = map[string]bool{"migrations": true, "migration": true, "migrate": true}
+
+// inMigrationDir reports whether a file lies in a migrations directory
+func inMigrationDir(rootDir, path string) bool {
+	rel, err := filepath.Rel(rootDir, filepath.Dir(path))
+	if err != nil {
+		return false
+	}
+	for _, dir := range strings.Split(filepath.ToSlash(rel), "/") {
+		if migrationDirs[strings.ToLower(dir)] {
+			return true
+		}
+	}
+	return false
+}
+
+// migrationLess orders migrations by directory, then version. Files without
+// a version (Rails' structure.sql, Flyway R__ migrations) come last.
+func migrationLess(a, b string) bool {
+	if filepath.Dir(a) != filepath.Dir(b) {
+		return filepath.Dir(a) < filepath.Dir(b)
+	}
+	va, vb := migrationVersion(filepath.Base(a)), migrationVersion(filepath.Base(b))
+	switch {
+	case va == nil && vb == nil:
+		return a < b
+	case va == nil:
+		return false
+	case vb == nil:
+		return true
+	}
+	for i := 0; i < len(va) && i < len(vb); i++ {
+		if va[i] != vb[i] {
+			return va[i] < vb[i]
+		}
+	}
+	if len(va) != len(vb) {
+		return len(va) < len(vb)
+	}
+	return a < b
+}
+
+// migrationVersion parses the version prefix of a migration file name
+func migrationVersion(name string) []int {
+	match := migrationVersionPattern.FindStringSubmatch(name)
+	if match == nil {
+		return nil
+	}
+	var version []int
+	for _, part := range strings.Split(match[1], ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil
+		}
+		version = append(version, n)
+	}
+	return version
+}
+
+// ApplySQL applies the CREATE TABLE, ALTER TABLE and DROP TABLE statements of
+// a SQL script to tables, keyed by lower-cased table name. Other statements
+// are ignored.
+func ApplySQL(tables map[string]*Table, filePath, text string) {
+	text = blankSQLComments(text)
+
+	for _, stmt := range splitSQLStatements(text) {
+		body := strings.TrimSpace(text[stmt[0]:stmt[1]])
+		if body == "" {
+			continue
+		}
+		start := stmt[0] + strings.Index(text[stmt[0]:stmt[1]], body)
+		lineAt := func(offset int) int {
+			return strings.Count(text[:start+offset], "\n") + 1
+		}
+
+		if match := createTablePattern.FindStringSubmatchIndex(body); match != nil {
+			open := match[1] - 1
+			end := matchingParen(body, open)
+			if end < 0 {
+				continue
+			}
+			name := sqlTableName(body[match[2]:match[3]])
+			table := &Table{Name: name, File: filePath, Line: lineAt(0)}
+			offset := open + 1
+			for _, def := range splitSQLList(body[open+1 : end]) {
+				if column, ok := parseColumnDef(def); ok {
+					trimmed := strings.TrimLeft(def, " \t\r\n")
+					column.File = filePath
+					column.Line = lineAt(offset + len(def) - len(trimmed))
+					table.Columns = append(table.Columns, column)
+				}
+				offset += len(def) + 1
+			}
+			tables[strings.ToLower(name)] = table
+			continue
+		}
+
+		if match := alterTablePattern.FindStringSubmatch(body); match != nil {
+			table, ok := tables[strings.ToLower(sqlTableName(match[1]))]
+			if !ok {
+				continue
+			}
+			line := lineAt(0)
+			for _, action := range splitSQLList(match[2]) {
+				alterTable(tables, table, strings.TrimSpace(action), filePath, line)
+			}
+			continue
+		}
+
+		if match := dropTablePattern.FindStringSubmatch(body); match != nil {
+			for _, name := range strings.Split(match[1], ",") {
+				delete(tables, strings.ToLower(sqlTableName(strings.TrimSpace(name))))
+			}
+		}
+	}
+}
+
+// alterTable applies one action of an ALTER TABLE statement
+func alterTable(tables map[string]*Table, table *Table, action, filePath string, line int) {
+	switch {
+	case renameTablePattern.MatchString(action):
+		name := sqlTableName(renameTablePattern.FindStringSubmatch(action)[1])
+		delete(tables, strings.ToLower(table.Name))
+		table.Name = name
+		tables[strings.ToLower(name)] = table
+
+	case renameColumnPattern.MatchString(action):
+		match := renameColumnPattern.FindStringSubmatch(action)
+		if column := table.column(sqlIdentifier(match[1])); column != nil {
+			column.Name = sqlIdentifier(match[2])
+		}
+
+	case alterTypePattern.MatchString(action):
+		match := alterTypePattern.FindStringSubmatch(action)
+		if column := table.column(sqlIdentifier(match[1])); column != nil {
+			column.Type, column.File, column.Line = strings.TrimSpace(match[2]), filePath, line
+		}
+
+	case changeColumnPattern.MatchString(action):
+		// MySQL CHANGE old new TYPE renames and retypes
+		match := changeColumnPattern.FindStringSubmatch(action)
+		if definition, ok := parseColumnDef(match[2]); ok {
+			if column := table.column(sqlIdentifier(match[1])); column != nil {
+				column.Name, column.Type, column.File, column.Line = definition.Name, definition.Type, filePath, line
+			}
+		}
+
+	case modifyColumnPattern.MatchString(action):
+		if definition, ok := parseColumnDef(modifyColumnPattern.FindStringSubmatch(action)[1]); ok {
+			if column := table.column(definition.Name); column != nil {
+				column.Type, column.File, column.Line = definition.Type, filePath, line
+			}
+		}
+
+	case dropColumnPattern.MatchString(action):
+		name := sqlIdentifier(dropColumnPattern.FindStringSubmatch(action)[1])
+		if strings.EqualFold(name, "CONSTRAINT") || strings.EqualFold(name, "INDEX") {
+			return
+		}
+		for i, column := range table.Columns {
+			if strings.EqualFold(column.Name, name) {
+				table.Columns = append(table.Columns[:i], table.Columns[i+1:]...)
+				break
+			}
+		}
+
+	case addColumnPattern.MatchString(action):
+		definition, ok := parseColumnDef(addColumnPattern.FindStringSubmatch(action)[1])
+		if ok && table.column(definition.Name) == nil {
+			definition.File, definition.Line = filePath, line
+			table.Columns = append(table.Columns, definition)
+		}
+	}
+}
+
+// parseColumnDef parses a column definition such as "email VARCHAR(255) NOT NULL".
+// Table constraints are not columns.
+func parseColumnDef(def string) (Column, bool) {
+	fields := strings.Fields(strings.TrimSpace(def))
+	if len(fields) < 2 || tableConstraintKeywords[strings.ToUpper(fields[0])] {
+		return Column{}, false
+	}
+
+	// The type runs until the first constraint keyword outside parentheses
+	rest := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(def), fields[0]))
+	depth, end := 0, len(rest)
+	for i := 0; i < len(rest); i++ {
+		switch c := rest[i]; {
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case depth == 0 && (i == 0 || rest[i-1] == ' ' || rest[i-1] == '\t' || rest[i-1] == '\n'):
+			word := rest[i:]
+			if space := strings.IndexAny(word, " \t\r\n("); space >= 0 {
+				word = word[:space]
+			}
+			upper := strings.ToUpper(word)
+			// CHARACTER VARYING and CHARACTER(n) are types, CHARACTER SET is not
+			if upper == "CHARACTER" && i == 0 {
+				continue
+			}
+			if columnConstraintKeywords[upper] {
+				end = i
+			}
+		}
+		if end != len(rest) {
+			break
+		}
+	}
+
+	typ := strings.Join(strings.Fields(rest[:end]), " ")
+	if typ == "" {
+		return Column{}, false
+	}
+	return Column{Name: sqlIdentifier(fields[0]), Type: typ}, true
+}
+
+// sqlIdentifier removes the quoting of an identifier
+func sqlIdentifier(name string) string {
+	return strings.Trim(name, "\"`[]")
+}
+
+// sqlTableName removes quoting and the schema of a table name
+func sqlTableName(name string) string {
+	name = sqlIdentifier(name)
+	if dot := strings.LastIndex(name, "."); dot >= 0 {
+		name = sqlIdentifier(name[dot+1:])
+	}
+	return name
+}
+
+// blankSQLComments replaces -- and /* */ comments with spaces, keeping newlines
+func blankSQLComments(text string) string {
+	b := []byte(text)
+	for i := 0; i < len(b); i++ {
+		switch {
+		case b[i] == '\'':
+			for i++; i < len(b) && b[i] != '\''; i++ {
+			}
+		case b[i] == '-' && i+1 < len(b) && b[i+1] == '-':
+			for ; i < len(b) && b[i] != '\n'; i++ {
+				b[i] = ' '
+			}
+		case b[i] == '/' && i+1 < len(b) && b[i+1] == '*':
+			end := strings.Index(string(b[i+2:]), "*/")
+			stop := len(b)
+			if end >= 0 {
+				stop = i + 2 + end + 2
+			}
+			copy(b[i:stop], blankKeepingNewlines(string(b[i:stop])))
+			i = stop - 1
+		}
+	}
+	return string(b)
+}
+
+// splitSQLStatements returns the [start, end) offsets of the statements of a
+// script, split on semicolons outside quotes and dollar-quoted bodies
+func splitSQLStatements(text string) [][2]int {
+	var statements [][2]int
+	start := 0
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; {
+		case c == '\'' || c == '"' || c == '`':
+			for i++; i < len(text) && text[i] != c; i++ {
+			}
+		case c == '$':
+			// $$ ... $$ or $tag$ ... $tag$ function bodies
+			tagEnd := strings.IndexByte(text[i+1:], '$')
+			if tagEnd < 0 {
+				continue
+			}
+			tag := text[i : i+tagEnd+2]
+			if strings.ContainsAny(tag[1:len(tag)-1], " \t\n;(),'") {
+				continue
+			}
+			if end := strings.Index(text[i+len(tag):], tag); end >= 0 {
+				i += len(tag) + end + len(tag) - 1
+			}
+		case c == ';':
+			statements = append(statements, [2]int{start, i})
+			start = i + 1
+		}
+	}
+	if start < len(text) {
+		statements = append(statements, [2]int{start, len(text)})
+	}
+	return statements
+}
+
+// splitSQLList splits a comma-separated list, ignoring commas in parentheses
+// and quoted strings
+func splitSQLList(s string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\'' || c == '"' || c == '`':
+			for i++; i < len(s) && s[i] != c; i++ {
+			}
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == ',' && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// sqlTypeAliases maps type names to a canonical name across PostgreSQL, MySQL and SQLite
+var sqlTypeAliases = map[string]string{
+	"int": "integer", "int4": "integer", "serial": "integer", "serial4": "integer", "mediumint": "integer",
+	"bigint": "bigint", "int8": "bigint", "bigserial": "bigint", "serial8": "bigint",
+	"smallint": "smallint", "int2": "smallint", "smallserial": "smallint", "serial2": "smallint",
+	"character varying": "varchar", "nvarchar": "varchar", "varchar2": "varchar",
+	"character": "char", "nchar": "char", "bpchar": "char",
+	"bool":                        "boolean",
+	"timestamp without time zone": "timestamp", "datetime": "timestamp",
+	"timestamp with time zone": "timestamptz",
+	"time without time zone":   "time", "time with time zone": "timetz",
+	"decimal":          "numeric",
+	"float4":           "real",
+	"double precision": "double", "float8": "double", "float": "double",
+	"bytea": "binary", "blob": "binary", "longblob": "binary", "mediumblob": "binary", "varbinary": "binary",
+	"longtext": "text", "mediumtext": "text", "clob": "text",
+}
+
+// normalizeSQLType returns the canonical name of a type, its parameters
+// without spaces, and whether it is an array
+func normalizeSQLType(typ string) (name, params string, array bool) {
+	typ = strings.ToLower(strings.Join(strings.Fields(typ), " "))
+	for strings.HasSuffix(typ, "[]") {
+		array = true
+		typ = strings.TrimSpace(strings.TrimSuffix(typ, "[]"))
+	}
+	if open := strings.IndexByte(typ, '('); open >= 0 {
+		if end := strings.LastIndexByte(typ, ')'); end > open {
+			params = strings.ReplaceAll(typ[open+1:end], " ", "")
+			typ = strings.TrimSpace(typ[:open] + typ[end+1:])
+		}
+	}
+	typ = strings.TrimSuffix(typ, " unsigned")
+	if canonical, ok := sqlTypeAliases[typ]; ok {
+		typ = canonical
+	}
+	// MySQL's BOOLEAN is TINYINT(1)
+	if typ == "tinyint" && params == "1" {
+		typ, params = "boolean", ""
+	}
+	return typ, params, array
+}
+
+// sqlTypesMatch reports whether two declared types are the same. Parameters
+// such as lengths are only compared when both sides give them.
+func sqlTypesMatch(documented, actual string) bool {
+	dName, dParams, dArray := normalizeSQLType(documented)
+	aName, aParams, aArray := normalizeSQLType(actual)
+	if dName != aName || dArray != aArray {
+		return false
+	}
+	return dParams == "" || aParams == "" || dParams == aParams
+}
+
+// compareTables reports documented tables and columns that migrations do not
+// create, and columns whose migrated type differs from the documented one.
+// Tables of a module are compared with the schemas under its code directories,
+// if there are any. Of several schemas with a table, the one that matches the
+// documentation best is compared.
+func compareTables(specTables map[string]map[string]*Table, schemas []migrationSchema, codeModules map[string][]string) []Warning {
+	var warnings []Warning
+
+	var modules []string
+	for module := range specTables {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+
+	for _, module := range modules {
+		candidates := schemas
+		var scoped []migrationSchema
+		for _, schema := range schemas {
+			for _, dir := range codeModules[module] {
+				if pathContains(dir, schema.root) {
+					scoped = append(scoped, schema)
+					break
+				}
+			}
+		}
+		if len(scoped) > 0 {
+			candidates = scoped
+		}
+		warnings = append(warnings, compareModuleTables(specTables[module], candidates)...)
+	}
+
+	return warnings
+}
+
+// compareModuleTables compares the tables documented by one module with the
+// schemas they may be migrated in
+func compareModuleTables(specTables map[string]*Table, candidates []migrationSchema) []Warning {
+	var warnings []Warning
+
+	var names []string
+	for name := range specTables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		spec := specTables[name]
+
+		var best []Warning
+		found := false
+		for _, schema := range candidates {
+			if table, ok := schema.tables[name]; ok {
+				if columnWarnings := compareColumns(spec, table); !found || len(columnWarnings) < len(best) {
+					best, found = columnWarnings, true
+				}
+			}
+		}
+		if found {
+			warnings = append(warnings, best...)
+			continue
+		}
+
+		warnings = append(warnings, Warning{
+			Type:        WarningMissingTable,
+			Module:      "database",
+			Subject:     spec.Name,
+			Message:     fmt.Sprintf("Table '%s' is documented in %s but no migration creates it", spec.Name, filepath.Base(spec.File)),
+			Severity:    "error",
+			Remediation: fmt.Sprintf("Add a migration that creates '%s' or remove it from the data model", spec.Name),
+			File:        spec.File,
+			Line:        spec.Line,
+		})
+	}
+
+	return warnings
+}
+
+// compareColumns reports the documented columns of a table that migrations do
+// not create or create with another type
+func compareColumns(spec, table *Table) []Warning {
+	var warnings []Warning
+
+	for _, column := range spec.Columns {
+		actual := table.column(column.Name)
+		if actual == nil {
+			warnings = append(warnings, Warning{
+				Type:        WarningMissingColumn,
+				Module:      "database",
+				Subject:     spec.Name + "." + column.Name,
+				Message:     fmt.Sprintf("Column '%s.%s' is documented in %s but not created by migrations", spec.Name, column.Name, filepath.Base(column.File)),
+				Severity:    "error",
+				Remediation: fmt.Sprintf("Add '%s' to '%s' in a migration or remove it from the data model", column.Name, spec.Name),
+				File:        column.File,
+				Line:        column.Line,
+			})
+			continue
+		}
+		if !sqlTypesMatch(column.Type, actual.Type) {
+			warnings = append(warnings, Warning{
+				Type:        WarningColumnTypeMismatch,
+				Module:      "database",
+				Subject:     spec.Name + "." + column.Name,
+				Message:     fmt.Sprintf("Column '%s.%s' is documented as %s but migrated as %s", spec.Name, column.Name, column.Type, actual.Type),
+				Severity:    "warning",
+				Remediation: fmt.Sprintf("Change the type of '%s.%s' in a migration or update the data model", spec.Name, column.Name),
+				File:        actual.File,
+				Line:        actual.Line,
+			})
+		}
+	}
+
+	return warnings
+}
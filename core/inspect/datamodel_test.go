@@ -0,0 +1,228 @@
+package inspect
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestApplySQL(t *testing.T) {
+	tables := make(map[string]*Table)
+	ApplySQL(tables, "0001_init.up.sql", `-- Users; accounts
+CREATE TABLE IF NOT EXISTS public."users" (
+    id BIGSERIAL PRIMARY KEY,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    name character varying(100),
+    balance NUMERIC(10, 2) DEFAULT 0 CHECK (balance >= 0),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT users_email_key UNIQUE (email)
+);
+
+/* Orders; dropped below */
+CREATE TABLE orders (id INT);
+
+CREATE FUNCTION touch() RETURNS trigger AS $$
+BEGIN
+    NEW.updated_at = now();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+`)
+	ApplySQL(tables, "0002_alter.up.sql", `ALTER TABLE users ADD COLUMN IF NOT EXISTS bio TEXT, DROP COLUMN balance;
+ALTER TABLE users RENAME COLUMN name TO full_name;
+ALTER TABLE users ALTER COLUMN email TYPE TEXT USING email::text;
+ALTER TABLE users RENAME TO accounts;
+DROP TABLE IF EXISTS orders CASCADE;
+`)
+
+	if _, ok := tables["users"]; ok || tables["orders"] != nil {
+		t.Fatalf("Expected users to be renamed and orders dropped, got %v", tables)
+	}
+	accounts := tables["accounts"]
+	if accounts == nil {
+		t.Fatal("Expected table accounts")
+	}
+
+	var got []string
+	for _, column := range accounts.Columns {
+		got = append(got, column.Name+" "+column.Type)
+	}
+	want := []string{
+		"id BIGSERIAL",
+		"email TEXT",
+		"full_name character varying(100)",
+		"created_at TIMESTAMP WITH TIME ZONE",
+		"bio TEXT",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	if accounts.Line != 2 || accounts.Columns[3].Line != 7 || accounts.Columns[1].File != "0002_alter.up.sql" {
+		t.Errorf("Unexpected locations: table line %d, columns %+v", accounts.Line, accounts.Columns)
+	}
+}
+
+func TestSQLTypesMatch(t *testing.T) {
+	tests := []struct {
+		documented, actual string
+		want               bool
+	}{
+		{"INTEGER", "serial", true},
+		{"BIGINT", "int8", true},
+		{"VARCHAR", "character varying(255)", true},
+		{"VARCHAR(100)", "VARCHAR(255)", false},
+		{"NUMERIC(10,2)", "decimal(10, 2)", true},
+		{"BOOLEAN", "tinyint(1)", true},
+		{"TIMESTAMPTZ", "timestamp with time zone", true},
+		{"TIMESTAMP", "TIMESTAMPTZ", false},
+		{"TEXT[]", "text", false},
+		{"INTEGER", "BIGINT", false},
+	}
+	for _, tt := range tests {
+		if got := sqlTypesMatch(tt.documented, tt.actual); got != tt.want {
+			t.Errorf("sqlTypesMatch(%q, %q) = %v, want %v", tt.documented, tt.actual, got, tt.want)
+		}
+	}
+}
+
+func TestMigrationOrder(t *testing.T) {
+	rootDir := t.TempDir()
+	writeFiles(t, rootDir, map[string]string{
+		"db/migration/V10__add_index.sql":   "",
+		"db/migration/V2__users.sql":        "",
+		"db/migration/V1.1__fix.sql":        "",
+		"db/migration/R__views.sql":         "",
+		"db/migration/U2__users.sql":        "",
+		"migrations/0002_bio.up.sql":        "",
+		"migrations/0002_bio.down.sql":      "",
+		"migrations/0001_init.up.sql":       "",
+		"scripts/seed.sql":                  "",
+		"db/structure.sql":                  "",
+		"node_modules/pkg/migrations/1.sql": "",
+	})
+
+	migrations := findMigrations(scanFacts(t, rootDir, map[string]bool{"node_modules": true}, migrationExtractor(rootDir)))
+	var got []string
+	for _, migration := range migrations {
+		rel, _ := filepath.Rel(rootDir, migration.Path)
+		got = append(got, filepath.ToSlash(rel))
+	}
+	want := []string{
+		"db/structure.sql",
+		"db/migration/V1.1__fix.sql",
+		"db/migration/V2__users.sql",
+		"db/migration/V10__add_index.sql",
+		"db/migration/R__views.sql",
+		"migrations/0001_init.up.sql",
+		"migrations/0002_bio.up.sql",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestInspect_DataModel(t *testing.T) {
+	rootDir := t.TempDir()
+	writeFiles(t, rootDir, map[string]string{
+		".neev/foundation/users.md": "# Users\n",
+		".neev/blueprints/users/architecture.md": "# Users\n\n" +
+			"## Data Model\n\n" +
+			"```\n" +
+			"CREATE TABLE users (\n" +
+			"    id BIGINT PRIMARY KEY,\n" +
+			"    email VARCHAR(255) NOT NULL,\n" +
+			"    age INTEGER,\n" +
+			"    nickname TEXT\n" +
+			");\n" +
+			"```\n\n" +
+			"## Audit\n\n" +
+			"```sql\nCREATE TABLE audit_log (id BIGINT);\n```\n\n" +
+			"```\nCREATE TABLE scratch (id INT);\n```\n",
+		"migrations/0001_users.up.sql": "CREATE TABLE users (\n    id BIGSERIAL PRIMARY KEY,\n    email TEXT NOT NULL\n);\n",
+		"migrations/0002_age.up.sql":   "ALTER TABLE users ADD COLUMN age TEXT;\n",
+		"migrations/0002_age.down.sql": "ALTER TABLE users DROP COLUMN age;\n",
+	})
+
+	result, err := Inspect(InspectOptions{
+		RootDir:        rootDir,
+		FoundationPath: filepath.Join(rootDir, ".neev", "foundation"),
+		IgnoreDirs:     map[string]bool{},
+		Depth:          1,
+		CheckDataModel: true,
+	})
+	if err != nil {
+		t.Fatalf("Inspect failed: %v", err)
+	}
+
+	bySubject := make(map[string]Warning)
+	for _, w := range result.Warnings {
+		if w.Type.Check() == CheckDataModel {
+			bySubject[w.Subject] = w
+		}
+	}
+	if w, ok := bySubject["audit_log"]; !ok || w.Type != WarningMissingTable || w.Line != 17 || w.Severity != "error" {
+		t.Errorf("Expected audit_log to be a missing table, got %+v", w)
+	}
+	if w, ok := bySubject["users.nickname"]; !ok || w.Type != WarningMissingColumn || w.Line != 10 {
+		t.Errorf("Expected users.nickname to be a missing column, got %+v", w)
+	}
+	if w, ok := bySubject["users.email"]; !ok || w.Type != WarningColumnTypeMismatch || w.Line != 3 {
+		t.Errorf("Expected users.email to mismatch at the migration, got %+v", w)
+	}
+	if w, ok := bySubject["users.age"]; !ok || w.Type != WarningColumnTypeMismatch || filepath.Base(w.File) != "0002_age.up.sql" {
+		t.Errorf("Expected users.age to mismatch at the ALTER migration, got %+v", w)
+	}
+	if len(bySubject) != 4 {
+		t.Errorf("Expected 4 data model warnings, got %v", bySubject)
+	}
+	if result.Summary.MissingTables != 1 || result.Summary.MissingColumns != 1 || result.Summary.ColumnTypeMismatches != 2 {
+		t.Errorf("Unexpected summary %+v", result.Summary)
+	}
+
+	ran := false
+	for _, check := range result.Checks {
+		ran = ran || check == CheckDataModel
+	}
+	if !ran {
+		t.Errorf("Expected the data model check to run, got %v", result.Checks)
+	}
+}
+
+func TestInspect_DataModelPerMigrationRoot(t *testing.T) {
+	rootDir := t.TempDir()
+	writeFiles(t, rootDir, map[string]string{
+		".neev/foundation/users.md":   "# Users\n\n```sql\nCREATE TABLE accounts (id BIGINT, email TEXT);\n```\n",
+		".neev/foundation/billing.md": "# Billing\n",
+		".neev/blueprints/billing/architecture.md": "# Billing\n\n" +
+			"```sql\nCREATE TABLE accounts (id BIGINT, balance NUMERIC);\nCREATE TABLE sessions (id BIGINT);\n```\n",
+		".neev/blueprints/reporting/architecture.md": "# Reporting\n\n" +
+			"```sql\nCREATE TABLE sessions (id BIGINT, token TEXT);\n```\n",
+		"users/migrations/0001_init.up.sql":   "CREATE TABLE accounts (id BIGINT, email TEXT);\nCREATE TABLE sessions (id BIGINT, token TEXT);\n",
+		"billing/migrations/0001_init.up.sql": "CREATE TABLE accounts (id BIGINT, balance NUMERIC);\n",
+	})
+
+	result, err := Inspect(InspectOptions{
+		RootDir:        rootDir,
+		FoundationPath: filepath.Join(rootDir, ".neev", "foundation"),
+		IgnoreDirs:     map[string]bool{},
+		Depth:          1,
+		CheckDataModel: true,
+	})
+	if err != nil {
+		t.Fatalf("Inspect failed: %v", err)
+	}
+
+	// Each service's accounts table is checked against its own migrations;
+	// billing's sessions table is not created by billing's migrations, while
+	// the unscoped reporting blueprint finds sessions in the users schema
+	var got []string
+	for _, w := range result.Warnings {
+		if w.Type.Check() == CheckDataModel {
+			got = append(got, string(w.Type)+" "+w.Subject)
+		}
+	}
+	want := []string{string(WarningMissingTable) + " sessions"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,314 @@
+package inspect
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ElixirDetector handles Elixir language analysis
+type ElixirDetector struct{}
+
+// NewElixirDetector creates a new Elixir language detector
+func NewElixirDetector() *ElixirDetector {
+	return &ElixirDetector{}
+}
+
+// Detect returns true if this is an Elixir file
+func (d *ElixirDetector) Detect(filePath string) bool {
+	lower := strings.ToLower(filePath)
+	return strings.HasSuffix(lower, ".ex") || strings.HasSuffix(lower, ".exs")
+}
+
+// Language returns the language identifier
+func (d *ElixirDetector) Language() Language {
+	return LangElixir
+}
+
+var (
+	// scope "/api", MyAppWeb do
+	phoenixScopePattern = regexp.MustCompile(`^scope\s+"([^"]*)"`)
+
+	// get "/users/:id", UserController, :show
+	phoenixRoutePattern = regexp.MustCompile(`^(get|post|put|patch|delete|options|head)\s+"([^"]*)"\s*,\s*([\w.]+)\s*,\s*:(\w+)`)
+	phoenixMatchPattern = regexp.MustCompile(`^match\s+:(\*|\w+)\s*,\s*"([^"]*)"\s*,\s*([\w.]+)\s*,\s*:(\w+)`)
+
+	// resources "/posts", PostController, only: [:index, :show]
+	phoenixResourcesPattern  = regexp.MustCompile(`^resources\s+"([^"]*)"\s*,\s*([\w.]+)(.*)$`)
+	phoenixActionListPattern = regexp.MustCompile(`\b(only|except)\s*:\s*\[([^\]]*)\]`)
+
+	elixirModulePattern   = regexp.MustCompile(`^defmodule\s+([\w.]+)\s+do\b`)
+	elixirDefPattern      = regexp.MustCompile(`^(defp?)\s+([a-z_][\w]*[?!]?)\s*(\()?`)
+	elixirSpecPattern     = regexp.MustCompile(`^@spec\s+([a-z_][\w]*[?!]?)\s*\((.*)\)\s*::\s*(.+)$`)
+	elixirFnPattern       = regexp.MustCompile(`\bfn\b.*->$`)
+	elixirVariablePattern = regexp.MustCompile(`^[a-z_]\w*$`)
+)
+
+// phoenixResourceActions are the routes of resources/4 in Phoenix order
+var phoenixResourceActions = []struct {
+	action, method, suffix string
+	member                 bool
+}{
+	{"index", "GET", "", false},
+	{"edit", "GET", "/edit", true},
+	{"new", "GET", "/new", false},
+	{"show", "GET", "", true},
+	{"create", "POST", "", false},
+	{"update", "PATCH", "", true},
+	{"update", "PUT", "", true},
+	{"delete", "DELETE", "", true},
+}
+
+// ExtractEndpoints finds HTTP endpoints in Elixir code
+// Supports: Phoenix router scopes, verb macros and nested resources
+func (d *ElixirDetector) ExtractEndpoints(filePath string, content []byte) ([]Endpoint, error) {
+	var endpoints []Endpoint
+	lines := strings.Split(string(content), "\n")
+
+	// The route prefix inside each open do/end block
+	prefixes := []string{""}
+	current := func() string { return prefixes[len(prefixes)-1] }
+
+	emit := func(lineNum int, method, path, handler string) {
+		endpoints = append(endpoints, Endpoint{
+			Method:   method,
+			Path:     controllerRoutePath(current(), path),
+			Handler:  handler,
+			File:     filePath,
+			Line:     lineNum + 1,
+			Language: string(LangElixir),
+		})
+	}
+
+	for lineNum, line := range lines {
+		trimmedLine := strings.TrimSpace(line)
+		opens, closes := elixirBlocks(trimmedLine)
+
+		prefix := current()
+		if match := phoenixScopePattern.FindStringSubmatch(trimmedLine); match != nil {
+			prefix = joinRoutePath(strings.Trim(current(), "/"), strings.Trim(match[1], "/"))
+		} else if match := phoenixRoutePattern.FindStringSubmatch(trimmedLine); match != nil {
+			emit(lineNum, strings.ToUpper(match[1]), match[2], elixirShortName(match[3])+"."+match[4])
+		} else if match := phoenixMatchPattern.FindStringSubmatch(trimmedLine); match != nil {
+			method := strings.ToUpper(match[1])
+			if method == "*" {
+				method = "ANY"
+			}
+			emit(lineNum, method, match[2], elixirShortName(match[3])+"."+match[4])
+		} else if match := phoenixResourcesPattern.FindStringSubmatch(trimmedLine); match != nil {
+			controller := elixirShortName(match[2])
+			singleton := strings.Contains(match[3], "singleton: true")
+			actions := phoenixActions(match[3])
+			path := "/" + strings.Trim(match[1], "/")
+			for _, route := range phoenixResourceActions {
+				if !actions[route.action] || singleton && route.action == "index" {
+					continue
+				}
+				routePath := path
+				if route.member && !singleton {
+					routePath += "/:id"
+				}
+				emit(lineNum, route.method, routePath+route.suffix, controller+"."+route.action)
+			}
+
+			// Nested resources are scoped below a member, e.g. /posts/:post_id
+			nested := path
+			if !singleton {
+				nested += "/:" + phoenixResourceName(controller) + "_id"
+			}
+			prefix = joinRoutePath(strings.Trim(current(), "/"), strings.Trim(nested, "/"))
+		}
+
+		for i := 0; i < opens; i++ {
+			prefixes = append(prefixes, prefix)
+		}
+		for i := 0; i < closes && len(prefixes) > 1; i++ {
+			prefixes = prefixes[:len(prefixes)-1]
+		}
+	}
+
+	return endpoints, nil
+}
+
+// elixirBlocks counts the do/end and multi-line fn blocks a line opens and closes
+func elixirBlocks(line string) (opens, closes int) {
+	if i := strings.Index(line, "#"); i >= 0 && !strings.Contains(line[:i], `"`) {
+		line = strings.TrimSpace(line[:i])
+	}
+	if line == "end" || strings.HasPrefix(line, "end") && len(line) > 3 && !isAlnum(line[3]) && line[3] != '_' {
+		closes++
+	}
+	if strings.HasSuffix(line, " do") || line == "do" || elixirFnPattern.MatchString(line) {
+		opens++
+	}
+	return opens, closes
+}
+
+// phoenixActions returns the resource actions kept by only: and except:
+func phoenixActions(options string) map[string]bool {
+	actions := make(map[string]bool)
+	match := phoenixActionListPattern.FindStringSubmatch(options)
+	listed := make(map[string]bool)
+	if match != nil {
+		for _, atom := range strings.Split(match[2], ",") {
+			listed[strings.TrimPrefix(strings.TrimSpace(atom), ":")] = true
+		}
+	}
+	for _, route := range phoenixResourceActions {
+		switch {
+		case match == nil:
+			actions[route.action] = true
+		case match[1] == "only":
+			actions[route.action] = listed[route.action]
+		default:
+			actions[route.action] = !listed[route.action]
+		}
+	}
+	return actions
+}
+
+// phoenixResourceName derives the name Phoenix gives a resource from its
+// controller: PostController -> post, UserProfileController -> user_profile
+func phoenixResourceName(controller string) string {
+	name := strings.TrimSuffix(controller, "Controller")
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// elixirShortName returns the last segment of a module alias
+func elixirShortName(module string) string {
+	return module[strings.LastIndex(module, ".")+1:]
+}
+
+// elixirSpec holds the types of an @spec awaiting its function
+type elixirSpec struct {
+	params  []string
+	returns string
+}
+
+// ExtractFunctions finds def and defp functions in Elixir code, typed by a
+// preceding @spec when there is one
+func (d *ElixirDetector) ExtractFunctions(filePath string, content []byte) ([]FunctionSignature, error) {
+	var functions []FunctionSignature
+	text := string(content)
+
+	// The module that owns each open do/end block
+	modules := []string{""}
+	specs := make(map[string]elixirSpec)
+	seen := make(map[string]bool)
+
+	offset := 0
+	for lineNum, line := range strings.Split(text, "\n") {
+		lineStart := offset
+		offset += len(line) + 1
+
+		trimmedLine := strings.TrimSpace(line)
+		indent := strings.Index(line, trimmedLine)
+		opens, closes := elixirBlocks(trimmedLine)
+		module := modules[len(modules)-1]
+
+		if match := elixirModulePattern.FindStringSubmatch(trimmedLine); match != nil {
+			module = match[1]
+			if parent := modules[len(modules)-1]; parent != "" {
+				module = parent + "." + module
+			}
+		} else if match := elixirSpecPattern.FindStringSubmatch(trimmedLine); match != nil {
+			spec := elixirSpec{returns: strings.TrimSpace(match[3])}
+			for _, param := range splitTopLevel(match[2], ',') {
+				spec.params = append(spec.params, strings.TrimSpace(param))
+			}
+			specs[match[1]] = spec
+		} else if match := elixirDefPattern.FindStringSubmatchIndex(trimmedLine); match != nil {
+			kind := trimmedLine[match[2]:match[3]]
+			name := trimmedLine[match[4]:match[5]]
+
+			var params []ParameterSpec
+			if match[6] >= 0 {
+				open := lineStart + indent + match[6]
+				if closeIdx := matchingParen(text, open); closeIdx >= 0 {
+					params = d.parseElixirParameters(text[open+1 : closeIdx])
+				}
+			}
+
+			// Functions with several clauses are reported once
+			key := module + "." + name + "/" + strconv.Itoa(len(params))
+			if !seen[key] {
+				seen[key] = true
+
+				var returns []ReturnSpec
+				if spec, ok := specs[name]; ok && len(spec.params) == len(params) {
+					for i := range params {
+						params[i].Type = spec.params[i]
+					}
+					returns = append(returns, ReturnSpec{Type: spec.returns})
+				}
+				delete(specs, name)
+
+				visibility := "public"
+				if kind == "defp" {
+					visibility = "private"
+				}
+
+				functions = append(functions, FunctionSignature{
+					Name:       name,
+					Receiver:   module,
+					Parameters: params,
+					Returns:    returns,
+					File:       filePath,
+					Line:       lineNum + 1,
+					Visibility: visibility,
+				})
+			}
+		}
+
+		for i := 0; i < opens; i++ {
+			modules = append(modules, module)
+		}
+		for i := 0; i < closes && len(modules) > 1; i++ {
+			modules = modules[:len(modules)-1]
+		}
+	}
+
+	return functions, nil
+}
+
+// parseElixirParameters parses the parameters of a function head. Pattern
+// matches bind their variable (%{"id" => id} = params is params) and defaults given
+// with \\ are dropped. Types come from @spec; without one they are "any".
+func (d *ElixirDetector) parseElixirParameters(paramsStr string) []ParameterSpec {
+	var params []ParameterSpec
+
+	for _, part := range splitTopLevel(paramsStr, ',') {
+		part = strings.TrimSpace(part)
+		if i := strings.Index(part, `\\`); i >= 0 {
+			part = strings.TrimSpace(part[:i])
+		}
+		if pieces := splitTopLevel(part, '='); len(pieces) > 1 {
+			for _, piece := range pieces {
+				if piece = strings.TrimSpace(piece); elixirVariablePattern.MatchString(piece) {
+					part = piece
+					break
+				}
+			}
+		}
+		if part == "" {
+			continue
+		}
+		params = append(params, ParameterSpec{
+			Name: part,
+			Type: "any",
+		})
+	}
+
+	return params
+}
@@ -0,0 +1,253 @@
+package inspect
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// EnvVar is an environment variable, either declared by a spec or descriptor
+// or read in code
+type EnvVar struct {
+	Name string
+	File string
+	Line int
+}
+
+var (
+	// Spec sections that declare environment variables: only headings naming
+	// them, such as "Environment Variables" or "Env vars", since sections about
+	// configuration or the deployment environment list other things too
+	envHeadingPattern = regexp.MustCompile(`(?i)\benv(?:ironment)?[\s_-]+var(?:iable)?s?\b`)
+
+	// Names in an environment section: `NAME`, "- NAME ...", "| NAME |" and NAME=value
+	envNamePattern     = regexp.MustCompile("`([A-Z][A-Z0-9_]+)`")
+	envListPattern     = regexp.MustCompile(`^\s*(?:[-*+]|\d+\.)\s+\**([A-Z][A-Z0-9_]+)\b`)
+	envTableRowPattern = regexp.MustCompile("^\\s*\\|\\s*[`*]*([A-Z][A-Z0-9_]+)[`*]*\\s*\\|")
+	envAssignPattern   = regexp.MustCompile(`^\s*(?:export\s+)?([A-Z][A-Z0-9_]+)=`)
+)
+
+// envReadPatterns find environment variable reads. The group holds the name;
+// names computed at runtime are not found.
+var envReadPatterns = []struct {
+	language Language // Only files of this language; empty for any
+	pattern  *regexp.Regexp
+}{
+	// Go os.Getenv("PORT") and os.LookupEnv("PORT")
+	{LangGo, regexp.MustCompile(`\bos\.(?:Getenv|LookupEnv)\(\s*"(\w+)"`)},
+	// Node process.env.PORT and process.env["PORT"], Vite import.meta.env.VITE_API
+	{"", regexp.MustCompile(`\bprocess\.env\.([A-Za-z_]\w*)`)},
+	{"", regexp.MustCompile(`\bprocess\.env\[\s*["'\x60](\w+)["'\x60]\s*\]`)},
+	{"", regexp.MustCompile(`\bimport\.meta\.env\.([A-Za-z_]\w*)`)},
+	// Python os.environ["PORT"], os.environ.get("PORT"), os.getenv("PORT")
+	{LangPython, regexp.MustCompile(`\b(?:os\.)?environ(?:\.get)?(?:\[|\()\s*["'](\w+)["']`)},
+	{LangPython, regexp.MustCompile(`\bos\.getenv\(\s*["'](\w+)["']`)},
+	// Java and Kotlin System.getenv("PORT")
+	{"", regexp.MustCompile(`\bSystem\.getenv\(\s*"(\w+)"`)},
+	// Ruby ENV["PORT"] and ENV.fetch("PORT")
+	{LangRuby, regexp.MustCompile(`\bENV(?:\[|\.fetch\()\s*["'](\w+)["']`)},
+	// Rust std::env::var("PORT") and env!("PORT")
+	{LangRust, regexp.MustCompile(`\benv(?:::var(?:_os)?\(|!\()\s*"(\w+)"`)},
+	// PHP getenv('PORT'), $_ENV['PORT'] and Laravel env('PORT')
+	{LangPHP, regexp.MustCompile(`(?:\bgetenv\(|\$_ENV\[|\$_SERVER\[|\benv\()\s*["'](\w+)["']`)},
+	// Elixir System.get_env("PORT") and System.fetch_env!("PORT")
+	{LangElixir, regexp.MustCompile(`\bSystem\.(?:get_env|fetch_env!?)\(\s*"(\w+)"`)},
+	// C# Environment.GetEnvironmentVariable("PORT")
+	{LangCSharp, regexp.MustCompile(`\bEnvironment\.GetEnvironmentVariable\(\s*"(\w+)"`)},
+}
+
+// processEnvDestructurePattern finds const { PORT, HOST: host } = process.env
+var processEnvDestructurePattern = regexp.MustCompile(`\{([^{}]*)\}\s*=\s*process\.env\b`)
+
+// ValidateConfigContract compares the environment variables declared in specs
+// and module descriptors with those that code found by a repository scan reads
+func ValidateConfigContract(opts InspectOptions, scan *ScanResult) ([]Warning, error) {
+	var reads []EnvVar
+	for _, file := range scan.Facts[envReadExtractor.Name] {
+		for _, read := range file.Facts.([]EnvVar) {
+			read.File = file.Path
+			reads = append(reads, read)
+		}
+	}
+
+	return compareEnvVars(collectDeclaredEnvVars(opts), reads), nil
+}
+
+// collectDeclaredEnvVars gathers the variables listed in environment sections
+// of foundation and blueprint documents, and the env lists of module descriptors
+func collectDeclaredEnvVars(opts InspectOptions) []EnvVar {
+	var declared []EnvVar
+
+	files := specFiles(opts, []string{opts.FoundationPath, blueprintsDir(opts)}, func(path string) bool {
+		return strings.HasSuffix(path, ".md")
+	})
+	sort.Strings(files)
+
+	for _, path := range files {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		declared = append(declared, ParseEnvSection(path, string(content))...)
+	}
+
+	descriptors, _ := filepath.Glob(filepath.Join(opts.FoundationPath, "*.module.yaml"))
+	for _, path := range descriptors {
+		descriptor, err := loadModuleDescriptor(path)
+		if err != nil {
+			continue // Unreadable descriptors are skipped, as in getFoundationModules
+		}
+		for _, name := range descriptor.Env {
+			declared = append(declared, EnvVar{Name: name, File: path})
+		}
+	}
+
+	return declared
+}
+
+// ParseEnvSection extracts the variables declared under headings naming
+// environment variables in a Markdown document, e.g. "## Environment
+// Variables". Names are upper snake case in backticks, list items, the first
+// column of a table or NAME=value lines.
+func ParseEnvSection(filePath, text string) []EnvVar {
+	var vars []EnvVar
+	inSection, sectionLevel := false, 0
+	seen := make(map[string]bool)
+
+	for lineNum, line := range strings.Split(text, "\n") {
+		if match := headingPattern.FindStringSubmatch(line); match != nil {
+			level := len(line) - len(strings.TrimLeft(line, "#"))
+			if inSection && level > sectionLevel {
+				continue // Subsections belong to the environment section
+			}
+			inSection, sectionLevel = envHeadingPattern.MatchString(match[1]), level
+			continue
+		}
+		if !inSection {
+			continue
+		}
+
+		var names []string
+		for _, pattern := range []*regexp.Regexp{envListPattern, envTableRowPattern, envAssignPattern} {
+			if match := pattern.FindStringSubmatch(line); match != nil {
+				names = append(names, match[1])
+			}
+		}
+		for _, match := range envNamePattern.FindAllStringSubmatch(line, -1) {
+			names = append(names, match[1])
+		}
+		for _, name := range names {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			vars = append(vars, EnvVar{Name: name, File: filePath, Line: lineNum + 1})
+		}
+	}
+
+	return vars
+}
+
+// envReadExtractor collects environment variable reads during the repository
+// scan. Tests are skipped, since they commonly read variables of the CI
+// environment.
+var envReadExtractor = FileExtractor{
+	Name:    "env",
+	Version: "1",
+	Match: func(path string) bool {
+		return DetectLanguageByExtension(path) != "" && !isTestSource(path)
+	},
+	Extract: func(path string, content []byte) interface{} {
+		return ExtractEnvReads(path, string(content))
+	},
+	Decode: decodeFacts[[]EnvVar],
+}
+
+// isTestSource reports whether a file name follows a test naming convention
+func isTestSource(path string) bool {
+	name := filepath.Base(path)
+	base := strings.TrimSuffix(name, filepath.Ext(name))
+	lower := strings.ToLower(base)
+	return strings.HasPrefix(lower, "test_") || strings.HasSuffix(lower, "_test") || strings.HasSuffix(lower, "_spec") ||
+		strings.HasSuffix(lower, ".test") || strings.HasSuffix(lower, ".spec") ||
+		strings.HasSuffix(base, "Test") || strings.HasSuffix(base, "Tests")
+}
+
+// ExtractEnvReads finds the environment variables a source file reads by
+// literal name
+func ExtractEnvReads(filePath, text string) []EnvVar {
+	var reads []EnvVar
+	language := DetectLanguageByExtension(filePath)
+
+	add := func(name string, offset int) {
+		reads = append(reads, EnvVar{
+			Name: name,
+			File: filePath,
+			Line: strings.Count(text[:offset], "\n") + 1,
+		})
+	}
+
+	for _, read := range envReadPatterns {
+		if read.language != "" && read.language != language {
+			continue
+		}
+		for _, match := range read.pattern.FindAllStringSubmatchIndex(text, -1) {
+			add(text[match[2]:match[3]], match[0])
+		}
+	}
+
+	for _, match := range processEnvDestructurePattern.FindAllStringSubmatchIndex(text, -1) {
+		for _, part := range strings.Split(text[match[2]:match[3]], ",") {
+			name := strings.TrimSpace(part)
+			if end := strings.IndexAny(name, ":= "); end >= 0 {
+				name = name[:end]
+			}
+			if name != "" && !strings.HasPrefix(name, "...") {
+				add(name, match[0])
+			}
+		}
+	}
+
+	sort.SliceStable(reads, func(i, j int) bool { return reads[i].Line < reads[j].Line })
+	return reads
+}
+
+// compareEnvVars reports variables code reads that no spec declares, and
+// declared variables that no code reads
+func compareEnvVars(declared, reads []EnvVar) []Warning {
+	var warnings []Warning
+	unused, undocumented := contractDiff(declared, reads, func(v EnvVar) string { return v.Name }, nil)
+
+	// Read but not declared
+	for _, v := range undocumented {
+		warnings = append(warnings, Warning{
+			Type:        WarningUndocumentedEnvVar,
+			Module:      "config",
+			Subject:     v.Name,
+			Message:     fmt.Sprintf("Environment variable '%s' is read but not documented (found in %s:%d)", v.Name, filepath.Base(v.File), v.Line),
+			Severity:    "warning",
+			Remediation: fmt.Sprintf("Document '%s' in the environment section of stack.md or the blueprint", v.Name),
+			File:        v.File,
+			Line:        v.Line,
+		})
+	}
+
+	// Declared but never read
+	for _, v := range unused {
+		warnings = append(warnings, Warning{
+			Type:        WarningUnusedEnvVar,
+			Module:      "config",
+			Subject:     v.Name,
+			Message:     fmt.Sprintf("Environment variable '%s' is documented in %s but never read", v.Name, filepath.Base(v.File)),
+			Severity:    "warning",
+			Remediation: fmt.Sprintf("Read '%s' in code or remove it from the documented configuration", v.Name),
+			File:        v.File,
+			Line:        v.Line,
+		})
+	}
+
+	return warnings
+}
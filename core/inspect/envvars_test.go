@@ -0,0 +1,146 @@
+package inspect
+
+import (
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestParseEnvSection(t *testing.T) {
+	doc := "# Technology Stack\n\n" +
+		"We use Go and `POSTGRES`.\n\n" +
+		"## Environment Variables\n\n" +
+		"- DATABASE_URL - connection string\n" +
+		"- `REDIS_URL` (optional)\n\n" +
+		"### Secrets\n\n" +
+		"| Name | Description |\n" +
+		"|------|-------------|\n" +
+		"| `JWT_SECRET` | Signs tokens |\n\n" +
+		"```env\nPORT=8080\n```\n\n" +
+		"## Deployment\n\n" +
+		"- KUBECONFIG is set by CI\n\n" +
+		"## Configuration\n\n" +
+		"- `TIMEOUT_MS` in config.yaml\n\n" +
+		"## Environment\n\n" +
+		"- AWS_REGION `US_EAST_1`\n"
+
+	var got []string
+	for _, v := range ParseEnvSection("stack.md", doc) {
+		got = append(got, v.Name+" "+strconv.Itoa(v.Line))
+	}
+	want := []string{"DATABASE_URL 7", "REDIS_URL 8", "JWT_SECRET 14", "PORT 17"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestExtractEnvReads(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		code string
+		want []string
+	}{
+		{
+			name: "go",
+			file: "main.go",
+			code: `port := os.Getenv("PORT")
+if url, ok := os.LookupEnv("DATABASE_URL"); ok {
+	key := os.Getenv(prefix + "_KEY")
+}`,
+			want: []string{"PORT 1", "DATABASE_URL 2"},
+		},
+		{
+			name: "node",
+			file: "server.ts",
+			code: `const port = process.env.PORT ?? 3000
+const { REDIS_URL, JWT_SECRET: secret, ...rest } = process.env
+const api = import.meta.env.VITE_API_URL
+const key = process.env['API_KEY']`,
+			want: []string{"PORT 1", "REDIS_URL 2", "JWT_SECRET 2", "VITE_API_URL 3", "API_KEY 4"},
+		},
+		{
+			name: "python",
+			file: "settings.py",
+			code: `DEBUG = os.environ.get("DEBUG", "0") == "1"
+SECRET_KEY = os.environ["SECRET_KEY"]
+REGION = os.getenv('AWS_REGION')`,
+			want: []string{"DEBUG 1", "SECRET_KEY 2", "AWS_REGION 3"},
+		},
+		{
+			name: "java",
+			file: "Config.java",
+			code: `String url = System.getenv("DATABASE_URL");`,
+			want: []string{"DATABASE_URL 1"},
+		},
+		{
+			name: "ruby",
+			file: "config.rb",
+			code: `host = ENV["HOST"]
+port = ENV.fetch("PORT", 3000)`,
+			want: []string{"HOST 1", "PORT 2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, v := range ExtractEnvReads(tt.file, tt.code) {
+				got = append(got, v.Name+" "+strconv.Itoa(v.Line))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestInspect_ConfigContract(t *testing.T) {
+	rootDir := t.TempDir()
+	writeFiles(t, rootDir, map[string]string{
+		".neev/foundation/stack.md":            "# Stack\n\n## Environment Variables\n\n- `PORT`\n- `LOG_LEVEL`\n",
+		".neev/foundation/billing.module.yaml": "name: billing\nenv:\n  - STRIPE_KEY\n",
+		"cmd/main.go": `package main
+
+func main() {
+	_ = os.Getenv("PORT")
+	_ = os.Getenv("STRIPE_KEY")
+	_ = os.Getenv("FEATURE_FLAGS")
+}
+`,
+		"cmd/main_test.go": "package main\n\nvar ci = os.Getenv(\"CI\")\n",
+	})
+
+	result, err := Inspect(InspectOptions{
+		RootDir:        rootDir,
+		FoundationPath: filepath.Join(rootDir, ".neev", "foundation"),
+		IgnoreDirs:     map[string]bool{},
+		Depth:          2,
+	})
+	if err != nil {
+		t.Fatalf("Inspect failed: %v", err)
+	}
+
+	bySubject := make(map[string]Warning)
+	for _, w := range result.Warnings {
+		if w.Type.Check() == CheckConfig {
+			bySubject[w.Subject] = w
+		}
+	}
+	if w, ok := bySubject["FEATURE_FLAGS"]; !ok || w.Type != WarningUndocumentedEnvVar || w.Line != 6 || w.Module != "config" {
+		t.Errorf("Expected FEATURE_FLAGS to be undocumented, got %+v", w)
+	}
+	if w, ok := bySubject["LOG_LEVEL"]; !ok || w.Type != WarningUnusedEnvVar || w.Line != 6 {
+		t.Errorf("Expected LOG_LEVEL to be unused, got %+v", w)
+	}
+	if len(bySubject) != 2 {
+		t.Errorf("Expected 2 config warnings, got %v", bySubject)
+	}
+	if result.Summary.UndocumentedEnvVars != 1 || result.Summary.UnusedEnvVars != 1 {
+		t.Errorf("Expected 1 undocumented and 1 unused env var, got %+v", result.Summary)
+	}
+	if CheckConfig.PerModule() || CheckConfig.Module() != "config" {
+		t.Errorf("Expected config to be a repository-wide check reported against the config module")
+	}
+}
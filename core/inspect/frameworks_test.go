@@ -0,0 +1,266 @@
+package inspect
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Fixtures live in testdata/frameworks/<framework>/, one small application each
+
+func TestFrameworkFixtures_Endpoints(t *testing.T) {
+	tests := []struct {
+		name     string
+		detector LanguageDetector
+		fixture  string
+		want     []string
+	}{
+		{
+			name:     "ktor",
+			detector: &KotlinDetector{},
+			fixture:  "ktor/Routing.kt",
+			want: []string{
+				"GET /health ",
+				"GET /api/users ",
+				"POST /api/users ",
+				"GET /api/users/{id} ",
+				"DELETE /api/users/{id} ",
+				"PUT /settings ",
+			},
+		},
+		{
+			name:     "spring kotlin",
+			detector: &KotlinDetector{},
+			fixture:  "spring-kotlin/UserController.kt",
+			want: []string{
+				"GET /api/users list",
+				"GET /api/users/{id} get",
+				"POST /api/users create",
+				"DELETE /api/users/{id} delete",
+				"ANY /api/users/search search",
+			},
+		},
+		{
+			name:     "axum",
+			detector: &RustDetector{},
+			fixture:  "axum/main.rs",
+			want: []string{
+				"GET /api/users list_users",
+				"POST /api/users create_user",
+				"GET /api/users/:id get_user",
+				"DELETE /api/users/:id handlers::delete_user",
+				"GET /health ",
+				"GET /admin/stats stats",
+			},
+		},
+		{
+			name:     "actix",
+			detector: &RustDetector{},
+			fixture:  "actix/main.rs",
+			want: []string{
+				"GET /api/users list_users",
+				"POST /api/users create_user",
+				"GET /ping ping",
+				"HEAD /ping ping",
+				"GET /orders/{id} get_order",
+				"DELETE /orders/{id} delete_order",
+				"GET /version version",
+			},
+		},
+		{
+			name:     "laravel",
+			detector: &PHPDetector{},
+			fixture:  "laravel/routes/api.php",
+			want: []string{
+				"GET /api/users UserController@index",
+				"POST /api/users UserController@store",
+				"GET /api/search SearchController",
+				"POST /api/search SearchController",
+				"ANY /api/ping ",
+				"DELETE /api/admin/users/{user} UserController@destroy",
+				"GET /api/admin/reports/daily ReportController@daily",
+				"GET /api/photos PhotoController@index",
+				"GET /api/photos/{photo} PhotoController@show",
+				"GET /api/photos/{photo}/comments CommentController@index",
+				"POST /api/photos/{photo}/comments CommentController@store",
+				"GET /api/photos/{photo}/comments/{comment} CommentController@show",
+				"PUT /api/photos/{photo}/comments/{comment} CommentController@update",
+				"PATCH /api/photos/{photo}/comments/{comment} CommentController@update",
+				"DELETE /api/photos/{photo}/comments/{comment} CommentController@destroy",
+			},
+		},
+		{
+			name:     "phoenix",
+			detector: &ElixirDetector{},
+			fixture:  "phoenix/router.ex",
+			want: []string{
+				"GET /health HealthController.show",
+				"GET /api/users/:id UserController.show",
+				"POST /api/users UserController.create",
+				"ANY /api/echo EchoController.echo",
+				"GET /api/posts PostController.index",
+				"GET /api/posts/:id PostController.show",
+				"GET /api/posts/:post_id/comments CommentController.index",
+				"GET /api/posts/:post_id/comments/:id CommentController.show",
+				"POST /api/posts/:post_id/comments CommentController.create",
+				"PATCH /api/posts/:post_id/comments/:id CommentController.update",
+				"PUT /api/posts/:post_id/comments/:id CommentController.update",
+				"DELETE /api/posts/:post_id/comments/:id CommentController.delete",
+				"GET /api/account AccountController.show",
+				"PATCH /api/account AccountController.update",
+				"PUT /api/account AccountController.update",
+				"DELETE /api/admin/cache CacheController.clear",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, content := readFixture(t, tt.fixture)
+			if !tt.detector.Detect(path) {
+				t.Fatalf("Expected %s detector to accept %s", tt.detector.Language(), path)
+			}
+
+			endpoints, err := tt.detector.ExtractEndpoints(path, content)
+			if err != nil {
+				t.Fatalf("ExtractEndpoints failed: %v", err)
+			}
+
+			var got []string
+			for _, ep := range endpoints {
+				got = append(got, ep.Method+" "+ep.Path+" "+ep.Handler)
+				if ep.Language != string(tt.detector.Language()) {
+					t.Errorf("Expected language %s, got %s", tt.detector.Language(), ep.Language)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected:\n%v\ngot:\n%v", strings.Join(tt.want, "\n"), strings.Join(got, "\n"))
+			}
+		})
+	}
+}
+
+func TestFrameworkFixtures_Functions(t *testing.T) {
+	tests := []struct {
+		name     string
+		detector LanguageDetector
+		fixture  string
+		want     []string
+	}{
+		{
+			name:     "kotlin",
+			detector: &KotlinDetector{},
+			fixture:  "ktor/Routing.kt",
+			want: []string{
+				"public Application.configureRouting()",
+				"public UserService.find(id String, includeDeleted Boolean) User?",
+				"public UserService.list() List<User>",
+				"internal UserService.create(user User, onCreated (User) -> Unit) User",
+				"private UserService.audit(events String)",
+				"public String.toUserId() Long",
+			},
+		},
+		{
+			name:     "rust",
+			detector: &RustDetector{},
+			fixture:  "axum/main.rs",
+			want: []string{
+				"private main()",
+				"public list_users(State(state) State<AppState>) Json<Vec<User>>",
+				"public get_user(Path(id) Path<u64>, State(state) State<AppState>) Result<Json<User>, StatusCode>",
+				"private create_user(Json(payload) Json<CreateUser>) impl IntoResponse",
+				"public UserStore.find(id u64) Option<User>",
+				"private UserStore.insert(name &'static str)",
+			},
+		},
+		{
+			name:     "php",
+			detector: &PHPDetector{},
+			fixture:  "laravel/UserController.php",
+			want: []string{
+				"public UserController.__construct(users UserService)",
+				"public UserController.index(request Request) JsonResponse",
+				"public UserController.store(request Request, role ?string) JsonResponse",
+				"protected UserController.authorizeUser(user User)",
+				"public UserController.helper(args mixed)",
+				"public format_name(first string, last string) string",
+			},
+		},
+		{
+			name:     "elixir",
+			detector: &ElixirDetector{},
+			fixture:  "phoenix/accounts.ex",
+			want: []string{
+				"public MyApp.Accounts.get_user(id integer(), opts keyword()) User.t() | nil",
+				"public MyApp.Accounts.create_user(attrs map()) {:ok, User.t()} | {:error, Ecto.Changeset.t()}",
+				"public MyApp.Accounts.list_users()",
+				"private MyApp.Accounts.normalize(email any)",
+				"public MyApp.Accounts.Token.sign(user any)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, content := readFixture(t, tt.fixture)
+			functions, err := tt.detector.ExtractFunctions(path, content)
+			if err != nil {
+				t.Fatalf("ExtractFunctions failed: %v", err)
+			}
+
+			var got []string
+			for _, fn := range functions {
+				got = append(got, describeFunction(fn))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected:\n%v\ngot:\n%v", strings.Join(tt.want, "\n"), strings.Join(got, "\n"))
+			}
+		})
+	}
+}
+
+func TestDetectLanguageByExtension_AdditionalLanguages(t *testing.T) {
+	tests := map[string]Language{
+		"src/main/kotlin/App.kt": LangKotlin,
+		"build.gradle.kts":       LangKotlin,
+		"src/main.rs":            LangRust,
+		"routes/web.php":         LangPHP,
+		"lib/app_web/router.ex":  LangElixir,
+		"test/app_test.exs":      LangElixir,
+	}
+	for path, want := range tests {
+		if got := DetectLanguageByExtension(path); got != want {
+			t.Errorf("DetectLanguageByExtension(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+// readFixture reads a framework fixture and returns its path and content
+func readFixture(t *testing.T, name string) (string, []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", "frameworks", filepath.FromSlash(name))
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	return path, content
+}
+
+// describeFunction renders a signature as "visibility Receiver.name(params) returns"
+func describeFunction(fn FunctionSignature) string {
+	name := fn.Name
+	if fn.Receiver != "" {
+		name = fn.Receiver + "." + name
+	}
+	var params []string
+	for _, p := range fn.Parameters {
+		params = append(params, p.Name+" "+p.Type)
+	}
+	var returns []string
+	for _, r := range fn.Returns {
+		returns = append(returns, r.Type)
+	}
+	return strings.TrimSpace(fn.Visibility + " " + name + "(" + strings.Join(params, ", ") + ") " + strings.Join(returns, ", "))
+}
@@ -0,0 +1,178 @@
+package inspect
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// File-system routing: frameworks that derive API routes from file paths
+// rather than registration calls. Supported layouts:
+//
+//	Next.js app router    app/api/users/[id]/route.ts   exports GET, POST, ...
+//	Next.js pages router  pages/api/users/[id].ts       default export, methods from req.method checks
+//	SvelteKit             src/routes/users/+server.ts   exports GET, POST, ...
+//	Nuxt (Nitro)          server/api/users/[id].get.ts  method from the file name suffix
+
+// httpMethods are the method names exported by file-routed handlers
+var httpMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
+
+var (
+	// export async function GET(, export const POST = ..., export { handler as PUT }
+	exportedMethodPattern = regexp.MustCompile(`export\s+(?:async\s+)?(?:function\s+|const\s+|let\s+)(GET|POST|PUT|PATCH|DELETE|HEAD|OPTIONS)\b|\bas\s+(GET|POST|PUT|PATCH|DELETE|HEAD|OPTIONS)\b`)
+
+	// req.method === 'POST' or case 'POST':
+	requestMethodPattern = regexp.MustCompile(`(?:\.method\s*[!=]==?\s*|case\s+)["'\x60](GET|POST|PUT|PATCH|DELETE|HEAD|OPTIONS)["'\x60]`)
+
+	// [id], [...slug], [[...slug]] and SvelteKit's [id=matcher]
+	dynamicSegmentPattern = regexp.MustCompile(`^\[{1,2}(?:\.\.\.)?([^\]=]+)(?:=[^\]]+)?\]{1,2}$`)
+)
+
+// extractFileRoutes infers endpoints from the path of a file-routed handler
+// and the methods it exports. Files outside a routing layout yield nothing.
+func extractFileRoutes(filePath string, content []byte, lang Language) []Endpoint {
+	segments := strings.Split(filepath.ToSlash(filePath), "/")
+	file := segments[len(segments)-1]
+	base := strings.TrimSuffix(file, filepath.Ext(file))
+	dirs := segments[:len(segments)-1]
+
+	var routeSegments []string
+	var methods []string
+
+	switch {
+	case base == "route" && lastIndex(dirs, "app") >= 0:
+		// Next.js app router: every directory below app/ is a path segment
+		routeSegments = dirs[lastIndex(dirs, "app")+1:]
+		methods = exportedMethods(content)
+
+	case base == "+server" && lastIndex(dirs, "routes") >= 0:
+		// SvelteKit endpoints
+		routeSegments = dirs[lastIndex(dirs, "routes")+1:]
+		methods = exportedMethods(content)
+
+	case followedBy(dirs, "pages", "api") >= 0:
+		// Next.js pages router: the file name is the last segment
+		if !strings.Contains(string(content), "export default") {
+			return nil
+		}
+		routeSegments = append(copyOf(dirs[followedBy(dirs, "pages", "api")+1:]), base)
+		methods = requestMethods(content)
+
+	case followedBy(dirs, "server", "api") >= 0 || followedBy(dirs, "server", "routes") >= 0:
+		// Nuxt server routes: server/api keeps its /api prefix, server/routes has none.
+		// Express apps use the same directories, so require a Nitro event handler.
+		if !strings.Contains(string(content), "eventHandler(") && !strings.Contains(string(content), "EventHandler(") {
+			return nil
+		}
+		start := followedBy(dirs, "server", "api") + 1
+		if start == 0 {
+			start = followedBy(dirs, "server", "routes") + 2
+		}
+		method := ""
+		if dot := strings.LastIndex(base, "."); dot >= 0 && isHTTPMethod(base[dot+1:]) {
+			method = strings.ToUpper(base[dot+1:])
+			base = base[:dot]
+		}
+		routeSegments = append(copyOf(dirs[start:]), base)
+		if method != "" {
+			methods = []string{method}
+		}
+
+	default:
+		return nil
+	}
+
+	if len(methods) == 0 {
+		methods = []string{"ANY"}
+	}
+
+	path := fileRoutePath(routeSegments)
+	var endpoints []Endpoint
+	for _, method := range methods {
+		endpoints = append(endpoints, Endpoint{
+			Method:   method,
+			Path:     path,
+			File:     filePath,
+			Line:     1,
+			Language: string(lang),
+		})
+	}
+	return endpoints
+}
+
+// fileRoutePath renders routing directories as an API path. Route groups
+// "(name)", parallel routes "@slot" and index files add no segment; dynamic
+// segments become parameters.
+func fileRoutePath(segments []string) string {
+	var parts []string
+	for _, segment := range segments {
+		switch {
+		case segment == "" || segment == "index":
+			continue
+		case strings.HasPrefix(segment, "(") && strings.HasSuffix(segment, ")"):
+			continue
+		case strings.HasPrefix(segment, "@"):
+			continue
+		}
+		if match := dynamicSegmentPattern.FindStringSubmatch(segment); match != nil {
+			segment = "{" + match[1] + "}"
+		}
+		parts = append(parts, segment)
+	}
+	return "/" + strings.Join(parts, "/")
+}
+
+// exportedMethods returns the HTTP methods a route module exports, in a fixed order
+func exportedMethods(content []byte) []string {
+	found := make(map[string]bool)
+	for _, match := range exportedMethodPattern.FindAllStringSubmatch(string(content), -1) {
+		found[match[1]+match[2]] = true
+	}
+	return orderedMethods(found)
+}
+
+// requestMethods returns the HTTP methods a catch-all handler checks for
+func requestMethods(content []byte) []string {
+	found := make(map[string]bool)
+	for _, match := range requestMethodPattern.FindAllStringSubmatch(string(content), -1) {
+		found[match[1]] = true
+	}
+	return orderedMethods(found)
+}
+
+// orderedMethods lists the found methods in httpMethods order
+func orderedMethods(found map[string]bool) []string {
+	var methods []string
+	for _, method := range httpMethods {
+		if found[method] {
+			methods = append(methods, method)
+		}
+	}
+	return methods
+}
+
+// lastIndex returns the index of the last segment equal to name, or -1
+func lastIndex(segments []string, name string) int {
+	for i := len(segments) - 1; i >= 0; i-- {
+		if segments[i] == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// followedBy returns the index of the last segment "first" directly followed
+// by "second", or -1
+func followedBy(segments []string, first, second string) int {
+	for i := len(segments) - 2; i >= 0; i-- {
+		if segments[i] == first && segments[i+1] == second {
+			return i
+		}
+	}
+	return -1
+}
+
+// copyOf returns a copy of segments that can be appended to safely
+func copyOf(segments []string) []string {
+	return append([]string(nil), segments...)
+}
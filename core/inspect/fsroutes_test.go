@@ -0,0 +1,93 @@
+package inspect
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/neev-kit/neev/core/openapi"
+)
+
+func TestExtractFileRoutes(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		content string
+		want    []string
+	}{
+		{
+			name:    "next app router",
+			path:    "/repo/web/src/app/api/users/[id]/route.ts",
+			content: "export async function GET(req: Request) {}\nexport const DELETE = handler\n",
+			want:    []string{"GET /api/users/{id}", "DELETE /api/users/{id}"},
+		},
+		{
+			name:    "next app router groups and catch-all",
+			path:    "app/(admin)/api/files/[...path]/route.js",
+			content: "async function handler() {}\nexport { handler as GET, handler as HEAD }\n",
+			want:    []string{"GET /api/files/{path}", "HEAD /api/files/{path}"},
+		},
+		{
+			name:    "next pages router",
+			path:    "pages/api/orders/index.ts",
+			content: "export default function handler(req, res) {\n  if (req.method === 'POST') {}\n  switch (req.method) { case 'GET': }\n}\n",
+			want:    []string{"GET /api/orders", "POST /api/orders"},
+		},
+		{
+			name:    "next pages router without method checks",
+			path:    "pages/api/health.js",
+			content: "export default (req, res) => res.end('ok')\n",
+			want:    []string{"ANY /api/health"},
+		},
+		{
+			name:    "sveltekit",
+			path:    "src/routes/api/posts/[slug=word]/+server.ts",
+			content: "export const GET: RequestHandler = async () => {}\nexport async function PUT() {}\n",
+			want:    []string{"GET /api/posts/{slug}", "PUT /api/posts/{slug}"},
+		},
+		{
+			name:    "nuxt method suffix",
+			path:    "server/api/users/[id].get.ts",
+			content: "export default defineEventHandler((event) => {})\n",
+			want:    []string{"GET /api/users/{id}"},
+		},
+		{
+			name:    "nuxt server routes",
+			path:    "server/routes/sitemap.xml.ts",
+			content: "export default eventHandler(() => '')\n",
+			want:    []string{"ANY /sitemap.xml"},
+		},
+		{
+			name:    "express app in server/routes",
+			path:    "server/routes/users.ts",
+			content: "router.get('/users', listUsers)\n",
+		},
+		{
+			name:    "not a route file",
+			path:    "app/components/route.tsx.bak",
+			content: "export function GET() {}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		var got []string
+		for _, ep := range extractFileRoutes(tt.path, []byte(tt.content), LangTypeScript) {
+			got = append(got, ep.Method+" "+ep.Path)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestFileRoutes_MatchOpenAPI(t *testing.T) {
+	detector := &TypeScriptDetector{}
+	endpoints, err := detector.ExtractEndpoints("app/api/users/[id]/route.ts", []byte("export async function GET() {}\n"))
+	if err != nil {
+		t.Fatalf("ExtractEndpoints failed: %v", err)
+	}
+
+	spec := []openapi.Endpoint{{Method: "GET", Path: "/api/users/{id}"}}
+	if warnings := compareEndpoints(spec, endpoints); len(warnings) != 0 {
+		t.Errorf("Expected file route to satisfy the spec, got %+v", warnings)
+	}
+}
@@ -1,7 +1,11 @@
 package inspect
 
 import (
-	"regexp"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strconv"
 	"strings"
 )
 
@@ -23,206 +27,572 @@ func (d *GoDetector) Language() Language {
 	return LangGo
 }
 
+// goHTTPMethods maps router method names to HTTP methods.
+// Gin and Echo use upper-case names, Chi and Fiber use title case.
+var goHTTPMethods = map[string]string{
+	"GET": "GET", "POST": "POST", "PUT": "PUT", "DELETE": "DELETE",
+	"PATCH": "PATCH", "OPTIONS": "OPTIONS", "HEAD": "HEAD", "Any": "ANY",
+	"Get": "GET", "Post": "POST", "Put": "PUT", "Delete": "DELETE",
+	"Patch": "PATCH", "Options": "OPTIONS", "Head": "HEAD", "All": "ANY",
+}
+
+// goRouterTypes are the router types of the supported frameworks, by package
+// and type name without pointer
+var goRouterTypes = map[string]bool{
+	"gin.Engine": true, "gin.RouterGroup": true, "gin.IRouter": true, "gin.IRoutes": true,
+	"echo.Echo": true, "echo.Group": true,
+	"fiber.App": true, "fiber.Router": true, "fiber.Group": true,
+	"chi.Router": true, "chi.Mux": true,
+	"mux.Router": true, "http.ServeMux": true,
+}
+
+// goRouterConstructors create routers of the supported frameworks
+var goRouterConstructors = map[string]bool{
+	"gin.Default": true, "gin.New": true, "echo.New": true, "fiber.New": true,
+	"chi.NewRouter": true, "chi.NewMux": true, "mux.NewRouter": true, "http.NewServeMux": true,
+}
+
+// goRouterNames are conventional router variable names, for routers whose
+// type is declared in another file
+var goRouterNames = map[string]bool{
+	"router": true, "r": true, "app": true, "engine": true, "e": true, "g": true,
+	"echo": true, "mux": true, "servemux": true, "api": true, "group": true,
+}
+
 // ExtractEndpoints finds HTTP endpoints in Go code
-// Supports: Gin, Echo, Fiber, Chi, net/http
+// Supports: Gin, Echo, Fiber, Chi, gorilla/mux, net/http (including Go 1.22 method patterns)
+//
+// The file is parsed with go/parser so registrations spanning several lines,
+// route groups (api := r.Group("/api")) and Chi/Fiber Route closures are
+// resolved to their full paths.
 func (d *GoDetector) ExtractEndpoints(filePath string, content []byte) ([]Endpoint, error) {
-	var endpoints []Endpoint
-	contentStr := string(content)
-	lines := strings.Split(contentStr, "\n")
-	
-	// Patterns for different Go web frameworks
-	patterns := []*regexp.Regexp{
-		// Gin: router.GET("/path", handler)
-		regexp.MustCompile(`(?i)(router|r|app|engine|e|g)\.(GET|POST|PUT|DELETE|PATCH|OPTIONS|HEAD)\s*\(\s*"([^"]+)"\s*,\s*([^)]+)\)`),
-		
-		// Echo: app.GET("/path", handler)
-		regexp.MustCompile(`(?i)(e|echo|app)\.(GET|POST|PUT|DELETE|PATCH|OPTIONS|HEAD)\s*\(\s*"([^"]+)"\s*,\s*([^)]+)\)`),
-		
-		// Chi: r.Get("/path", handler)
-		regexp.MustCompile(`(?i)(r|router)\.(Get|Post|Put|Delete|Patch|Options|Head)\s*\(\s*"([^"]+)"\s*,\s*([^)]+)\)`),
-		
-		// net/http: http.HandleFunc("/path", handler)
-		regexp.MustCompile(`http\.HandleFunc\s*\(\s*"([^"]+)"\s*,\s*([^)]+)\)`),
-		
-		// ServeMux: mux.HandleFunc("/path", handler)
-		regexp.MustCompile(`(?i)(mux|serveMux|router)\.(HandleFunc|Handle)\s*\(\s*"([^"]+)"\s*,\s*([^)]+)\)`),
-	}
-	
-	for lineNum, line := range lines {
-		for _, pattern := range patterns {
-			matches := pattern.FindStringSubmatch(line)
-			if matches != nil {
-				var method, path, handler string
-				
-				// Handle different match group structures
-				if len(matches) >= 4 {
-					if strings.Contains(matches[0], "HandleFunc") || strings.Contains(matches[0], "http.") {
-						// http.HandleFunc pattern
-						if len(matches) == 3 {
-							method = "GET" // Default for HandleFunc
-							path = matches[1]
-							handler = matches[2]
-						} else {
-							method = strings.ToUpper(matches[2])
-							path = matches[3]
-							handler = matches[4]
-						}
-					} else {
-						// Framework pattern (Gin, Echo, Chi)
-						method = strings.ToUpper(matches[2])
-						path = matches[3]
-						if len(matches) > 4 {
-							handler = matches[4]
-						} else {
-							handler = ""
-						}
-					}
-					
-					// Clean up handler name
-					handler = strings.TrimSpace(handler)
-					handler = strings.Split(handler, ",")[0] // Remove additional args
-					
-					endpoint := Endpoint{
-						Method:  method,
-						Path:    path,
-						Handler: handler,
-						File:    filePath,
-						Line:    lineNum + 1,
-						Language: string(LangGo),
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filePath, content, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &goRouteWalker{
+		fset:     fset,
+		filePath: filePath,
+		consts:   collectGoStringConsts(file),
+		routers:  collectGoRouters(file),
+		prefixes: make(map[string]string),
+		consumed: make(map[*ast.CallExpr]bool),
+	}
+	ast.Inspect(file, w.visit)
+
+	return w.endpoints, nil
+}
+
+// goRouteWalker tracks router group prefixes while walking a Go AST
+type goRouteWalker struct {
+	fset      *token.FileSet
+	filePath  string
+	consts    map[string]string
+	routers   map[string]bool   // Variables, parameters and fields declared as routers
+	prefixes  map[string]string // router variable name -> path prefix
+	consumed  map[*ast.CallExpr]bool
+	endpoints []Endpoint
+}
+
+func (w *goRouteWalker) visit(n ast.Node) bool {
+	switch node := n.(type) {
+	case *ast.AssignStmt:
+		if len(node.Lhs) == len(node.Rhs) {
+			for i, lhs := range node.Lhs {
+				w.bindPrefix(lhs, node.Rhs[i])
+			}
+		}
+	case *ast.ValueSpec:
+		if len(node.Names) == len(node.Values) {
+			for i, name := range node.Names {
+				w.bindPrefix(name, node.Values[i])
+			}
+		}
+	case *ast.CallExpr:
+		return w.visitCall(node)
+	}
+	return true
+}
+
+// bindPrefix records the path prefix of a router variable assignment
+func (w *goRouteWalker) bindPrefix(lhs ast.Expr, rhs ast.Expr) {
+	ident, ok := lhs.(*ast.Ident)
+	if !ok || ident.Name == "_" {
+		return
+	}
+	if prefix, ok := w.prefixOf(rhs); ok {
+		w.prefixes[ident.Name] = prefix
+	}
+}
+
+// prefixOf resolves the path prefix of a router expression.
+// The boolean result reports whether the expression is a known router group.
+func (w *goRouteWalker) prefixOf(expr ast.Expr) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		prefix, ok := w.prefixes[e.Name]
+		return prefix, ok
+	case *ast.ParenExpr:
+		return w.prefixOf(e.X)
+	case *ast.CallExpr:
+		sel, ok := e.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return "", false
+		}
+		parent, _ := w.prefixOf(sel.X)
+		switch sel.Sel.Name {
+		case "Group", "Route", "PathPrefix":
+			// Gin/Echo/Fiber Group, Chi Route without closure, gorilla PathPrefix
+			if len(e.Args) == 0 {
+				return "", false
+			}
+			if path, ok := w.stringValue(e.Args[0]); ok {
+				return joinRoutePath(parent, path), true
+			}
+		case "Subrouter", "With":
+			// gorilla Subrouter and Chi With keep the parent prefix
+			return parent, true
+		}
+	}
+	return "", false
+}
+
+// visitCall handles route registrations and prefix-scoped closures
+func (w *goRouteWalker) visitCall(call *ast.CallExpr) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || w.consumed[call] {
+		return true
+	}
+	name := sel.Sel.Name
+
+	// Chi/Fiber Route("/prefix", func(r chi.Router) {...}) and Chi Group(func(r chi.Router) {...})
+	if (name == "Route" || name == "Group") && len(call.Args) > 0 {
+		if fn, ok := call.Args[len(call.Args)-1].(*ast.FuncLit); ok {
+			if name == "Group" && len(call.Args) != 1 {
+				return true
+			}
+			prefix, _ := w.prefixOf(sel.X)
+			if name == "Route" && len(call.Args) == 2 {
+				path, ok := w.stringValue(call.Args[0])
+				if !ok {
+					return true
+				}
+				prefix = joinRoutePath(prefix, path)
+			}
+			w.walkScoped(fn, prefix)
+			return false
+		}
+	}
+
+	// gorilla/mux: r.HandleFunc("/path", h).Methods("GET", "POST")
+	if name == "Methods" {
+		inner, ok := sel.X.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		if innerSel, ok := inner.Fun.(*ast.SelectorExpr); ok && (innerSel.Sel.Name == "HandleFunc" || innerSel.Sel.Name == "Handle") {
+			for _, arg := range call.Args {
+				if method, ok := w.stringValue(arg); ok {
+					w.addHandle(inner, innerSel, strings.ToUpper(method))
+				}
+			}
+			w.consumed[inner] = true
+		}
+		return true
+	}
+
+	switch {
+	case name == "HandleFunc" || name == "Handle":
+		// Gin r.Handle("GET", "/path", h) takes the method first
+		if len(call.Args) == 3 {
+			if method, ok := w.stringValue(call.Args[0]); ok && isHTTPMethod(method) {
+				w.addEndpoint(call, sel.X, strings.ToUpper(method), call.Args[1], call.Args[2])
+				return true
+			}
+		}
+		w.addHandle(call, sel, "")
+	case name == "Add" || name == "Method" || name == "MethodFunc":
+		// Echo e.Add("GET", "/path", h) and Chi r.Method("GET", "/path", h)
+		if len(call.Args) >= 3 {
+			if method, ok := w.stringValue(call.Args[0]); ok && isHTTPMethod(method) {
+				w.addEndpoint(call, sel.X, strings.ToUpper(method), call.Args[1], call.Args[len(call.Args)-1])
+			}
+		}
+	default:
+		// Only routers, so HTTP clients such as client.Post("/x", ct, body) are ignored
+		if method, ok := goHTTPMethods[name]; ok && len(call.Args) >= 2 && w.isRouter(sel.X) {
+			w.addEndpoint(call, sel.X, method, call.Args[0], call.Args[len(call.Args)-1])
+		}
+	}
+
+	return true
+}
+
+// isRouter reports whether an expression is a router: a router group, a
+// variable, parameter or field of a router type, or a conventionally named one
+func (w *goRouteWalker) isRouter(expr ast.Expr) bool {
+	if _, known := w.prefixOf(expr); known {
+		return true
+	}
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return w.routers[e.Name] || goRouterNames[strings.ToLower(e.Name)]
+	case *ast.SelectorExpr:
+		return w.routers[e.Sel.Name] || goRouterNames[strings.ToLower(e.Sel.Name)]
+	case *ast.ParenExpr:
+		return w.isRouter(e.X)
+	case *ast.CallExpr:
+		return goRouterConstructors[goQualifiedName(e.Fun)]
+	}
+	return false
+}
+
+// walkScoped walks a router closure with its parameter bound to prefix
+func (w *goRouteWalker) walkScoped(fn *ast.FuncLit, prefix string) {
+	var param string
+	if params := fn.Type.Params; params != nil && len(params.List) > 0 && len(params.List[0].Names) > 0 {
+		param = params.List[0].Names[0].Name
+	}
+
+	previous, hadPrevious := w.prefixes[param]
+	if param != "" {
+		w.prefixes[param] = prefix
+	}
+
+	ast.Inspect(fn.Body, w.visit)
+
+	if param != "" {
+		if hadPrevious {
+			w.prefixes[param] = previous
+		} else {
+			delete(w.prefixes, param)
+		}
+	}
+}
+
+// addHandle records a net/http style Handle/HandleFunc registration.
+// Go 1.22 patterns such as "GET /users/{id}" carry the method in the pattern;
+// otherwise the handler answers any method.
+func (w *goRouteWalker) addHandle(call *ast.CallExpr, sel *ast.SelectorExpr, method string) {
+	if len(call.Args) < 2 {
+		return
+	}
+	pattern, ok := w.stringValue(call.Args[0])
+	if !ok {
+		return
+	}
+
+	patternMethod, path := splitServeMuxPattern(pattern)
+	if method == "" {
+		method = patternMethod
+	}
+	if method == "" {
+		method = "ANY"
+	}
+
+	prefix, known := w.prefixOf(sel.X)
+	if !strings.HasPrefix(path, "/") && !(path == "" && known) {
+		return
+	}
+	w.endpoints = append(w.endpoints, Endpoint{
+		Method:   method,
+		Path:     joinRoutePath(prefix, path),
+		Handler:  goHandlerName(call.Args[len(call.Args)-1]),
+		File:     w.filePath,
+		Line:     w.fset.Position(call.Pos()).Line,
+		Language: string(LangGo),
+	})
+}
+
+// addEndpoint records a framework route registration
+func (w *goRouteWalker) addEndpoint(call *ast.CallExpr, router ast.Expr, method string, pathArg, handlerArg ast.Expr) {
+	path, ok := w.stringValue(pathArg)
+	if !ok {
+		return
+	}
+
+	// Only accept route-like paths so calls such as cache.Get("key", &v) are ignored;
+	// an empty path is valid on a known group (api.GET("", h)).
+	prefix, known := w.prefixOf(router)
+	if !strings.HasPrefix(path, "/") && !(path == "" && known) {
+		return
+	}
+
+	w.endpoints = append(w.endpoints, Endpoint{
+		Method:   method,
+		Path:     joinRoutePath(prefix, path),
+		Handler:  goHandlerName(handlerArg),
+		File:     w.filePath,
+		Line:     w.fset.Position(call.Pos()).Line,
+		Language: string(LangGo),
+	})
+}
+
+// stringValue evaluates string literals, file-level string constants,
+// http.MethodX selectors and concatenations of those
+func (w *goRouteWalker) stringValue(expr ast.Expr) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind != token.STRING {
+			return "", false
+		}
+		value, err := strconv.Unquote(e.Value)
+		return value, err == nil
+	case *ast.Ident:
+		value, ok := w.consts[e.Name]
+		return value, ok
+	case *ast.ParenExpr:
+		return w.stringValue(e.X)
+	case *ast.SelectorExpr:
+		if pkg, ok := e.X.(*ast.Ident); ok && pkg.Name == "http" && strings.HasPrefix(e.Sel.Name, "Method") {
+			return strings.ToUpper(strings.TrimPrefix(e.Sel.Name, "Method")), true
+		}
+	case *ast.BinaryExpr:
+		if e.Op != token.ADD {
+			return "", false
+		}
+		left, ok := w.stringValue(e.X)
+		if !ok {
+			return "", false
+		}
+		right, ok := w.stringValue(e.Y)
+		if !ok {
+			return "", false
+		}
+		return left + right, true
+	}
+	return "", false
+}
+
+// collectGoRouters returns the names of variables, parameters and struct
+// fields that are declared with a router type or assigned a new router
+func collectGoRouters(file *ast.File) map[string]bool {
+	routers := make(map[string]bool)
+	addFields := func(fields *ast.FieldList) {
+		if fields == nil {
+			return
+		}
+		for _, field := range fields.List {
+			if !goRouterTypes[goQualifiedName(field.Type)] {
+				continue
+			}
+			for _, name := range field.Names {
+				routers[name.Name] = true
+			}
+		}
+	}
+	isConstructor := func(expr ast.Expr) bool {
+		call, ok := expr.(*ast.CallExpr)
+		return ok && goRouterConstructors[goQualifiedName(call.Fun)]
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncType:
+			addFields(node.Params)
+		case *ast.StructType:
+			addFields(node.Fields)
+		case *ast.ValueSpec:
+			for i, name := range node.Names {
+				if goRouterTypes[goQualifiedName(node.Type)] || (i < len(node.Values) && isConstructor(node.Values[i])) {
+					routers[name.Name] = true
+				}
+			}
+		case *ast.AssignStmt:
+			if len(node.Lhs) != len(node.Rhs) {
+				break
+			}
+			for i, lhs := range node.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok && isConstructor(node.Rhs[i]) {
+					routers[ident.Name] = true
+				}
+			}
+		}
+		return true
+	})
+	return routers
+}
+
+// goQualifiedName returns "pkg.Name" for a package-qualified type or function,
+// ignoring pointers
+func goQualifiedName(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return ""
+	}
+	return pkg.Name + "." + sel.Sel.Name
+}
+
+// collectGoStringConsts returns top-level string constants declared in the file
+func collectGoStringConsts(file *ast.File) map[string]string {
+	consts := make(map[string]string)
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs, ok := spec.(*ast.ValueSpec)
+			if !ok || len(vs.Names) != len(vs.Values) {
+				continue
+			}
+			for i, name := range vs.Names {
+				if lit, ok := vs.Values[i].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+					if value, err := strconv.Unquote(lit.Value); err == nil {
+						consts[name.Name] = value
 					}
-					endpoints = append(endpoints, endpoint)
 				}
 			}
 		}
 	}
-	
-	return endpoints, nil
+	return consts
+}
+
+// splitServeMuxPattern splits a net/http ServeMux pattern into method and path.
+// "GET example.com/users/{id...}" -> ("GET", "/users/{id}")
+func splitServeMuxPattern(pattern string) (string, string) {
+	pattern = strings.TrimSpace(pattern)
+	method := ""
+	if idx := strings.IndexAny(pattern, " \t"); idx > 0 && isHTTPMethod(pattern[:idx]) {
+		method = strings.ToUpper(pattern[:idx])
+		pattern = strings.TrimSpace(pattern[idx:])
+	}
+
+	// Drop an optional host
+	if !strings.HasPrefix(pattern, "/") {
+		if idx := strings.Index(pattern, "/"); idx > 0 {
+			pattern = pattern[idx:]
+		}
+	}
+
+	pattern = strings.TrimSuffix(pattern, "{$}")
+	pattern = strings.ReplaceAll(pattern, "...}", "}")
+	return method, pattern
+}
+
+// isHTTPMethod reports whether s is an HTTP method name
+func isHTTPMethod(s string) bool {
+	switch strings.ToUpper(s) {
+	case "GET", "POST", "PUT", "DELETE", "PATCH", "OPTIONS", "HEAD", "CONNECT", "TRACE":
+		return true
+	}
+	return false
+}
+
+// joinRoutePath joins a group prefix and a route path
+func joinRoutePath(prefix, path string) string {
+	if prefix == "" {
+		return path
+	}
+	if path == "" || path == "/" {
+		return prefix
+	}
+	return strings.TrimSuffix(prefix, "/") + "/" + strings.TrimPrefix(path, "/")
+}
+
+// goHandlerName renders the handler expression of a route registration
+func goHandlerName(expr ast.Expr) string {
+	if _, ok := expr.(*ast.FuncLit); ok {
+		return ""
+	}
+	return types.ExprString(expr)
 }
 
-// ExtractFunctions finds function signatures in Go code
+// ExtractFunctions finds function and method signatures in Go code.
+// Signatures spanning several lines are handled by go/parser; methods
+// carry their receiver type in FunctionSignature.Receiver.
 func (d *GoDetector) ExtractFunctions(filePath string, content []byte) ([]FunctionSignature, error) {
 	var functions []FunctionSignature
-	contentStr := string(content)
-	lines := strings.Split(contentStr, "\n")
-	
-	// Pattern: func FunctionName(params) returns
-	funcPattern := regexp.MustCompile(`^func\s+(\*?\w+\.)?\s*([A-Z]\w+)\s*\((.*?)\)\s*(.*)$`)
-	
-	for lineNum, line := range lines {
-		line = strings.TrimSpace(line)
-		
-		// Skip test functions for now
-		if strings.Contains(line, "Test") && strings.Contains(line, "*testing.T") {
+
+	// Skip test files
+	if strings.HasSuffix(strings.ToLower(filePath), "_test.go") {
+		return functions, nil
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filePath, content, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok {
 			continue
 		}
-		
-		matches := funcPattern.FindStringSubmatch(line)
-		if matches != nil {
-			receiver := matches[1]
-			funcName := matches[2]
-			paramsStr := matches[3]
-			returnsStr := strings.TrimSpace(matches[4])
-			
-			// Skip if method receiver is present (we want exported functions primarily)
-			if receiver != "" {
-				continue
-			}
-			
-			// Parse parameters
-			params := d.parseGoParameters(paramsStr)
-			
-			// Parse return types
-			returns := d.parseGoReturns(returnsStr)
-			
-			// Determine visibility (exported = public in Go)
-			visibility := "private"
-			if len(funcName) > 0 && funcName[0] >= 'A' && funcName[0] <= 'Z' {
-				visibility = "public"
-			}
-			
-			function := FunctionSignature{
-				Name:       funcName,
-				Parameters: params,
-				Returns:    returns,
-				File:       filePath,
-				Line:       lineNum + 1,
-				Visibility: visibility,
-			}
-			functions = append(functions, function)
-		}
-	}
-	
+
+		receiver := ""
+		if fn.Recv != nil && len(fn.Recv.List) > 0 {
+			receiver = types.ExprString(fn.Recv.List[0].Type)
+		}
+
+		// Determine visibility (exported = public in Go)
+		visibility := "private"
+		if fn.Name.IsExported() {
+			visibility = "public"
+		}
+
+		functions = append(functions, FunctionSignature{
+			Name:       fn.Name.Name,
+			Receiver:   receiver,
+			Parameters: goParameters(fn.Type.Params),
+			Returns:    goReturns(fn.Type.Results),
+			File:       filePath,
+			Line:       fset.Position(fn.Pos()).Line,
+			Visibility: visibility,
+		})
+	}
+
 	return functions, nil
 }
 
-// parseGoParameters parses Go function parameters
-func (d *GoDetector) parseGoParameters(paramsStr string) []ParameterSpec {
+// goParameters converts a Go parameter list, expanding grouped names (a, b int)
+func goParameters(fields *ast.FieldList) []ParameterSpec {
 	var params []ParameterSpec
-	
-	if strings.TrimSpace(paramsStr) == "" {
+	if fields == nil {
 		return params
 	}
-	
-	// Split by comma, handling potential function types
-	parts := strings.Split(paramsStr, ",")
-	
-	for _, part := range parts {
-		part = strings.TrimSpace(part)
-		if part == "" {
+
+	for _, field := range fields.List {
+		typ := types.ExprString(field.Type)
+		if len(field.Names) == 0 {
+			params = append(params, ParameterSpec{Type: typ})
 			continue
 		}
-		
-		// Simple pattern: name type or just type
-		tokens := strings.Fields(part)
-		if len(tokens) >= 2 {
-			params = append(params, ParameterSpec{
-				Name: tokens[0],
-				Type: strings.Join(tokens[1:], " "),
-			})
-		} else if len(tokens) == 1 {
-			// Just type, no name
-			params = append(params, ParameterSpec{
-				Name: "",
-				Type: tokens[0],
-			})
-		}
-	}
-	
+		for _, name := range field.Names {
+			params = append(params, ParameterSpec{Name: name.Name, Type: typ})
+		}
+	}
+
 	return params
 }
 
-// parseGoReturns parses Go function return types
-func (d *GoDetector) parseGoReturns(returnsStr string) []ReturnSpec {
+// goReturns converts a Go result list, keeping names of named returns
+func goReturns(fields *ast.FieldList) []ReturnSpec {
 	var returns []ReturnSpec
-	
-	returnsStr = strings.TrimSpace(returnsStr)
-	if returnsStr == "" {
-		return returns
-	}
-	
-	// Remove parentheses for multiple returns
-	returnsStr = strings.TrimPrefix(returnsStr, "(")
-	returnsStr = strings.TrimSuffix(returnsStr, ")")
-	returnsStr = strings.TrimSuffix(returnsStr, "{") // Remove function body start
-	returnsStr = strings.TrimSpace(returnsStr)
-	
-	if returnsStr == "" {
+	if fields == nil {
 		return returns
 	}
-	
-	// Split by comma
-	parts := strings.Split(returnsStr, ",")
-	
-	for _, part := range parts {
-		part = strings.TrimSpace(part)
-		if part == "" {
+
+	for _, field := range fields.List {
+		typ := types.ExprString(field.Type)
+		if len(field.Names) == 0 {
+			returns = append(returns, ReturnSpec{Type: typ})
 			continue
 		}
-		
-		returns = append(returns, ReturnSpec{
-			Type: part,
-		})
+		for _, name := range field.Names {
+			returns = append(returns, ReturnSpec{Type: typ, Name: name.Name})
+		}
 	}
-	
+
 	return returns
 }
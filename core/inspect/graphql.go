@@ -0,0 +1,367 @@
+package inspect
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// GraphQLField is a field of a root operation type (Query, Mutation or
+// Subscription), either declared in a schema or resolved in code
+type GraphQLField struct {
+	Type string // Query, Mutation or Subscription
+	Name string
+	File string
+	Line int
+}
+
+// Subject returns the schema coordinate of the field, e.g. Query.users
+func (f GraphQLField) Subject() string {
+	return f.Type + "." + f.Name
+}
+
+// graphQLRootTypes are the operation types whose fields are checked
+var graphQLRootTypes = []string{"Query", "Mutation", "Subscription"}
+
+var (
+	graphQLStringPattern   = regexp.MustCompile(`(?s)""".*?"""|"(?:[^"\\\n]|\\.)*"|#[^\n]*`)
+	graphQLRootTypePattern = regexp.MustCompile(`\b(?:extend\s+)?type\s+(Query|Mutation|Subscription)\b[^{]*\{`)
+	graphQLFieldPattern    = regexp.MustCompile(`(?m)^\s*(\w+)\s*(?:\([^)]*\))?\s*:`)
+
+	// func (r *queryResolver) Users(ctx context.Context) ([]*model.User, error)
+	gqlgenResolverPattern = regexp.MustCompile(`\bfunc\s*\(\s*\w*\s*\*?\s*(\w+)Resolver\s*\)\s*([A-Z]\w*)\s*\(`)
+
+	// const resolvers = { Query: { users: () => ... } }
+	apolloRootPattern = regexp.MustCompile(`\b(Query|Mutation|Subscription)\s*:\s*\{`)
+	apolloKeyPattern  = regexp.MustCompile(`^(?:async\s+)?\*?\s*["']?([A-Za-z_$][\w$]*)["']?\s*(?:[:(,]|$)`)
+
+	// class Query(graphene.ObjectType):
+	grapheneClassPattern    = regexp.MustCompile(`^class\s+(\w*?)(Query|Mutation|Subscription)\s*\(([^)]*)\)\s*:`)
+	grapheneFieldPattern    = regexp.MustCompile(`^(\w+)\s*=\s*[\w.]+\(`)
+	grapheneResolverPattern = regexp.MustCompile(`^(?:async\s+)?def\s+resolve_(\w+)\s*\(`)
+)
+
+// ValidateGraphQLContracts compares the Query, Mutation and Subscription
+// fields of schema.graphql files in blueprints against resolvers in gqlgen
+// (Go), Apollo (JavaScript/TypeScript) and Graphene (Python) code found by a
+// repository scan
+func ValidateGraphQLContracts(opts InspectOptions, scan *ScanResult) ([]Warning, error) {
+	var resolvers []GraphQLField
+	for _, file := range scan.Facts[graphQLExtractor.Name] {
+		for _, resolver := range file.Facts.([]GraphQLField) {
+			resolver.File = file.Path
+			resolvers = append(resolvers, resolver)
+		}
+	}
+
+	return compareGraphQLFields(collectSchemaFields(opts), resolvers), nil
+}
+
+// collectSchemaFields gathers root operation fields from .graphql and
+// .graphqls files in blueprints
+func collectSchemaFields(opts InspectOptions) []GraphQLField {
+	var fields []GraphQLField
+
+	files := specFiles(opts, []string{blueprintsDir(opts)}, func(path string) bool {
+		ext := strings.ToLower(filepath.Ext(path))
+		return ext == ".graphql" || ext == ".graphqls"
+	})
+	for _, path := range files {
+		if content, err := os.ReadFile(path); err == nil {
+			fields = append(fields, ParseGraphQLSchema(path, content)...)
+		}
+	}
+
+	return fields
+}
+
+// ParseGraphQLSchema extracts the fields of the root operation types, including
+// those added by "extend type", from GraphQL SDL
+func ParseGraphQLSchema(filePath string, content []byte) []GraphQLField {
+	var fields []GraphQLField
+
+	// Blank out descriptions, strings and comments, keeping newlines so that line numbers stay correct
+	text := graphQLStringPattern.ReplaceAllStringFunc(string(content), blankKeepingNewlines)
+
+	for _, match := range graphQLRootTypePattern.FindAllStringSubmatchIndex(text, -1) {
+		open := match[1] - 1
+		end := matchingBrace(text, open)
+		if end < 0 {
+			end = len(text)
+		}
+
+		// Blank out arguments so that their colons are not taken for fields
+		body := []byte(text[open+1 : end])
+		depth := 0
+		for i, c := range body {
+			switch {
+			case c == '(':
+				depth++
+			case c == ')':
+				depth--
+			case depth > 0 && c != '\n':
+				body[i] = ' '
+			}
+		}
+
+		for _, field := range graphQLFieldPattern.FindAllSubmatchIndex(body, -1) {
+			fields = append(fields, GraphQLField{
+				Type: text[match[2]:match[3]],
+				Name: string(body[field[2]:field[3]]),
+				File: filePath,
+				Line: strings.Count(text[:open+1+field[2]], "\n") + 1,
+			})
+		}
+	}
+
+	return fields
+}
+
+// blankKeepingNewlines replaces every character but newlines with a space
+func blankKeepingNewlines(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\n' {
+			return r
+		}
+		return ' '
+	}, s)
+}
+
+// graphQLExtractor collects root operation resolvers during the repository scan
+var graphQLExtractor = FileExtractor{
+	Name:    "graphql",
+	Version: "1",
+	Match: func(path string) bool {
+		return graphQLResolverParser(path) != nil
+	},
+	Extract: func(path string, content []byte) interface{} {
+		return graphQLResolverParser(path)(path, string(content))
+	},
+	Decode: decodeFacts[[]GraphQLField],
+}
+
+// graphQLResolverParser returns the resolver parser for a source file, or nil
+func graphQLResolverParser(path string) func(string, string) []GraphQLField {
+	if strings.HasSuffix(path, ".d.ts") {
+		return nil
+	}
+	switch DetectLanguageByExtension(path) {
+	case LangGo:
+		return parseGqlgenResolvers
+	case LangJavaScript, LangTypeScript:
+		return parseApolloResolvers
+	case LangPython:
+		return parseGrapheneResolvers
+	}
+	return nil
+}
+
+// parseGqlgenResolvers finds methods of gqlgen's queryResolver, mutationResolver
+// and subscriptionResolver. gqlgen exports field names, so createUser is CreateUser.
+func parseGqlgenResolvers(filePath, text string) []GraphQLField {
+	var resolvers []GraphQLField
+	for _, match := range gqlgenResolverPattern.FindAllStringSubmatchIndex(text, -1) {
+		rootType := graphQLRootType(text[match[2]:match[3]])
+		if rootType == "" {
+			continue
+		}
+		method := text[match[4]:match[5]]
+		resolvers = append(resolvers, GraphQLField{
+			Type: rootType,
+			Name: strings.ToLower(method[:1]) + method[1:],
+			File: filePath,
+			Line: strings.Count(text[:match[0]], "\n") + 1,
+		})
+	}
+	return resolvers
+}
+
+// parseApolloResolvers finds the keys of Query, Mutation and Subscription
+// objects in an Apollo resolver map
+func parseApolloResolvers(filePath, text string) []GraphQLField {
+	var resolvers []GraphQLField
+	for _, match := range apolloRootPattern.FindAllStringSubmatchIndex(text, -1) {
+		rootType := text[match[2]:match[3]]
+		open := match[1] - 1
+		end := matchingBrace(text, open)
+		if end < 0 {
+			continue
+		}
+		for _, pos := range objectKeyOffsets(text, open, end) {
+			key := apolloKeyPattern.FindStringSubmatch(text[pos:end])
+			if key == nil {
+				continue
+			}
+			resolvers = append(resolvers, GraphQLField{
+				Type: rootType,
+				Name: key[1],
+				File: filePath,
+				Line: strings.Count(text[:pos], "\n") + 1,
+			})
+		}
+	}
+	return resolvers
+}
+
+// objectKeyOffsets returns where each top-level entry of the object literal
+// between the braces at open and end starts. Spread entries are skipped.
+func objectKeyOffsets(text string, open, end int) []int {
+	var offsets []int
+	depth := 0
+	expectKey := true
+	for i := open + 1; i < end; i++ {
+		c := text[i]
+		switch {
+		case c == '"' || c == '\'' || c == '`':
+			if depth == 0 && expectKey {
+				offsets = append(offsets, i)
+				expectKey = false
+			}
+			for i++; i < end && text[i] != c; i++ {
+				if text[i] == '\\' {
+					i++
+				}
+			}
+		case c == '/' && i+1 < end && text[i+1] == '/':
+			for i < end && text[i] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < end && text[i+1] == '*':
+			if close := strings.Index(text[i+2:end], "*/"); close >= 0 {
+				i += close + 3
+			} else {
+				i = end
+			}
+		case c == '(' || c == '[' || c == '{':
+			depth++
+		case c == ')' || c == ']' || c == '}':
+			depth--
+		case c == ',' && depth == 0:
+			expectKey = true
+		case depth == 0 && expectKey && c != ' ' && c != '\t' && c != '\n' && c != '\r':
+			if c != '.' {
+				offsets = append(offsets, i)
+			}
+			expectKey = false
+		}
+	}
+	return offsets
+}
+
+// parseGrapheneResolvers finds the fields and resolve_ methods of Graphene
+// ObjectType classes named Query, Mutation or Subscription, or mixins such as
+// UserQuery. Graphene camel-cases snake_case field names.
+func parseGrapheneResolvers(filePath, text string) []GraphQLField {
+	var resolvers []GraphQLField
+	rootType := ""
+	classIndent, bodyIndent := 0, -1
+	seen := make(map[string]bool)
+
+	for lineNum, line := range strings.Split(text, "\n") {
+		trimmedLine := strings.TrimSpace(line)
+		if trimmedLine == "" || strings.HasPrefix(trimmedLine, "#") {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+
+		if rootType != "" && indent <= classIndent {
+			rootType = ""
+		}
+		if match := grapheneClassPattern.FindStringSubmatch(trimmedLine); match != nil && strings.Contains(match[3], "ObjectType") {
+			rootType, classIndent, bodyIndent = match[2], indent, -1
+			continue
+		}
+		if rootType == "" {
+			continue
+		}
+		if bodyIndent < 0 {
+			bodyIndent = indent
+		}
+		if indent != bodyIndent {
+			continue
+		}
+
+		name := ""
+		if match := grapheneFieldPattern.FindStringSubmatch(trimmedLine); match != nil {
+			name = match[1]
+		} else if match := grapheneResolverPattern.FindStringSubmatch(trimmedLine); match != nil {
+			name = match[1]
+		}
+		name = camelCase(name)
+		if name == "" || seen[rootType+"."+name] {
+			continue
+		}
+		seen[rootType+"."+name] = true
+		resolvers = append(resolvers, GraphQLField{
+			Type: rootType,
+			Name: name,
+			File: filePath,
+			Line: lineNum + 1,
+		})
+	}
+	return resolvers
+}
+
+// graphQLRootType returns the root operation type a gqlgen resolver type
+// serves, e.g. queryResolver -> Query, or "" for object resolvers
+func graphQLRootType(resolver string) string {
+	for _, rootType := range graphQLRootTypes {
+		if strings.EqualFold(resolver, rootType) {
+			return rootType
+		}
+	}
+	return ""
+}
+
+// camelCase converts a snake_case name to camelCase, e.g. user_by_id -> userById
+func camelCase(name string) string {
+	parts := strings.Split(strings.Trim(name, "_"), "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// compareGraphQLFields reports schema fields without a resolver and resolvers
+// for fields the schema does not declare
+func compareGraphQLFields(schemaFields, resolvers []GraphQLField) []Warning {
+	var warnings []Warning
+	fieldKey := func(f GraphQLField) string {
+		return f.Type + "." + strings.ToLower(f.Name)
+	}
+	unresolved, undeclared := contractDiff(schemaFields, resolvers, fieldKey, nil)
+
+	// Declared in the schema but not resolved
+	for _, f := range unresolved {
+		warnings = append(warnings, Warning{
+			Type:        WarningUnresolvedField,
+			Module:      "api",
+			Subject:     f.Subject(),
+			Message:     fmt.Sprintf("GraphQL field %s is declared in %s but has no resolver", f.Subject(), filepath.Base(f.File)),
+			Severity:    "error",
+			Remediation: fmt.Sprintf("Implement a resolver for %s or remove it from the schema", f.Subject()),
+			File:        f.File,
+			Line:        f.Line,
+		})
+	}
+
+	// Resolved but not declared in the schema
+	for _, f := range undeclared {
+		warnings = append(warnings, Warning{
+			Type:        WarningUndocumentedResolver,
+			Module:      "api",
+			Subject:     f.Subject(),
+			Message:     fmt.Sprintf("GraphQL resolver %s is not declared in the schema (found in %s:%d)", f.Subject(), filepath.Base(f.File), f.Line),
+			Severity:    "warning",
+			Remediation: fmt.Sprintf("Add %s to the blueprint's schema.graphql or remove the resolver", f.Subject()),
+			File:        f.File,
+			Line:        f.Line,
+		})
+	}
+
+	return warnings
+}
@@ -0,0 +1,193 @@
+package inspect
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const usersSchema = `"""
+The root query
+"""
+type Query {
+  users(filter: UserFilter = {role: "admin"}): [User!]!
+  # legacyUsers: [User!]!
+  user(
+    id: ID!
+  ): User
+}
+
+type User {
+  id: ID!
+  name: String
+}
+
+type Mutation {
+  createUser(input: CreateUserInput!): User! @deprecated(reason: "Use register: true")
+}
+
+extend type Query {
+  me: User
+}
+
+type Subscription {
+  userCreated: User!
+}
+`
+
+func TestParseGraphQLSchema(t *testing.T) {
+	fields := ParseGraphQLSchema("schema.graphql", []byte(usersSchema))
+
+	var got []string
+	for _, f := range fields {
+		got = append(got, f.Subject())
+	}
+	want := []string{"Query.users", "Query.user", "Mutation.createUser", "Query.me", "Subscription.userCreated"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Expected %v, got %v", want, got)
+	}
+	if fields[1].Line != 7 || fields[2].Line != 18 {
+		t.Errorf("Expected lines 7 and 18, got %d and %d", fields[1].Line, fields[2].Line)
+	}
+}
+
+func TestGraphQLResolvers(t *testing.T) {
+	tests := []struct {
+		name  string
+		parse func(string, string) []GraphQLField
+		code  string
+		want  []string
+	}{
+		{
+			name:  "gqlgen",
+			parse: parseGqlgenResolvers,
+			code: `package graph
+
+func (r *mutationResolver) CreateUser(ctx context.Context, input model.CreateUserInput) (*model.User, error) {
+	return nil, nil
+}
+
+func (r *queryResolver) Users(ctx context.Context, filter *model.UserFilter) ([]*model.User, error) {
+	return nil, nil
+}
+
+func (r *userResolver) Name(ctx context.Context, obj *model.User) (*string, error) {
+	return nil, nil
+}
+
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+`,
+			want: []string{"Mutation.createUser", "Query.users"},
+		},
+		{
+			name:  "apollo",
+			parse: parseApolloResolvers,
+			code: `const resolvers = {
+  Query: {
+    // me: () => null,
+    users: async (_, { filter }) => db.users.find(filter),
+    user(parent, { id }) {
+      return db.users.get(id, { deleted: false });
+    },
+    ...adminQueries,
+    'viewer': () => null,
+  },
+  Mutation: {
+    createUser,
+  },
+  Subscription: {
+    userCreated: { subscribe: () => pubsub.asyncIterator(["USER_CREATED"]) },
+  },
+};
+`,
+			want: []string{"Query.users", "Query.user", "Query.viewer", "Mutation.createUser", "Subscription.userCreated"},
+		},
+		{
+			name:  "graphene",
+			parse: parseGrapheneResolvers,
+			code: `class Query(graphene.ObjectType):
+    users = graphene.List(User)
+    user_by_id = graphene.Field(User, id=graphene.ID(required=True))
+
+    class Meta:
+        description = graphene.String()
+
+    def resolve_users(root, info):
+        return []
+
+    def resolve_user_by_id(root, info, id):
+        return None
+
+
+class UserMutation(graphene.ObjectType):
+    create_user = CreateUser.Field()
+
+
+class UserType(graphene.ObjectType):
+    name = graphene.String()
+`,
+			want: []string{"Query.users", "Query.userById", "Mutation.createUser"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, f := range tt.parse("resolvers", tt.code) {
+				got = append(got, f.Subject())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestInspect_GraphQLContracts(t *testing.T) {
+	rootDir := t.TempDir()
+	writeFiles(t, rootDir, map[string]string{
+		".neev/foundation/api.md":               "# API\n",
+		".neev/blueprints/users/schema.graphql": usersSchema,
+		"web/resolvers.ts": `export const resolvers = {
+  Query: {
+    users: () => [],
+    user: (_: unknown, args: { id: string }) => null,
+    me: () => null,
+  },
+  Mutation: {
+    createUser: () => null,
+    deleteUser: () => true,
+  },
+};
+`,
+	})
+
+	result, err := Inspect(InspectOptions{
+		RootDir:        rootDir,
+		FoundationPath: filepath.Join(rootDir, ".neev", "foundation"),
+		IgnoreDirs:     map[string]bool{},
+		Depth:          2,
+	})
+	if err != nil {
+		t.Fatalf("Inspect failed: %v", err)
+	}
+
+	bySubject := make(map[string]Warning)
+	for _, w := range result.Warnings {
+		if w.Type == WarningUnresolvedField || w.Type == WarningUndocumentedResolver {
+			bySubject[w.Subject] = w
+		}
+	}
+	if w, ok := bySubject["Subscription.userCreated"]; !ok || w.Type != WarningUnresolvedField || w.Severity != "error" {
+		t.Errorf("Expected Subscription.userCreated to be unresolved, got %+v", w)
+	}
+	if w, ok := bySubject["Mutation.deleteUser"]; !ok || w.Type != WarningUndocumentedResolver || w.Line != 9 {
+		t.Errorf("Expected Mutation.deleteUser to be undocumented, got %+v", w)
+	}
+	if len(bySubject) != 2 {
+		t.Errorf("Expected 2 GraphQL warnings, got %v", bySubject)
+	}
+	if result.Summary.UnresolvedFields != 1 || result.Summary.UndocumentedResolvers != 1 {
+		t.Errorf("Expected 1 unresolved field and 1 undocumented resolver, got %+v", result.Summary)
+	}
+}
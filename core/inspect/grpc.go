@@ -0,0 +1,396 @@
+package inspect
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// RPC is a gRPC method, either defined by a .proto contract or implemented by a server
+type RPC struct {
+	Package string // Proto package, e.g. "users.v1"; empty for implementations
+	Service string
+	Method  string
+	Server  string // Implementing type, for implementations
+	File    string
+	Line    int
+}
+
+// FullName returns the gRPC method name, e.g. /users.v1.UserService/GetUser
+func (r RPC) FullName() string {
+	service := r.Service
+	if r.Package != "" {
+		service = r.Package + "." + service
+	}
+	return "/" + service + "/" + r.Method
+}
+
+var (
+	protoCommentPattern = regexp.MustCompile(`(?s)//[^\n]*|/\*.*?\*/`)
+	protoPackagePattern = regexp.MustCompile(`\bpackage\s+([\w.]+)\s*;`)
+	protoServicePattern = regexp.MustCompile(`\bservice\s+(\w+)\s*\{`)
+	protoRPCPattern     = regexp.MustCompile(`\brpc\s+(\w+)\s*\(`)
+
+	// Blueprint and foundation docs reference contracts by path, e.g. proto/users/v1/users.proto
+	protoReferencePattern = regexp.MustCompile(`[\w./-]+\.proto\b`)
+
+	// type userServer struct { pb.UnimplementedUserServiceServer }
+	goStructPattern        = regexp.MustCompile(`\btype\s+(\w+)\s+struct\s*\{([^}]*)\}`)
+	goUnimplementedPattern = regexp.MustCompile(`(?m)^\s*\*?(?:\w+\.)?Unimplemented(\w+)Server\s*(?://.*)?$`)
+	goMethodPattern        = regexp.MustCompile(`\bfunc\s*\(\s*(?:\w+\s+)?\*?\s*(\w+)\s*\)\s*([A-Z]\w*)\s*\(([^)]*)\)`)
+	// Handlers take a context (unary, client streaming on old plugins) or a stream
+	goRPCParamsPattern = regexp.MustCompile(`context\.Context|\w+_\w+Server\b|StreamingServer\[`)
+
+	// class UserServiceImpl extends UserServiceGrpc.UserServiceImplBase {
+	javaImplBasePattern = regexp.MustCompile(`\bclass\s+(\w+)\s+extends\s+([\w.]+)ImplBase\b[^{]*\{`)
+	javaRPCPattern      = regexp.MustCompile(`\bpublic\s+(?:void|StreamObserver<[^>]*>)\s+(\w+)\s*\(([^)]*)\)`)
+
+	// class UserServicer(users_pb2_grpc.UserServiceServicer):
+	pythonServicerPattern = regexp.MustCompile(`^class\s+(\w+)\s*\(\s*(?:[\w.]+\.)?(\w+)Servicer\s*\)\s*:`)
+	pythonRPCPattern      = regexp.MustCompile(`^(?:async\s+)?def\s+([A-Za-z]\w*)\s*\((.*)\)`)
+)
+
+// ValidateGRPCContracts compares the services and methods of the .proto files
+// referenced from blueprints or the foundation against the gRPC servers in Go,
+// Java and Python code found by a repository scan
+func ValidateGRPCContracts(opts InspectOptions, scan *ScanResult) ([]Warning, error) {
+	var specRPCs []RPC
+	for _, file := range collectProtoFiles(opts) {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			continue // Skip files we can't read
+		}
+		specRPCs = append(specRPCs, ParseProtoServices(file, content)...)
+	}
+
+	return compareRPCs(specRPCs, findRPCImplementations(scan)), nil
+}
+
+// collectProtoFiles returns the .proto files inside .neev/blueprints and the
+// foundation, plus those their documents reference by path
+func collectProtoFiles(opts InspectOptions) []string {
+	found := make(map[string]bool)
+
+	addReference := func(doc, ref string) {
+		// References are relative to the document or to the project root
+		for _, candidate := range []string{filepath.Join(filepath.Dir(doc), ref), filepath.Join(opts.RootDir, ref)} {
+			if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+				found[filepath.Clean(candidate)] = true
+				return
+			}
+		}
+	}
+
+	docs := specFiles(opts, []string{blueprintsDir(opts), opts.FoundationPath}, func(path string) bool {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".proto", ".md", ".yaml", ".yml":
+			return true
+		}
+		return false
+	})
+	for _, path := range docs {
+		if strings.EqualFold(filepath.Ext(path), ".proto") {
+			found[filepath.Clean(path)] = true
+			continue
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		for _, ref := range protoReferencePattern.FindAllString(string(content), -1) {
+			addReference(path, filepath.FromSlash(ref))
+		}
+	}
+
+	var files []string
+	for file := range found {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	return files
+}
+
+// ParseProtoServices extracts the RPC methods of each service in a .proto file
+func ParseProtoServices(filePath string, content []byte) []RPC {
+	var rpcs []RPC
+
+	// Blank out comments, keeping newlines so that line numbers stay correct
+	text := protoCommentPattern.ReplaceAllStringFunc(string(content), blankKeepingNewlines)
+
+	pkg := ""
+	if match := protoPackagePattern.FindStringSubmatch(text); match != nil {
+		pkg = match[1]
+	}
+
+	for _, service := range protoServicePattern.FindAllStringSubmatchIndex(text, -1) {
+		open := service[1] - 1
+		end := matchingBrace(text, open)
+		if end < 0 {
+			end = len(text)
+		}
+		for _, rpc := range protoRPCPattern.FindAllStringSubmatchIndex(text[open:end], -1) {
+			rpcs = append(rpcs, RPC{
+				Package: pkg,
+				Service: text[service[2]:service[3]],
+				Method:  text[open+rpc[2] : open+rpc[3]],
+				File:    filePath,
+				Line:    strings.Count(text[:open+rpc[0]], "\n") + 1,
+			})
+		}
+	}
+
+	return rpcs
+}
+
+// rpcFacts are the gRPC servers of one source file. Go methods may live in any
+// file of the server's package, so they are matched to servers after the scan.
+type rpcFacts struct {
+	RPCs      []RPC             `json:"rpcs,omitempty"`       // Java and Python servers
+	GoServers map[string]string `json:"go_servers,omitempty"` // Go type -> service
+	GoMethods []RPC             `json:"go_methods,omitempty"` // Exported Go methods taking a context or stream
+}
+
+// rpcExtractor collects gRPC servers during the repository scan: Go structs
+// embedding Unimplemented<Service>Server, Java classes extending
+// <Service>Grpc.<Service>ImplBase and Python <Service>Servicer subclasses
+var rpcExtractor = FileExtractor{
+	Name:    "grpc",
+	Version: "1",
+	Match: func(path string) bool {
+		name := filepath.Base(path)
+		if strings.HasSuffix(name, ".pb.go") || strings.HasSuffix(name, "_pb2_grpc.py") || strings.HasSuffix(name, "_pb2.py") {
+			return false // Generated code
+		}
+		language := DetectLanguageByExtension(path)
+		return language == LangGo || language == LangJava || language == LangPython
+	},
+	Extract: func(path string, content []byte) interface{} {
+		return parseRPCServers(path, string(content))
+	},
+	Decode: decodeFacts[rpcFacts],
+}
+
+// parseRPCServers finds the gRPC servers of a Go, Java or Python file
+func parseRPCServers(filePath, text string) rpcFacts {
+	var facts rpcFacts
+	switch DetectLanguageByExtension(filePath) {
+	case LangJava:
+		facts.RPCs = parseJavaServers(filePath, text)
+		return facts
+	case LangPython:
+		facts.RPCs = parsePythonServicers(filePath, text)
+		return facts
+	}
+
+	for _, match := range goStructPattern.FindAllStringSubmatch(text, -1) {
+		if embedded := goUnimplementedPattern.FindStringSubmatch(match[2]); embedded != nil {
+			if facts.GoServers == nil {
+				facts.GoServers = make(map[string]string)
+			}
+			facts.GoServers[match[1]] = embedded[1]
+		}
+	}
+	for _, match := range goMethodPattern.FindAllStringSubmatchIndex(text, -1) {
+		if !goRPCParamsPattern.MatchString(text[match[6]:match[7]]) {
+			continue
+		}
+		facts.GoMethods = append(facts.GoMethods, RPC{
+			Server: text[match[2]:match[3]],
+			Method: text[match[4]:match[5]],
+			File:   filePath,
+			Line:   strings.Count(text[:match[0]], "\n") + 1,
+		})
+	}
+	return facts
+}
+
+// findRPCImplementations returns the gRPC servers found by a repository scan,
+// matching Go methods to the servers of their package
+func findRPCImplementations(scan *ScanResult) []RPC {
+	var rpcs []RPC
+
+	goServers := make(map[string]string) // dir + type -> service
+	var goMethods []RPC
+	for _, file := range scan.Facts[rpcExtractor.Name] {
+		facts := file.Facts.(rpcFacts)
+		for _, rpc := range facts.RPCs {
+			rpc.File = file.Path
+			rpcs = append(rpcs, rpc)
+		}
+		dir := filepath.Dir(file.Path)
+		for server, service := range facts.GoServers {
+			goServers[dir+"\x00"+server] = service
+		}
+		for _, method := range facts.GoMethods {
+			method.File = file.Path
+			goMethods = append(goMethods, method)
+		}
+	}
+
+	for _, method := range goMethods {
+		if service, ok := goServers[filepath.Dir(method.File)+"\x00"+method.Server]; ok {
+			method.Service = service
+			rpcs = append(rpcs, method)
+		}
+	}
+
+	return rpcs
+}
+
+// parseJavaServers finds the RPC methods of classes extending a generated ImplBase
+func parseJavaServers(filePath, text string) []RPC {
+	var rpcs []RPC
+	for _, class := range javaImplBasePattern.FindAllStringSubmatchIndex(text, -1) {
+		// UserServiceGrpc.UserService or, with a static import, UserService
+		base := text[class[4]:class[5]]
+		service := base[strings.LastIndex(base, ".")+1:]
+
+		open := class[1] - 1
+		end := matchingBrace(text, open)
+		if end < 0 {
+			end = len(text)
+		}
+		for _, method := range javaRPCPattern.FindAllStringSubmatchIndex(text[open:end], -1) {
+			if !strings.Contains(text[open+method[4]:open+method[5]], "StreamObserver") {
+				continue
+			}
+			rpcs = append(rpcs, RPC{
+				Service: service,
+				Method:  text[open+method[2] : open+method[3]],
+				Server:  text[class[2]:class[3]],
+				File:    filePath,
+				Line:    strings.Count(text[:open+method[0]], "\n") + 1,
+			})
+		}
+	}
+	return rpcs
+}
+
+// parsePythonServicers finds the methods of classes subclassing a generated Servicer.
+// RPC methods take (self, request, context); private helpers are skipped.
+func parsePythonServicers(filePath, text string) []RPC {
+	var rpcs []RPC
+	var server, service string
+	classIndent := 0
+
+	for lineNum, line := range strings.Split(text, "\n") {
+		trimmedLine := strings.TrimSpace(line)
+		if trimmedLine == "" || strings.HasPrefix(trimmedLine, "#") {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+
+		if server != "" && indent <= classIndent {
+			server = ""
+		}
+		if match := pythonServicerPattern.FindStringSubmatch(trimmedLine); match != nil {
+			server, service, classIndent = match[1], match[2], indent
+			continue
+		}
+		if server == "" {
+			continue
+		}
+
+		match := pythonRPCPattern.FindStringSubmatch(trimmedLine)
+		if match == nil {
+			continue
+		}
+		params := splitTopLevel(match[2], ',')
+		if len(params) != 3 || strings.TrimSpace(params[0]) != "self" {
+			continue
+		}
+		rpcs = append(rpcs, RPC{
+			Service: service,
+			Method:  match[1],
+			Server:  server,
+			File:    filePath,
+			Line:    lineNum + 1,
+		})
+	}
+	return rpcs
+}
+
+// compareRPCs reports contract RPCs without an implementation and implemented
+// RPCs the contract does not define. Method names are compared case-insensitively
+// because Java servers use lowerCamelCase.
+func compareRPCs(specRPCs, implRPCs []RPC) []Warning {
+	var warnings []Warning
+	rpcKey := func(rpc RPC) string {
+		return rpc.Service + "/" + strings.ToLower(rpc.Method)
+	}
+	unimplemented, undefined := contractDiff(specRPCs, implRPCs, rpcKey, nil)
+
+	specServices := make(map[string]RPC)
+	for _, rpc := range specRPCs {
+		specServices[rpc.Service] = rpc
+	}
+	implServices := make(map[string]bool)
+	for _, rpc := range implRPCs {
+		implServices[rpc.Service] = true
+	}
+
+	// Defined in the contract but not implemented
+	for _, rpc := range unimplemented {
+		message := fmt.Sprintf("gRPC method %s is defined in %s but not implemented", rpc.FullName(), filepath.Base(rpc.File))
+		if !implServices[rpc.Service] {
+			message += fmt.Sprintf(" (no server for %s was found)", rpc.Service)
+		}
+		warnings = append(warnings, Warning{
+			Type:        WarningMissingRPC,
+			Module:      "api",
+			Subject:     rpc.FullName(),
+			Message:     message,
+			Severity:    "error",
+			Remediation: fmt.Sprintf("Implement %s on the %s server or remove it from the .proto contract", rpc.Method, rpc.Service),
+			File:        rpc.File,
+			Line:        rpc.Line,
+		})
+	}
+
+	// Implemented but not defined in the contract
+	for _, rpc := range undefined {
+		if spec, ok := specServices[rpc.Service]; ok {
+			rpc.Package = spec.Package
+		}
+		warnings = append(warnings, Warning{
+			Type:    WarningUndocumentedRPC,
+			Module:  "api",
+			Subject: rpc.FullName(),
+			Message: fmt.Sprintf("gRPC method %s is implemented by %s but not defined in any .proto contract (found in %s:%d)",
+				rpc.FullName(), rpc.Server, filepath.Base(rpc.File), rpc.Line),
+			Severity:    "warning",
+			Remediation: fmt.Sprintf("Add rpc %s to service %s in the .proto contract", rpc.Method, rpc.Service),
+			File:        rpc.File,
+			Line:        rpc.Line,
+		})
+	}
+
+	return warnings
+}
+
+// matchingBrace returns the index of the brace closing the one at open,
+// skipping quoted strings, or -1 if it is unbalanced
+func matchingBrace(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"', '\'':
+			for i++; i < len(s) && s[i] != c && s[i] != '\n'; i++ {
+				if s[i] == '\\' {
+					i++
+				}
+			}
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
@@ -0,0 +1,187 @@
+package inspect
+
+import (
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+const usersProto = `syntax = "proto3";
+
+package users.v1;
+
+// UserService manages accounts
+service UserService {
+  rpc GetUser(GetUserRequest) returns (User);
+  // rpc LegacyLookup(GetUserRequest) returns (User);
+  rpc ListUsers(ListUsersRequest) returns (stream User);
+  rpc DeleteUser(DeleteUserRequest) returns (google.protobuf.Empty) {
+    option (google.api.http) = { delete: "/v1/users/{id}" };
+  }
+}
+
+service AuditService {
+  rpc Record(stream AuditEvent) returns (RecordSummary);
+}
+`
+
+func TestParseProtoServices(t *testing.T) {
+	rpcs := ParseProtoServices("users.proto", []byte(usersProto))
+
+	var got []string
+	for _, rpc := range rpcs {
+		got = append(got, rpc.FullName())
+	}
+	want := []string{
+		"/users.v1.UserService/GetUser",
+		"/users.v1.UserService/ListUsers",
+		"/users.v1.UserService/DeleteUser",
+		"/users.v1.AuditService/Record",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Expected %v, got %v", want, got)
+	}
+	if rpcs[0].Line != 7 || rpcs[3].Line != 16 {
+		t.Errorf("Expected lines 7 and 16, got %d and %d", rpcs[0].Line, rpcs[3].Line)
+	}
+}
+
+func TestFindRPCImplementations(t *testing.T) {
+	rootDir := t.TempDir()
+	writeFiles(t, rootDir, map[string]string{
+		"server/server.go": `package server
+
+type userServer struct {
+	pb.UnimplementedUserServiceServer
+	store *Store
+}
+
+func (s *userServer) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.User, error) {
+	return nil, nil
+}
+
+func (s *userServer) Close() error { return nil }
+`,
+		"server/stream.go": `package server
+
+func (s *userServer) ListUsers(req *pb.ListUsersRequest, stream pb.UserService_ListUsersServer) error {
+	return nil
+}
+
+func (h *handler) GetUser(ctx context.Context, req *Request) error { return nil }
+`,
+		"server/users_grpc.pb.go": `package server
+
+func (UnimplementedUserServiceServer) GetUser(context.Context, *GetUserRequest) (*User, error) {
+	return nil, nil
+}
+`,
+		"java/AuditServiceImpl.java": `public class AuditServiceImpl extends AuditServiceGrpc.AuditServiceImplBase {
+    @Override
+    public StreamObserver<AuditEvent> record(StreamObserver<RecordSummary> responseObserver) {
+        return null;
+    }
+
+    public void reset() {}
+}
+`,
+		"py/servicer.py": `class UserServicer(users_pb2_grpc.UserServiceServicer):
+    def __init__(self, store):
+        self.store = store
+
+    def DeleteUser(self, request, context):
+        return empty_pb2.Empty()
+
+    async def RenameUser(self, request, context):
+        pass
+
+    def _lookup(self, user_id):
+        pass
+
+
+def serve(server, port):
+    pass
+`,
+	})
+
+	rpcs := findRPCImplementations(scanFacts(t, rootDir, map[string]bool{}, rpcExtractor))
+
+	var got []string
+	for _, rpc := range rpcs {
+		got = append(got, rpc.Server+" "+rpc.FullName())
+	}
+	sort.Strings(got)
+	want := []string{
+		"AuditServiceImpl /AuditService/record",
+		"UserServicer /UserService/DeleteUser",
+		"UserServicer /UserService/RenameUser",
+		"userServer /UserService/GetUser",
+		"userServer /UserService/ListUsers",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestInspect_GRPCContracts(t *testing.T) {
+	rootDir := t.TempDir()
+	writeFiles(t, rootDir, map[string]string{
+		".neev/foundation/api.md":    "# API\n\nThe gRPC contract is proto/users/v1/users.proto.\n",
+		"proto/users/v1/users.proto": usersProto,
+		"server/server.go": `package server
+
+type userServer struct {
+	pb.UnimplementedUserServiceServer
+}
+
+func (s *userServer) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.User, error) {
+	return nil, nil
+}
+
+func (s *userServer) ListUsers(req *pb.ListUsersRequest, stream pb.UserService_ListUsersServer) error {
+	return nil
+}
+
+func (s *userServer) BanUser(ctx context.Context, req *pb.BanUserRequest) (*pb.User, error) {
+	return nil, nil
+}
+`,
+	})
+
+	result, err := Inspect(InspectOptions{
+		RootDir:        rootDir,
+		FoundationPath: filepath.Join(rootDir, ".neev", "foundation"),
+		IgnoreDirs:     map[string]bool{},
+		Depth:          2,
+	})
+	if err != nil {
+		t.Fatalf("Inspect failed: %v", err)
+	}
+
+	bySubject := make(map[string]Warning)
+	for _, w := range result.Warnings {
+		if w.Type == WarningMissingRPC || w.Type == WarningUndocumentedRPC {
+			bySubject[w.Subject] = w
+		}
+	}
+
+	if w, ok := bySubject["/users.v1.UserService/DeleteUser"]; !ok || w.Type != WarningMissingRPC || w.Severity != "error" || w.Line != 10 {
+		t.Errorf("Expected DeleteUser to be a missing RPC, got %+v", w)
+	}
+	if w, ok := bySubject["/users.v1.AuditService/Record"]; !ok || w.Type != WarningMissingRPC {
+		t.Errorf("Expected Record to be a missing RPC, got %+v", w)
+	}
+	if w, ok := bySubject["/users.v1.UserService/BanUser"]; !ok || w.Type != WarningUndocumentedRPC || w.Severity != "warning" || w.Module != "api" {
+		t.Errorf("Expected BanUser to be an undocumented RPC, got %+v", w)
+	}
+	if len(bySubject) != 3 {
+		t.Errorf("Expected 3 gRPC warnings, got %v", bySubject)
+	}
+	if result.Summary.MissingRPCs != 2 || result.Summary.UndocumentedRPCs != 1 {
+		t.Errorf("Expected 2 missing and 1 undocumented RPCs, got %+v", result.Summary)
+	}
+	if WarningMissingRPC.Check() != CheckEndpoints {
+		t.Errorf("Expected gRPC warnings to belong to the endpoints check")
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
+	"github.com/neev-kit/neev/core/frontmatter"
 	"gopkg.in/yaml.v3"
 )
 
@@ -18,159 +20,510 @@ type InspectOptions struct {
 	Depth          int  // Analysis depth: 1=structure, 2=+API, 3=+signatures
 	CheckAPI       bool // Enable OpenAPI validation (Level 2)
 	CheckSignatures bool // Enable signature validation (Level 3)
+	CheckTests     bool // Enable BDD scenario coverage validation
+	CheckDataModel bool // Enable data model validation against SQL migrations
+	BaselinePath   string // If set and the file exists, warnings recorded in it are hidden
+	Rules          []Rule // Severity overrides and disabled warning types (inspect.rules in neev.yaml)
+	ModuleRoots    []string          // Directories whose subdirectories are modules (default: src/ or the root)
+	ModulePaths    map[string]string // Explicit module-to-path globs, e.g. billing: services/billing/**
+	Workers        int               // Files parsed concurrently; defaults to GOMAXPROCS
+	CacheDir       string            // If set, extraction results are cached here by content hash
+	ChangedFiles   []string          // If non-nil, only warnings concerning these root-relative files are kept
+	Plugins        []Plugin          // External detectors (inspect.plugins in neev.yaml)
+
+	blueprintsPath string // Overrides .neev/blueprints, e.g. for the specs of a base revision
 }
 
 // Inspect performs drift detection between foundation specs and code structure
 func Inspect(opts InspectOptions) (*InspectResult, error) {
-	result := &InspectResult{
-		Success:  true,
-		Warnings: []Warning{},
-		Summary:  Summary{
-			Languages: make(map[string]int),
-		},
+	in, err := newInspection(opts)
+	if err != nil {
+		return nil, err
 	}
+	return in.run()
+}
 
+// inspection is what an inspection reads before it checks anything: the
+// specs, the code modules and a scan of the code
+type inspection struct {
+	opts              InspectOptions
+	analyzer          *PolyglotAnalyzer
+	plugins           []*PluginDetector
+	foundationModules map[string]frontmatter.Metadata
+	descriptors       map[string]ModuleDescriptor
+	codePaths         map[string][]string
+	codeModules       map[string][]string
+	stack             StackDeclaration
+	repoWide          bool // Repository-wide checks run; with --since, only if the changes can concern them
+	scanOpts          ScanOptions
+	scan              *ScanResult
+}
+
+// newInspection reads the specs and scans the code. With ChangedFiles set, the
+// scan is limited to what the changes can affect.
+func newInspection(opts InspectOptions) (*inspection, error) {
 	// Initialize polyglot analyzer
-	analyzer := NewPolyglotAnalyzer()
+	in := &inspection{opts: opts, analyzer: NewPolyglotAnalyzer()}
+	analyzer := in.analyzer
 	
+	// Plugins come first so that they can claim files a built-in detector also handles
+	for _, plugin := range opts.Plugins {
+		detector := NewPluginDetector(plugin, opts.RootDir)
+		in.plugins = append(in.plugins, detector)
+		analyzer.RegisterDetector(detector)
+	}
+
 	// Import detector types properly
 	goDetector := &GoDetector{}
 	pyDetector := &PythonDetector{}
+	tsDetector := &TypeScriptDetector{}
 	jsDetector := &JavaScriptDetector{}
 	javaDetector := &JavaDetector{}
 	csDetector := &CSharpDetector{}
 	rbDetector := &RubyDetector{}
+	ktDetector := &KotlinDetector{}
+	rsDetector := &RustDetector{}
+	phpDetector := &PHPDetector{}
+	exDetector := &ElixirDetector{}
 	
 	analyzer.RegisterDetector(goDetector)
 	analyzer.RegisterDetector(pyDetector)
+	analyzer.RegisterDetector(tsDetector) // Before JavaScript, which also accepts .ts files
 	analyzer.RegisterDetector(jsDetector)
 	analyzer.RegisterDetector(javaDetector)
 	analyzer.RegisterDetector(csDetector)
 	analyzer.RegisterDetector(rbDetector)
+	analyzer.RegisterDetector(ktDetector)
+	analyzer.RegisterDetector(rsDetector)
+	analyzer.RegisterDetector(phpDetector)
+	analyzer.RegisterDetector(exDetector)
+
+	if err := in.loadSpecs(); err != nil {
+		return nil, err
+	}
 
-	// Detect languages in the codebase
-	languages, err := analyzer.DetectLanguages(opts.RootDir, opts.IgnoreDirs)
+	scanOpts := in.scanOptions()
+
+	// With --since, repository-wide checks only run if the changes can concern
+	// them; otherwise only the affected modules are analysed
+	in.repoWide = true
+	if opts.ChangedFiles != nil {
+		scope := newChangeScope(opts, in.codeModules)
+		in.repoWide = scope.repoWide(opts.RootDir, scanOpts.Extractors)
+		if !in.repoWide {
+			scanOpts.Endpoints = false
+			scanOpts.Extractors = nil
+			scanOpts.Only = scope.affects(opts.RootDir, in.codeModules)
+		}
+	}
+
+	if opts.CacheDir != "" && scanOpts.extracts() {
+		scanOpts.Cache = LoadAnalysisCache(opts.CacheDir)
+	}
+	scan, err := analyzer.Scan(opts.RootDir, opts.IgnoreDirs, scanOpts)
+	if closeErr := in.closePlugins(); closeErr != nil && err == nil {
+		err = closeErr
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to detect languages: %w", err)
+		return nil, fmt.Errorf("failed to scan codebase: %w", err)
+	}
+	if scanOpts.Cache != nil {
+		// The cache only saves work; failing to write it must not fail the inspection
+		_ = scanOpts.Cache.Save()
+	}
+	in.scanOpts, in.scan = scanOpts, scan
+
+	return in, nil
+}
+
+// scanOptions selects what the scan extracts from the enabled checks
+func (in *inspection) scanOptions() ScanOptions {
+	opts := in.opts
+	checkAPI := opts.CheckAPI || opts.Depth >= 2
+	checkSignatures := opts.CheckSignatures || opts.Depth >= 3
+
+	// Scan the codebase once for languages, endpoints, signatures and the facts
+	// of the enabled contract checks; modules declaring languages in their front
+	// matter are only analysed in those
+	scanOpts := ScanOptions{
+		Endpoints: checkAPI,
+		Functions: checkSignatures,
+		Workers:   opts.Workers,
+		Languages: moduleLanguages(in.codeModules, in.foundationModules),
+	}
+	if checkAPI {
+		scanOpts.Extractors = append(scanOpts.Extractors, rpcExtractor, graphQLExtractor, topicExtractor, envReadExtractor, schemaSourceExtractor)
+	}
+	if !in.stack.IsZero() {
+		scanOpts.Extractors = append(scanOpts.Extractors, manifestExtractor)
+	}
+	if opts.CheckTests {
+		scanOpts.Extractors = append(scanOpts.Extractors, featureExtractor)
 	}
-	result.Summary.Languages = languages
+	if opts.CheckDataModel {
+		scanOpts.Extractors = append(scanOpts.Extractors, migrationExtractor(opts.RootDir))
+	}
+	return scanOpts
+}
+
+// loadSpecs reads the foundation modules, their code directories and the
+// stack declaration
+func (in *inspection) loadSpecs() error {
+	opts := in.opts
 
 	// Get foundation modules
 	foundationModules, descriptors, err := getFoundationModules(opts.FoundationPath, opts.UseDescriptors)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read foundation modules: %w", err)
+		return fmt.Errorf("failed to read foundation modules: %w", err)
+	}
+
+	// Get code modules; front matter code_paths and neev.yaml mappings override discovery
+	codePaths := moduleCodePaths(opts, foundationModules)
+	codeModules, err := getCodeModules(opts, codePaths)
+	if err != nil {
+		return fmt.Errorf("failed to scan code modules: %w", err)
 	}
 
-	// Get code modules
-	codeModules, err := getCodeModules(opts.RootDir, opts.IgnoreDirs)
+	stack, err := LoadStackDeclaration(opts.FoundationPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to scan code modules: %w", err)
+		return fmt.Errorf("failed to load stack declaration: %w", err)
 	}
 
+	in.foundationModules, in.descriptors = foundationModules, descriptors
+	in.codePaths, in.codeModules, in.stack = codePaths, codeModules, stack
+	return nil
+}
+
+// closePlugins ends the plugin processes and returns the first error a plugin caused
+func (in *inspection) closePlugins() error {
+	var err error
+	for _, plugin := range in.plugins {
+		if pluginErr := plugin.Close(); pluginErr != nil && err == nil {
+			err = pluginErr
+		}
+	}
+	return err
+}
+
+// run checks the specs against the code and filters the findings
+func (in *inspection) run() (*InspectResult, error) {
+	opts, scan := in.opts, in.scan
+	foundationModules, descriptors := in.foundationModules, in.descriptors
+	codePaths, codeModules, stack := in.codePaths, in.codeModules, in.stack
+
+	result := &InspectResult{
+		Success:  true,
+		Warnings: []Warning{},
+		Summary:  Summary{
+			Languages: scan.Languages,
+		},
+	}
+
+	checkAPI := opts.CheckAPI || opts.Depth >= 2
+	checkSignatures := opts.CheckSignatures || opts.Depth >= 3
+
 	result.Summary.TotalModules = len(foundationModules)
+	for module := range foundationModules {
+		result.Modules = append(result.Modules, module)
+	}
+	sort.Strings(result.Modules)
+
+	result.Checks = append(result.Checks, CheckModule)
+	if opts.UseDescriptors {
+		result.Checks = append(result.Checks, CheckFiles)
+	}
 
 	// Check for missing code directories
-	for module := range foundationModules {
+	for _, module := range result.Modules {
+		meta := foundationModules[module]
+		patterns, mapped := codePaths[module]
 		if _, exists := codeModules[module]; !exists {
 			warning := Warning{
 				Type:        WarningMissingModule,
 				Module:      module,
+				Subject:     module,
 				Message:     fmt.Sprintf("Foundation spec '%s.md' exists but directory '%s/' not found in code", module, module),
 				Severity:    "warning",
 				Remediation: fmt.Sprintf("Create directory '%s/' or remove the foundation spec", module),
+				File:        filepath.Join(opts.FoundationPath, module+".md"),
+			}
+			if mapped {
+				warning.Message = fmt.Sprintf("Foundation spec '%s.md' maps to '%s' but no matching directory found in code", module, strings.Join(patterns, "', '"))
+				warning.Remediation = fmt.Sprintf("Create directory '%s/' or update %s", modulePathBase(patterns[0]), codePathsSource(module, meta))
+			}
+			// Planned specs describe code that does not exist yet
+			if meta.Planned() {
+				warning.Severity = "info"
 			}
 			result.Warnings = append(result.Warnings, warning)
-			result.Summary.MissingModules++
-			result.Summary.WarningCount++
 		} else {
 			result.Summary.MatchingModules++
 
+			// A spec covering several paths expects each of them to exist
+			if len(patterns) > 1 {
+				for _, pattern := range patterns {
+					if dir, err := resolveModulePath(opts.RootDir, pattern); err == nil && dir != "" {
+						continue
+					}
+					result.Warnings = append(result.Warnings, Warning{
+						Type:        WarningMissingModule,
+						Module:      module,
+						Subject:     pattern,
+						Message:     fmt.Sprintf("Code path '%s' of foundation spec '%s.md' not found in code", pattern, module),
+						Severity:    "warning",
+						Remediation: fmt.Sprintf("Create directory '%s/' or update %s", modulePathBase(pattern), codePathsSource(module, meta)),
+						File:        filepath.Join(opts.FoundationPath, module+".md"),
+					})
+				}
+			}
+
 			// If descriptors are enabled, check file-level details
 			if opts.UseDescriptors {
 				if descriptor, hasDescriptor := descriptors[module]; hasDescriptor {
 					fileWarnings := checkModuleFiles(opts.RootDir, module, descriptor, codeModules[module])
 					result.Warnings = append(result.Warnings, fileWarnings...)
-					for _, w := range fileWarnings {
-						if w.Severity == "error" {
-							result.Summary.ErrorCount++
-						} else {
-							result.Summary.WarningCount++
-						}
-					}
 				}
 			}
 		}
 	}
 
-	// Check for orphaned code directories (code without specs)
+	// Check for orphaned code directories (code without specs), in a stable order
+	codeModuleNames := make([]string, 0, len(codeModules))
 	for module := range codeModules {
+		codeModuleNames = append(codeModuleNames, module)
+	}
+	sort.Strings(codeModuleNames)
+	for _, module := range codeModuleNames {
 		if _, exists := foundationModules[module]; !exists {
+			dir := relativeModuleDir(opts.RootDir, codeModules[module][0])
 			warning := Warning{
 				Type:        WarningExtraCode,
 				Module:      module,
-				Message:     fmt.Sprintf("Code directory '%s/' exists but no foundation spec '%s.md' found", module, module),
+				Subject:     module,
+				Message:     fmt.Sprintf("Code directory '%s/' exists but no foundation spec '%s.md' found", dir, module),
 				Severity:    "info",
 				Remediation: fmt.Sprintf("Create foundation spec '%s.md' or remove the directory", module),
+				File:        codeModules[module][0],
 			}
 			result.Warnings = append(result.Warnings, warning)
-			result.Summary.ExtraCodeDirs++
-			result.Summary.WarningCount++
 		}
 	}
 
 	// Level 2: OpenAPI validation (if enabled)
-	if opts.CheckAPI || opts.Depth >= 2 {
-		apiWarnings, err := ValidateOpenAPIContracts(opts, analyzer)
+	if checkAPI && in.repoWide {
+		result.Checks = append(result.Checks, CheckEndpoints)
+		apiWarnings, err := ValidateOpenAPIContracts(opts, scan)
 		if err != nil {
 			return nil, fmt.Errorf("failed to validate API contracts: %w", err)
 		}
-		
-		for _, w := range apiWarnings {
-			result.Warnings = append(result.Warnings, w)
-			if w.Type == WarningMissingEndpoint {
-				result.Summary.MissingEndpoints++
-			} else if w.Type == WarningUndocumentedEndpoint {
-				result.Summary.UndocumentedEnds++
-			}
-			
-			if w.Severity == "error" {
-				result.Summary.ErrorCount++
-			} else {
-				result.Summary.WarningCount++
-			}
+		result.Warnings = append(result.Warnings, apiWarnings...)
+
+		rpcWarnings, err := ValidateGRPCContracts(opts, scan)
+		if err != nil {
+			return nil, fmt.Errorf("failed to validate gRPC contracts: %w", err)
 		}
+		result.Warnings = append(result.Warnings, rpcWarnings...)
+
+		graphQLWarnings, err := ValidateGraphQLContracts(opts, scan)
+		if err != nil {
+			return nil, fmt.Errorf("failed to validate GraphQL contracts: %w", err)
+		}
+		result.Warnings = append(result.Warnings, graphQLWarnings...)
+
+		result.Checks = append(result.Checks, CheckEvents)
+		eventWarnings, err := ValidateAsyncAPIContracts(opts, scan)
+		if err != nil {
+			return nil, fmt.Errorf("failed to validate AsyncAPI contracts: %w", err)
+		}
+		result.Warnings = append(result.Warnings, eventWarnings...)
+
+		result.Checks = append(result.Checks, CheckConfig)
+		configWarnings, err := ValidateConfigContract(opts, scan)
+		if err != nil {
+			return nil, fmt.Errorf("failed to validate configuration contract: %w", err)
+		}
+		result.Warnings = append(result.Warnings, configWarnings...)
+	}
+
+	// Dependencies against the declared stack (if one is declared)
+	if !stack.IsZero() && in.repoWide {
+		result.Checks = append(result.Checks, CheckStack)
+		stackWarnings, err := ValidateStack(scan, stack)
+		if err != nil {
+			return nil, fmt.Errorf("failed to validate stack: %w", err)
+		}
+		result.Warnings = append(result.Warnings, stackWarnings...)
 	}
 
 	// Level 3: Function signature validation (if enabled)
-	if opts.CheckSignatures || opts.Depth >= 3 {
-		sigWarnings, err := ValidateFunctionSignatures(opts, analyzer)
+	if checkSignatures {
+		result.Checks = append(result.Checks, CheckSignatures)
+		sigWarnings, err := ValidateFunctionSignatures(opts, scan)
 		if err != nil {
 			return nil, fmt.Errorf("failed to validate function signatures: %w", err)
 		}
-		
-		for _, w := range sigWarnings {
-			result.Warnings = append(result.Warnings, w)
-			if w.Type == WarningSignatureMismatch {
-				result.Summary.SignatureMismatches++
-			}
-			
-			if w.Severity == "error" {
-				result.Summary.ErrorCount++
-			} else {
-				result.Summary.WarningCount++
+		result.Warnings = append(result.Warnings, sigWarnings...)
+	}
+
+	// BDD test coverage validation (if enabled)
+	if opts.CheckTests && in.repoWide {
+		result.Checks = append(result.Checks, CheckTests)
+		testWarnings, coverage, err := ValidateTestCoverage(opts, scan)
+		if err != nil {
+			return nil, fmt.Errorf("failed to validate test coverage: %w", err)
+		}
+		result.Warnings = append(result.Warnings, testWarnings...)
+		result.Summary.TestScenarios = coverage.Scenarios
+		result.Summary.TestedEndpoints = coverage.TestedEndpoints
+	}
+
+	// Data model validation against migrations (if enabled)
+	if opts.CheckDataModel && in.repoWide {
+		result.Checks = append(result.Checks, CheckDataModel)
+		dataWarnings, err := ValidateDataModel(opts, scan, codeModules)
+		if err != nil {
+			return nil, fmt.Errorf("failed to validate data model: %w", err)
+		}
+		result.Warnings = append(result.Warnings, dataWarnings...)
+	}
+
+	assignOwners(opts, result.Warnings, foundationModules, codeModules)
+
+	// Keep only drift that concerns the changed files (--since)
+	if opts.ChangedFiles != nil {
+		scope := newChangeScope(opts, codeModules)
+		inScope := []Warning{}
+		for _, w := range result.Warnings {
+			if scope.includes(w, opts.RootDir) {
+				inScope = append(inScope, w)
 			}
 		}
+		result.Warnings = inScope
+	}
+
+	// Hide accepted drift: descriptor suppressions first, then rule policy, then the baseline
+	suppressions, err := loadSuppressions(opts.FoundationPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load suppressions: %w", err)
+	}
+	result.Warnings = applySuppressions(result, suppressions, timeNow())
+	result.Warnings = applyRules(result.Warnings, opts.Rules)
+
+	if opts.BaselinePath != "" {
+		baseline, err := LoadBaseline(opts.BaselinePath)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to load baseline: %w", err)
+		}
+		if baseline != nil {
+			result.Warnings = baseline.Filter(result)
+		}
 	}
 
-	result.Summary.TotalWarnings = len(result.Warnings)
-	result.Success = result.Summary.ErrorCount == 0
+	summarizeWarnings(result)
 
 	return result, nil
 }
 
-// getFoundationModules returns module names from foundation specs and their descriptors if available
-func getFoundationModules(foundationPath string, useDescriptors bool) (map[string]bool, map[string]ModuleDescriptor, error) {
-	modules := make(map[string]bool)
+// summarizeWarnings derives the warning counters of the summary from result.Warnings
+func summarizeWarnings(result *InspectResult) {
+	summary := &result.Summary
+	summary.MissingModules = 0
+	summary.ExtraCodeDirs = 0
+	summary.MissingEndpoints = 0
+	summary.UndocumentedEnds = 0
+	summary.SchemaMismatches = 0
+	summary.MissingRPCs = 0
+	summary.UndocumentedRPCs = 0
+	summary.UnresolvedFields = 0
+	summary.UndocumentedResolvers = 0
+	summary.MissingTopics = 0
+	summary.UndocumentedTopics = 0
+	summary.UndocumentedEnvVars = 0
+	summary.UnusedEnvVars = 0
+	summary.ForbiddenDependencies = 0
+	summary.UnapprovedDependencies = 0
+	summary.DependencyVersionMismatches = 0
+	summary.MissingTables = 0
+	summary.MissingColumns = 0
+	summary.ColumnTypeMismatches = 0
+	summary.SignatureMismatches = 0
+	summary.UntestedEndpoints = 0
+	summary.OrphanedScenarios = 0
+	summary.ErrorCount = 0
+	summary.WarningCount = 0
+	summary.NewWarnings = 0
+	summary.PreexistingWarnings = 0
+
+	for _, w := range result.Warnings {
+		switch w.Type {
+		case WarningMissingModule:
+			summary.MissingModules++
+		case WarningExtraCode:
+			summary.ExtraCodeDirs++
+		case WarningMissingEndpoint:
+			summary.MissingEndpoints++
+		case WarningUndocumentedEndpoint:
+			summary.UndocumentedEnds++
+		case WarningSchemaMismatch:
+			summary.SchemaMismatches++
+		case WarningMissingRPC:
+			summary.MissingRPCs++
+		case WarningUndocumentedRPC:
+			summary.UndocumentedRPCs++
+		case WarningUnresolvedField:
+			summary.UnresolvedFields++
+		case WarningUndocumentedResolver:
+			summary.UndocumentedResolvers++
+		case WarningMissingTopic:
+			summary.MissingTopics++
+		case WarningUndocumentedTopic:
+			summary.UndocumentedTopics++
+		case WarningUndocumentedEnvVar:
+			summary.UndocumentedEnvVars++
+		case WarningUnusedEnvVar:
+			summary.UnusedEnvVars++
+		case WarningForbiddenDependency:
+			summary.ForbiddenDependencies++
+		case WarningUnapprovedDependency:
+			summary.UnapprovedDependencies++
+		case WarningDependencyVersion:
+			summary.DependencyVersionMismatches++
+		case WarningMissingTable:
+			summary.MissingTables++
+		case WarningMissingColumn:
+			summary.MissingColumns++
+		case WarningColumnTypeMismatch:
+			summary.ColumnTypeMismatches++
+		case WarningSignatureMismatch:
+			summary.SignatureMismatches++
+		case WarningUntestedEndpoint:
+			summary.UntestedEndpoints++
+		case WarningOrphanedScenario:
+			summary.OrphanedScenarios++
+		}
+
+		if w.Severity == "error" {
+			summary.ErrorCount++
+		} else {
+			summary.WarningCount++
+		}
+
+		switch w.Change {
+		case ChangeNew:
+			summary.NewWarnings++
+		case ChangePreexisting:
+			summary.PreexistingWarnings++
+		}
+	}
+
+	summary.TotalWarnings = len(result.Warnings)
+	result.Success = summary.ErrorCount == 0
+}
+
+// getFoundationModules returns module names from foundation specs with their front matter,
+// and their descriptors if available. Specs with status "archived" are skipped.
+func getFoundationModules(foundationPath string, useDescriptors bool) (map[string]frontmatter.Metadata, map[string]ModuleDescriptor, error) {
+	modules := make(map[string]frontmatter.Metadata)
 	descriptors := make(map[string]ModuleDescriptor)
 
 	// Check if foundation directory exists
@@ -192,7 +545,13 @@ func getFoundationModules(foundationPath string, useDescriptors bool) (map[strin
 		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".md") {
 			// Remove .md extension to get module name
 			moduleName := strings.TrimSuffix(entry.Name(), ".md")
-			modules[moduleName] = true
+
+			// Malformed front matter is treated as none
+			meta, _, _ := frontmatter.ParseFile(filepath.Join(foundationPath, entry.Name()))
+			if meta.Archived() {
+				continue
+			}
+			modules[moduleName] = meta
 
 			// Try to load descriptor if enabled
 			if useDescriptors {
@@ -207,6 +566,18 @@ func getFoundationModules(foundationPath string, useDescriptors bool) (map[strin
 	return modules, descriptors, nil
 }
 
+// HasFrontMatter reports whether any foundation spec declares front matter,
+// which only the structured inspection honours
+func HasFrontMatter(foundationPath string) bool {
+	matches, _ := filepath.Glob(filepath.Join(foundationPath, "*.md"))
+	for _, match := range matches {
+		if meta, _, err := frontmatter.ParseFile(match); err == nil && !meta.IsZero() {
+			return true
+		}
+	}
+	return false
+}
+
 // loadModuleDescriptor loads a module descriptor from a YAML file
 func loadModuleDescriptor(path string) (ModuleDescriptor, error) {
 	var descriptor ModuleDescriptor
@@ -224,56 +595,178 @@ func loadModuleDescriptor(path string) (ModuleDescriptor, error) {
 	return descriptor, nil
 }
 
-// getCodeModules returns a set of module directory names from the code structure
-func getCodeModules(rootDir string, ignoreDirs map[string]bool) (map[string]string, error) {
-	modules := make(map[string]string)
-
-	// Try src/ first, fall back to root
-	srcPath := filepath.Join(rootDir, "src")
-	scanPath := srcPath
-	if _, err := os.Stat(srcPath); os.IsNotExist(err) {
-		scanPath = rootDir
+// moduleCodePaths returns the code path globs mapped to each module. Front matter
+// code_paths take precedence over the modules mapping in neev.yaml.
+func moduleCodePaths(opts InspectOptions, foundationModules map[string]frontmatter.Metadata) map[string][]string {
+	codePaths := make(map[string][]string)
+	for name, pattern := range opts.ModulePaths {
+		codePaths[name] = []string{pattern}
 	}
+	for name, meta := range foundationModules {
+		if len(meta.CodePaths) > 0 {
+			codePaths[name] = meta.CodePaths
+		}
+	}
+	return codePaths
+}
 
-	entries, err := os.ReadDir(scanPath)
-	if err != nil {
-		return nil, err
+// codePathsSource names where a module's code paths are configured, for remediations
+func codePathsSource(module string, meta frontmatter.Metadata) string {
+	if len(meta.CodePaths) > 0 {
+		return fmt.Sprintf("code_paths in '%s.md'", module)
 	}
+	return "the modules mapping in neev.yaml"
+}
 
-	for _, entry := range entries {
-		if !entry.IsDir() {
-			continue
+// getCodeModules returns module names mapped to their code directories.
+// Subdirectories of each module root are modules (src/ or the root by default);
+// explicit code path mappings take precedence over discovered directories.
+func getCodeModules(opts InspectOptions, codePaths map[string][]string) (map[string][]string, error) {
+	modules := make(map[string][]string)
+
+	for _, root := range moduleRoots(opts) {
+		scanPaths, err := filepath.Glob(filepath.Join(opts.RootDir, filepath.FromSlash(root)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid module root '%s': %w", root, err)
 		}
 
-		name := entry.Name()
+		for _, scanPath := range scanPaths {
+			entries, err := os.ReadDir(scanPath)
+			if err != nil {
+				continue // Not a directory
+			}
 
-		// Skip ignored directories
-		if ignoreDirs[name] {
-			continue
+			for _, entry := range entries {
+				if !entry.IsDir() {
+					continue
+				}
+
+				name := entry.Name()
+
+				// Skip ignored and hidden directories
+				if opts.IgnoreDirs[name] || strings.HasPrefix(name, ".") {
+					continue
+				}
+
+				// The first root that provides a module name wins
+				if _, exists := modules[name]; !exists {
+					modules[name] = []string{filepath.Join(scanPath, name)}
+				}
+			}
 		}
+	}
 
-		// Skip hidden directories
-		if strings.HasPrefix(name, ".") {
-			continue
+	if len(codePaths) == 0 {
+		return modules, nil
+	}
+
+	mapped := make(map[string][]string)
+	var mappedDirs []string
+	for name, patterns := range codePaths {
+		delete(modules, name)
+		for _, pattern := range patterns {
+			dir, err := resolveModulePath(opts.RootDir, pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid path '%s' for module '%s': %w", pattern, name, err)
+			}
+			if dir != "" {
+				mapped[name] = append(mapped[name], dir)
+				mappedDirs = append(mappedDirs, dir)
+			}
+		}
+	}
+
+	// Discovered directories that contain or sit inside a mapped module are not modules of their own
+	for name, dirs := range modules {
+		for _, mappedDir := range mappedDirs {
+			if pathContains(dirs[0], mappedDir) || pathContains(mappedDir, dirs[0]) {
+				delete(modules, name)
+				break
+			}
 		}
+	}
 
-		modules[name] = filepath.Join(scanPath, name)
+	for name, dirs := range mapped {
+		modules[name] = dirs
 	}
 
 	return modules, nil
 }
 
-// checkModuleFiles verifies that expected files from the descriptor exist
-func checkModuleFiles(rootDir, moduleName string, descriptor ModuleDescriptor, modulePath string) []Warning {
+// moduleRoots returns the directories whose subdirectories are modules: the
+// configured roots, else src/ if it exists, else the root itself
+func moduleRoots(opts InspectOptions) []string {
+	if len(opts.ModuleRoots) > 0 {
+		return opts.ModuleRoots
+	}
+	if _, err := os.Stat(filepath.Join(opts.RootDir, "src")); err == nil {
+		return []string{"src"}
+	}
+	return []string{"."}
+}
+
+// resolveModulePath returns the directory a module path glob points to, or ""
+// if nothing matches. Trailing "/**" and "/*" select the directory itself; other
+// wildcards pick the first matching directory.
+func resolveModulePath(rootDir, pattern string) (string, error) {
+	base := modulePathBase(pattern)
+
+	matches, err := filepath.Glob(filepath.Join(rootDir, filepath.FromSlash(base)))
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(matches)
+
+	for _, match := range matches {
+		if stat, err := os.Stat(match); err == nil && stat.IsDir() {
+			return match, nil
+		}
+	}
+
+	return "", nil
+}
+
+// modulePathBase strips trailing "/**" and "/*" segments from a module path glob
+func modulePathBase(pattern string) string {
+	base := strings.TrimRight(filepath.ToSlash(pattern), "/")
+	for strings.HasSuffix(base, "/**") || strings.HasSuffix(base, "/*") {
+		base = strings.TrimRight(strings.TrimSuffix(strings.TrimSuffix(base, "*"), "*"), "/")
+	}
+	if base == "" || base == "**" || base == "*" {
+		return "."
+	}
+	return base
+}
+
+// pathContains reports whether child is dir or one of its descendants
+func pathContains(dir, child string) bool {
+	rel, err := filepath.Rel(dir, child)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// relativeModuleDir returns a module directory relative to the root, with forward slashes
+func relativeModuleDir(rootDir, dir string) string {
+	rel, err := filepath.Rel(rootDir, dir)
+	if err != nil {
+		return filepath.ToSlash(dir)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// checkModuleFiles verifies that expected files from the descriptor exist.
+// A module spanning several directories satisfies an expectation in any of them.
+func checkModuleFiles(rootDir, moduleName string, descriptor ModuleDescriptor, modulePaths []string) []Warning {
 	var warnings []Warning
+	modulePath := modulePaths[0]
 
 	// Check expected files
 	for _, expectedFile := range descriptor.ExpectedFiles {
 		filePath := filepath.Join(modulePath, expectedFile)
-		if _, err := os.Stat(filePath); os.IsNotExist(err) {
+		if !existsInAny(modulePaths, expectedFile, false) {
 			warning := Warning{
 				Type:        WarningMissingFile,
 				Module:      moduleName,
+				Subject:     expectedFile,
 				Message:     fmt.Sprintf("Expected file '%s' not found in module '%s'", expectedFile, moduleName),
 				Severity:    "warning",
 				Remediation: fmt.Sprintf("Create file '%s' or update module descriptor", filePath),
@@ -285,10 +778,11 @@ func checkModuleFiles(rootDir, moduleName string, descriptor ModuleDescriptor, m
 	// Check expected directories
 	for _, expectedDir := range descriptor.ExpectedDirs {
 		dirPath := filepath.Join(modulePath, expectedDir)
-		if stat, err := os.Stat(dirPath); os.IsNotExist(err) || !stat.IsDir() {
+		if !existsInAny(modulePaths, expectedDir, true) {
 			warning := Warning{
 				Type:        WarningMissingFile,
 				Module:      moduleName,
+				Subject:     expectedDir,
 				Message:     fmt.Sprintf("Expected directory '%s' not found in module '%s'", expectedDir, moduleName),
 				Severity:    "warning",
 				Remediation: fmt.Sprintf("Create directory '%s' or update module descriptor", dirPath),
@@ -299,12 +793,18 @@ func checkModuleFiles(rootDir, moduleName string, descriptor ModuleDescriptor, m
 
 	// Check patterns (glob matching)
 	for _, pattern := range descriptor.Patterns {
-		fullPattern := filepath.Join(modulePath, pattern)
-		matches, err := filepath.Glob(fullPattern)
-		if err != nil || len(matches) == 0 {
+		var matches []string
+		for _, dir := range modulePaths {
+			dirMatches, err := filepath.Glob(filepath.Join(dir, pattern))
+			if err == nil {
+				matches = append(matches, dirMatches...)
+			}
+		}
+		if len(matches) == 0 {
 			warning := Warning{
 				Type:        WarningMissingFile,
 				Module:      moduleName,
+				Subject:     pattern,
 				Message:     fmt.Sprintf("No files matching pattern '%s' in module '%s'", pattern, moduleName),
 				Severity:    "info",
 				Remediation: fmt.Sprintf("Add files matching pattern '%s' or update module descriptor", pattern),
@@ -315,3 +815,14 @@ func checkModuleFiles(rootDir, moduleName string, descriptor ModuleDescriptor, m
 
 	return warnings
 }
+
+// existsInAny reports whether name exists in any of the directories
+func existsInAny(dirs []string, name string, wantDir bool) bool {
+	for _, dir := range dirs {
+		stat, err := os.Stat(filepath.Join(dir, name))
+		if err == nil && (!wantDir || stat.IsDir()) {
+			return true
+		}
+	}
+	return false
+}
@@ -3,6 +3,8 @@ package inspect
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -155,6 +157,127 @@ func TestInspect_MatchingModules(t *testing.T) {
 	}
 }
 
+func TestInspect_ModuleRootsAndMapping(t *testing.T) {
+	tmpDir := t.TempDir()
+	foundationDir := filepath.Join(tmpDir, ".neev", "foundation")
+	os.MkdirAll(foundationDir, 0755)
+
+	for _, module := range []string{"auth", "billing", "ui", "search"} {
+		os.WriteFile(filepath.Join(foundationDir, module+".md"), []byte("# "+module), 0644)
+	}
+
+	os.MkdirAll(filepath.Join(tmpDir, "internal", "auth"), 0755)
+	os.MkdirAll(filepath.Join(tmpDir, "internal", "legacy"), 0755)
+	os.MkdirAll(filepath.Join(tmpDir, "packages", "ui"), 0755)
+	os.MkdirAll(filepath.Join(tmpDir, "services", "billing"), 0755)
+
+	opts := InspectOptions{
+		RootDir:        tmpDir,
+		FoundationPath: foundationDir,
+		IgnoreDirs:     map[string]bool{".git": true, ".neev": true},
+		ModuleRoots:    []string{"internal", "packages"},
+		ModulePaths: map[string]string{
+			"billing": "services/billing/**",
+			"search":  "services/search/**",
+		},
+	}
+
+	result, err := Inspect(opts)
+	if err != nil {
+		t.Fatalf("Inspect failed: %v", err)
+	}
+
+	if result.Summary.MatchingModules != 3 {
+		t.Errorf("Expected 3 matching modules, got %d", result.Summary.MatchingModules)
+	}
+
+	var missing, extra []Warning
+	for _, w := range result.Warnings {
+		switch w.Type {
+		case WarningMissingModule:
+			missing = append(missing, w)
+		case WarningExtraCode:
+			extra = append(extra, w)
+		}
+	}
+
+	if len(missing) != 1 || missing[0].Module != "search" || !strings.Contains(missing[0].Message, "services/search/**") {
+		t.Errorf("Expected search to be missing at its mapped path, got %+v", missing)
+	}
+	if len(extra) != 1 || extra[0].Module != "legacy" || !strings.Contains(extra[0].Message, "internal/legacy/") {
+		t.Errorf("Expected only internal/legacy to be extra code, got %+v", extra)
+	}
+}
+
+func TestInspect_FrontMatterCodePaths(t *testing.T) {
+	tmpDir := t.TempDir()
+	foundationDir := filepath.Join(tmpDir, ".neev", "foundation")
+	os.MkdirAll(foundationDir, 0755)
+
+	specs := map[string]string{
+		"billing": "---\ncode_paths: [services/billing, libs/invoices, libs/tax]\n---\n# Billing",
+		"search":  "---\nstatus: planned\n---\n# Search",
+		"legacy":  "---\nstatus: archived\n---\n# Legacy",
+	}
+	for module, content := range specs {
+		os.WriteFile(filepath.Join(foundationDir, module+".md"), []byte(content), 0644)
+	}
+
+	os.MkdirAll(filepath.Join(tmpDir, "services", "billing"), 0755)
+	os.MkdirAll(filepath.Join(tmpDir, "libs", "invoices"), 0755)
+	os.MkdirAll(filepath.Join(tmpDir, "libs", "shared"), 0755)
+
+	opts := InspectOptions{
+		RootDir:        tmpDir,
+		FoundationPath: foundationDir,
+		IgnoreDirs:     map[string]bool{".git": true, ".neev": true},
+		ModuleRoots:    []string{"libs"},
+	}
+
+	result, err := Inspect(opts)
+	if err != nil {
+		t.Fatalf("Inspect failed: %v", err)
+	}
+
+	if result.Summary.TotalModules != 2 {
+		t.Errorf("Expected archived spec to be skipped, got %d modules", result.Summary.TotalModules)
+	}
+
+	got := make(map[string]Warning)
+	for _, w := range result.Warnings {
+		got[string(w.Type)+" "+w.Module+" "+w.Subject] = w
+	}
+
+	if _, ok := got["MISSING_MODULE billing libs/tax"]; !ok {
+		t.Errorf("Expected missing code path libs/tax for billing, got %+v", result.Warnings)
+	}
+	if w, ok := got["MISSING_MODULE search search"]; !ok || w.Severity != "info" {
+		t.Errorf("Expected planned module search to be reported as info, got %+v", w)
+	}
+	if _, ok := got["EXTRA_CODE invoices invoices"]; ok {
+		t.Errorf("Did not expect libs/invoices to be reported as extra code")
+	}
+	if _, ok := got["EXTRA_CODE shared shared"]; !ok {
+		t.Errorf("Expected libs/shared to be reported as extra code, got %+v", result.Warnings)
+	}
+}
+
+func TestModulePathBase(t *testing.T) {
+	tests := map[string]string{
+		"services/billing/**": "services/billing",
+		"services/billing/*":  "services/billing",
+		"services/billing/":   "services/billing",
+		"packages/ui-*/**":    "packages/ui-*",
+		"**":                  ".",
+	}
+
+	for pattern, want := range tests {
+		if got := modulePathBase(pattern); got != want {
+			t.Errorf("modulePathBase(%q) = %q, want %q", pattern, got, want)
+		}
+	}
+}
+
 func TestInspect_WithDescriptors(t *testing.T) {
 	// Create temporary directory structure
 	tmpDir := t.TempDir()
@@ -274,3 +397,45 @@ func TestInspectResult_JSON(t *testing.T) {
 	// Should be marshallable to JSON
 	_ = result // Just verify it compiles with JSON tags
 }
+
+func TestInspect_FrontMatterOwnersAndLanguages(t *testing.T) {
+	rootDir := t.TempDir()
+	writeFiles(t, rootDir, map[string]string{
+		".neev/foundation/billing.md":              "---\nowners: [\"@payments\"]\nlanguages: [go]\n---\n# Billing\n",
+		".neev/blueprints/payments/intent.md":      "---\nowners: [\"@payments-api\"]\n---\n# Payments\n",
+		".neev/blueprints/payments/refunds.proto":  "syntax = \"proto3\";\npackage refunds;\n\nservice Refunds {\n  rpc Refund(RefundRequest) returns (RefundReply);\n}\n",
+		".neev/blueprints/payments/openapi.yaml":   "openapi: 3.0.0\npaths:\n  /api/refunds:\n    post:\n      summary: Refund\n",
+		".neev/blueprints/old-checkout/intent.md":  "---\nstatus: archived\n---\n# Old checkout\n",
+		".neev/blueprints/old-checkout/cart.proto": "syntax = \"proto3\";\npackage cart;\n\nservice Cart {\n  rpc GetCart(GetCartRequest) returns (CartReply);\n}\n",
+		"billing/routes.go":                        "package billing\n\nfunc Register(r *gin.Engine) {\n\tr.GET(\"/api/invoices\", ListInvoices)\n}\n",
+		"billing/scripts/export.py":                "@app.route(\"/api/export\")\ndef export():\n    pass\n",
+	})
+
+	result, err := Inspect(InspectOptions{
+		RootDir:        rootDir,
+		FoundationPath: filepath.Join(rootDir, ".neev", "foundation"),
+		IgnoreDirs:     map[string]bool{},
+		Depth:          2,
+	})
+	if err != nil {
+		t.Fatalf("Inspect failed: %v", err)
+	}
+
+	got := make(map[string]Warning)
+	for _, w := range result.Warnings {
+		got[string(w.Type)+" "+w.Subject] = w
+	}
+
+	if w, ok := got["UNDOCUMENTED_ENDPOINT GET /api/invoices"]; !ok || !reflect.DeepEqual(w.Owners, []string{"@payments"}) {
+		t.Errorf("Expected the billing owners on its undocumented endpoint, got %+v", w)
+	}
+	if w, ok := got["MISSING_RPC /refunds.Refunds/Refund"]; !ok || !reflect.DeepEqual(w.Owners, []string{"@payments-api"}) {
+		t.Errorf("Expected the blueprint owners on its missing RPC, got %+v", w)
+	}
+	if _, ok := got["MISSING_RPC /cart.Cart/GetCart"]; ok {
+		t.Errorf("Expected the archived blueprint to be skipped")
+	}
+	if _, ok := got["UNDOCUMENTED_ENDPOINT ANY /api/export"]; ok || result.Summary.Languages["python"] != 0 {
+		t.Errorf("Expected Python in the Go-only billing module to be skipped, got %+v", result.Summary.Languages)
+	}
+}
@@ -24,89 +24,97 @@ func (d *JavaDetector) Language() Language {
 }
 
 // ExtractEndpoints finds HTTP endpoints in Java code
-// Supports: Spring Boot annotations
+// Supports: Spring Boot annotations, including class-level @RequestMapping prefixes
 func (d *JavaDetector) ExtractEndpoints(filePath string, content []byte) ([]Endpoint, error) {
 	var endpoints []Endpoint
 	contentStr := string(content)
 	lines := strings.Split(contentStr, "\n")
 	
 	// Spring Boot annotation patterns
-	annotationPattern := regexp.MustCompile(`@(GetMapping|PostMapping|PutMapping|DeleteMapping|PatchMapping|RequestMapping)\s*(?:\((?:value\s*=\s*)?["']([^"']+)["'])?`)
-	methodPattern := regexp.MustCompile(`^\s*(?:public|private|protected)?\s+(?:\w+\s+)*(\w+)\s*\(`)
+	annotationPattern := regexp.MustCompile(`^@(GetMapping|PostMapping|PutMapping|DeleteMapping|PatchMapping|RequestMapping)\b(?:\s*\((.*)\))?`)
+	classPattern := regexp.MustCompile(`\b(?:class|interface)\s+\w+`)
+	methodPattern := regexp.MustCompile(`^[^=(@;]*\s(\w+)\s*\(`)
 	
-	var currentAnnotation string
-	var currentPath string
-	var currentMethod string
+	prefix := ""
+	var pending *springMapping
 	
 	for lineNum, line := range lines {
 		trimmedLine := strings.TrimSpace(line)
 		
 		// Check for Spring annotation
-		annotationMatch := annotationPattern.FindStringSubmatch(trimmedLine)
-		if annotationMatch != nil {
-			annotation := annotationMatch[1]
-			path := ""
-			if len(annotationMatch) > 2 {
-				path = annotationMatch[2]
-			}
-			
-			// Convert annotation to HTTP method
-			method := "GET"
-			switch annotation {
-			case "PostMapping":
-				method = "POST"
-			case "PutMapping":
-				method = "PUT"
-			case "DeleteMapping":
-				method = "DELETE"
-			case "PatchMapping":
-				method = "PATCH"
-			case "RequestMapping":
-				// Try to find method in annotation
-				if strings.Contains(trimmedLine, "method") {
-					if strings.Contains(trimmedLine, "POST") {
-						method = "POST"
-					} else if strings.Contains(trimmedLine, "PUT") {
-						method = "PUT"
-					} else if strings.Contains(trimmedLine, "DELETE") {
-						method = "DELETE"
-					}
-				}
+		if annotationMatch := annotationPattern.FindStringSubmatch(trimmedLine); annotationMatch != nil {
+			pending = parseSpringMapping(annotationMatch[1], annotationMatch[2])
+			continue
+		}
+		
+		if pending == nil {
+			// A class without @RequestMapping has no prefix
+			if classPattern.MatchString(trimmedLine) {
+				prefix = ""
 			}
-			
-			currentAnnotation = annotation
-			currentPath = path
-			currentMethod = method
 			continue
 		}
 		
-		// If we have a pending annotation, look for the method definition
-		if currentAnnotation != "" {
-			methodMatch := methodPattern.FindStringSubmatch(trimmedLine)
-			if methodMatch != nil {
-				handler := methodMatch[1]
-				
-				endpoint := Endpoint{
-					Method:   currentMethod,
-					Path:     currentPath,
-					Handler:  handler,
+		// @RequestMapping on the class prefixes every handler in it
+		if classPattern.MatchString(trimmedLine) {
+			prefix = pending.path
+			pending = nil
+			continue
+		}
+		
+		// Look for the method definition of the pending annotation
+		if methodMatch := methodPattern.FindStringSubmatch(trimmedLine); methodMatch != nil {
+			for _, method := range pending.methods {
+				endpoints = append(endpoints, Endpoint{
+					Method:   method,
+					Path:     controllerRoutePath(prefix, pending.path),
+					Handler:  methodMatch[1],
 					File:     filePath,
 					Line:     lineNum + 1,
 					Language: string(LangJava),
-				}
-				endpoints = append(endpoints, endpoint)
-				
-				// Reset
-				currentAnnotation = ""
-				currentPath = ""
-				currentMethod = ""
+				})
 			}
+			pending = nil
 		}
 	}
 	
 	return endpoints, nil
 }
 
+// springMapping is a parsed Spring request mapping annotation
+type springMapping struct {
+	methods []string
+	path    string
+}
+
+var (
+	springPathPattern   = regexp.MustCompile(`^\s*\{?\s*"([^"]*)"|\b(?:value|path)\s*=\s*\{?\s*"([^"]*)"`)
+	springMethodPattern = regexp.MustCompile(`RequestMethod\.(\w+)`)
+)
+
+// parseSpringMapping reads the HTTP methods and first path of an annotation
+// such as @GetMapping("/{id}") or @RequestMapping(value = "/x", method = RequestMethod.POST).
+// A @RequestMapping without methods answers any method.
+func parseSpringMapping(annotation, args string) *springMapping {
+	mapping := &springMapping{}
+	if match := springPathPattern.FindStringSubmatch(args); match != nil {
+		mapping.path = match[1] + match[2]
+	}
+	
+	if annotation != "RequestMapping" {
+		mapping.methods = []string{strings.ToUpper(strings.TrimSuffix(annotation, "Mapping"))}
+		return mapping
+	}
+	
+	for _, match := range springMethodPattern.FindAllStringSubmatch(args, -1) {
+		mapping.methods = append(mapping.methods, match[1])
+	}
+	if len(mapping.methods) == 0 {
+		mapping.methods = []string{"ANY"}
+	}
+	return mapping
+}
+
 // ExtractFunctions finds function signatures in Java code
 func (d *JavaDetector) ExtractFunctions(filePath string, content []byte) ([]FunctionSignature, error) {
 	var functions []FunctionSignature
@@ -28,7 +28,7 @@ func (d *JavaScriptDetector) Language() Language {
 }
 
 // ExtractEndpoints finds HTTP endpoints in JavaScript/TypeScript code
-// Supports: Express, Fastify, Koa
+// Supports: Express, Fastify, Koa, and file-system routing (Next.js, SvelteKit, Nuxt)
 func (d *JavaScriptDetector) ExtractEndpoints(filePath string, content []byte) ([]Endpoint, error) {
 	var endpoints []Endpoint
 	contentStr := string(content)
@@ -81,6 +81,9 @@ func (d *JavaScriptDetector) ExtractEndpoints(filePath string, content []byte) (
 		}
 	}
 	
+	// File-routed handlers (Next.js, SvelteKit, Nuxt)
+	endpoints = append(endpoints, extractFileRoutes(filePath, content, LangJavaScript)...)
+	
 	return endpoints, nil
 }
 
@@ -0,0 +1,241 @@
+package inspect
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// JUnitTestSuites is the root element of a JUnit XML report
+type JUnitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	Name       string           `xml:"name,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	Errors     int              `xml:"errors,attr"`
+	Properties []JUnitProperty  `xml:"properties>property"` // Summary counters, e.g. missing_modules
+	Suites     []JUnitTestSuite `xml:"testsuite"`
+}
+
+// JUnitProperty is a named value of the report, such as a summary counter
+type JUnitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// JUnitTestSuite groups the checks of one module
+type JUnitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	TestCases []JUnitTestCase `xml:"testcase"`
+}
+
+// JUnitTestCase is a single check
+type JUnitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *JUnitFailure `xml:"failure,omitempty"`
+	Skipped   *JUnitSkipped `xml:"skipped,omitempty"`
+}
+
+// JUnitFailure describes drift found by a check
+type JUnitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// JUnitSkipped marks a check whose findings are informational only
+type JUnitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// ToJUnit converts an inspection result to a JUnit XML report.
+// Each foundation module becomes a testsuite with one testcase per check that ran;
+// warnings for other modules (e.g. "api" endpoints, extra code directories) get
+// their own testsuites. Error and warning findings are failures, info findings are skipped.
+func ToJUnit(result *InspectResult) *JUnitTestSuites {
+	// Group warnings by module and check
+	grouped := make(map[string]map[Check][]Warning)
+	for _, w := range result.Warnings {
+		if grouped[w.Module] == nil {
+			grouped[w.Module] = make(map[Check][]Warning)
+		}
+		grouped[w.Module][w.Type.Check()] = append(grouped[w.Module][w.Type.Check()], w)
+	}
+
+	foundation := make(map[string]bool)
+	for _, module := range result.Modules {
+		foundation[module] = true
+	}
+
+	ran := make(map[Check]bool)
+	for _, check := range result.Checks {
+		ran[check] = true
+	}
+
+	// Foundation modules first, in order, then any other modules with findings
+	suiteNames := append([]string{}, result.Modules...)
+	var others []string
+	for module := range grouped {
+		if !foundation[module] {
+			others = append(others, module)
+		}
+	}
+	listed := make(map[string]bool)
+	for _, check := range result.Checks {
+		if module := check.Module(); module != "" && grouped[module] == nil && !foundation[module] && !listed[module] {
+			listed[module] = true
+			others = append(others, module)
+		}
+	}
+	sort.Strings(others)
+	suiteNames = append(suiteNames, others...)
+
+	report := &JUnitTestSuites{
+		Name:       "neev inspect",
+		Properties: junitSummaryProperties(result.Summary),
+	}
+
+	for _, module := range suiteNames {
+		suite := JUnitTestSuite{Name: module}
+
+		for _, check := range []Check{CheckModule, CheckFiles, CheckEndpoints, CheckEvents, CheckConfig, CheckDataModel, CheckStack, CheckSignatures, CheckTests} {
+			warnings := grouped[module][check]
+
+			// Foundation modules list every per-module check that ran; other suites
+			// only list checks with findings, plus the repository-wide checks that report
+			// against them (e.g. endpoints for the "api" suite).
+			include := len(warnings) > 0
+			if foundation[module] && ran[check] && check.PerModule() {
+				include = true
+			}
+			if module == check.Module() && ran[check] {
+				include = true
+			}
+			if !include {
+				continue
+			}
+
+			testCase := junitTestCase(module, check, warnings)
+			suite.TestCases = append(suite.TestCases, testCase)
+			suite.Tests++
+			if testCase.Failure != nil {
+				suite.Failures++
+			}
+			if testCase.Skipped != nil {
+				suite.Skipped++
+			}
+		}
+
+		if suite.Tests == 0 {
+			continue
+		}
+
+		report.Suites = append(report.Suites, suite)
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+	}
+
+	return report
+}
+
+// MarshalJUnit renders an inspection result as indented JUnit XML
+func MarshalJUnit(result *InspectResult) ([]byte, error) {
+	data, err := xml.MarshalIndent(ToJUnit(result), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+// junitTestCase builds the testcase for one module check
+func junitTestCase(module string, check Check, warnings []Warning) JUnitTestCase {
+	testCase := JUnitTestCase{
+		Name:      string(check),
+		ClassName: "neev.inspect." + module,
+	}
+	if len(warnings) == 0 {
+		return testCase
+	}
+
+	var failing []Warning
+	for _, w := range warnings {
+		if w.Severity == "error" || w.Severity == "warning" {
+			failing = append(failing, w)
+		}
+	}
+
+	if len(failing) == 0 {
+		testCase.Skipped = &JUnitSkipped{Message: warnings[0].Message}
+		return testCase
+	}
+
+	// Report the most severe finding first
+	sort.SliceStable(failing, func(i, j int) bool {
+		return failing[i].Severity == "error" && failing[j].Severity != "error"
+	})
+
+	var body strings.Builder
+	for _, w := range warnings {
+		fmt.Fprintf(&body, "[%s] %s: %s\n", w.Severity, w.Type, w.Message)
+		if w.Remediation != "" {
+			fmt.Fprintf(&body, "  Remediation: %s\n", w.Remediation)
+		}
+	}
+
+	testCase.Failure = &JUnitFailure{
+		Message: failing[0].Message,
+		Type:    string(failing[0].Type),
+		Body:    body.String(),
+	}
+	return testCase
+}
+
+// junitSummaryProperties exposes the Summary counters as properties of the
+// testsuites element
+func junitSummaryProperties(summary Summary) []JUnitProperty {
+	counters := []struct {
+		name  string
+		value int
+	}{
+		{"total_modules", summary.TotalModules},
+		{"matching_modules", summary.MatchingModules},
+		{"missing_modules", summary.MissingModules},
+		{"extra_code_dirs", summary.ExtraCodeDirs},
+		{"total_warnings", summary.TotalWarnings},
+		{"error_count", summary.ErrorCount},
+		{"warning_count", summary.WarningCount},
+		{"missing_endpoints", summary.MissingEndpoints},
+		{"undocumented_endpoints", summary.UndocumentedEnds},
+		{"schema_mismatches", summary.SchemaMismatches},
+		{"missing_rpcs", summary.MissingRPCs},
+		{"undocumented_rpcs", summary.UndocumentedRPCs},
+		{"unresolved_fields", summary.UnresolvedFields},
+		{"undocumented_resolvers", summary.UndocumentedResolvers},
+		{"missing_topics", summary.MissingTopics},
+		{"undocumented_topics", summary.UndocumentedTopics},
+		{"undocumented_env_vars", summary.UndocumentedEnvVars},
+		{"unused_env_vars", summary.UnusedEnvVars},
+		{"forbidden_dependencies", summary.ForbiddenDependencies},
+		{"unapproved_dependencies", summary.UnapprovedDependencies},
+		{"dependency_version_mismatches", summary.DependencyVersionMismatches},
+		{"missing_tables", summary.MissingTables},
+		{"missing_columns", summary.MissingColumns},
+		{"column_type_mismatches", summary.ColumnTypeMismatches},
+		{"signature_mismatches", summary.SignatureMismatches},
+		{"untested_endpoints", summary.UntestedEndpoints},
+		{"orphaned_scenarios", summary.OrphanedScenarios},
+	}
+
+	var properties []JUnitProperty
+	for _, c := range counters {
+		properties = append(properties, JUnitProperty{Name: c.name, Value: strconv.Itoa(c.value)})
+	}
+	return properties
+}
@@ -0,0 +1,91 @@
+package inspect
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestToJUnit(t *testing.T) {
+	result := &InspectResult{
+		Modules: []string{"auth", "billing"},
+		Checks:  []Check{CheckModule, CheckFiles, CheckEndpoints},
+		Warnings: []Warning{
+			{Type: WarningMissingModule, Module: "billing", Message: "billing missing", Severity: "warning"},
+			{Type: WarningMissingFile, Module: "auth", Message: "pattern not matched", Severity: "info"},
+			{Type: WarningExtraCode, Module: "legacy", Message: "legacy has no spec", Severity: "info"},
+			{Type: WarningMissingEndpoint, Module: "api", Message: "POST /users not implemented", Severity: "error"},
+		},
+		Summary: Summary{TotalModules: 2, MatchingModules: 1, MissingModules: 1},
+	}
+
+	report := ToJUnit(result)
+
+	var names []string
+	for _, suite := range report.Suites {
+		names = append(names, suite.Name)
+	}
+	if got := strings.Join(names, ","); got != "auth,billing,api,legacy" {
+		t.Fatalf("Suites = %s, want auth,billing,api,legacy", got)
+	}
+
+	auth := report.Suites[0]
+	if auth.Tests != 2 || auth.Failures != 0 || auth.Skipped != 1 {
+		t.Errorf("auth suite: tests=%d failures=%d skipped=%d, want 2/0/1", auth.Tests, auth.Failures, auth.Skipped)
+	}
+
+	billing := report.Suites[1]
+	if billing.TestCases[0].Name != string(CheckModule) || billing.TestCases[0].Failure == nil {
+		t.Errorf("Expected failing 'module exists' testcase for billing, got %+v", billing.TestCases[0])
+	}
+
+	api := report.Suites[2]
+	if len(api.TestCases) != 1 || api.TestCases[0].Failure == nil || api.TestCases[0].Failure.Type != string(WarningMissingEndpoint) {
+		t.Errorf("Expected failing endpoints testcase for api, got %+v", api.TestCases)
+	}
+
+	if report.Tests != 6 || report.Failures != 2 {
+		t.Errorf("Totals: tests=%d failures=%d, want 6/2", report.Tests, report.Failures)
+	}
+
+	foundProperty := false
+	for _, property := range report.Properties {
+		if property.Name == "missing_modules" && property.Value == "1" {
+			foundProperty = true
+		}
+	}
+	if !foundProperty {
+		t.Errorf("Expected missing_modules=1 property, got %+v", report.Properties)
+	}
+}
+
+func TestMarshalJUnit(t *testing.T) {
+	result := &InspectResult{
+		Success: true,
+		Modules: []string{"auth"},
+		Checks:  []Check{CheckModule},
+	}
+
+	data, err := MarshalJUnit(result)
+	if err != nil {
+		t.Fatalf("MarshalJUnit failed: %v", err)
+	}
+
+	if !strings.HasPrefix(string(data), "<?xml") {
+		t.Errorf("Expected XML header")
+	}
+	if !strings.Contains(string(data), `<testsuites name="neev inspect" tests="1" failures="0" errors="0">`) {
+		t.Errorf("Expected only standard testsuites attributes, got %s", data)
+	}
+	if !strings.Contains(string(data), `<property name="total_modules" value="0"></property>`) {
+		t.Errorf("Expected summary counters as testsuites properties, got %s", data)
+	}
+
+	var decoded JUnitTestSuites
+	if err := xml.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Invalid XML: %v", err)
+	}
+	if decoded.Tests != 1 || decoded.Failures != 0 {
+		t.Errorf("Expected 1 passing test, got tests=%d failures=%d", decoded.Tests, decoded.Failures)
+	}
+}
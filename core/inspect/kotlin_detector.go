@@ -0,0 +1,195 @@
+package inspect
+
+import (
+	"regexp"
+	"strings"
+)
+
+// KotlinDetector handles Kotlin language analysis
+type KotlinDetector struct{}
+
+// NewKotlinDetector creates a new Kotlin language detector
+func NewKotlinDetector() *KotlinDetector {
+	return &KotlinDetector{}
+}
+
+// Detect returns true if this is a Kotlin file
+func (d *KotlinDetector) Detect(filePath string) bool {
+	lower := strings.ToLower(filePath)
+	return strings.HasSuffix(lower, ".kt") || strings.HasSuffix(lower, ".kts")
+}
+
+// Language returns the language identifier
+func (d *KotlinDetector) Language() Language {
+	return LangKotlin
+}
+
+var (
+	// Ktor: route("/api/users") { and get("/{id}") { or get {
+	ktorRoutePattern = regexp.MustCompile(`^route\s*\(\s*"([^"]*)"`)
+	ktorVerbPattern  = regexp.MustCompile(`^(get|post|put|patch|delete|head|options)\s*(?:\(\s*"([^"]*)"\s*\))?\s*\{`)
+
+	kotlinAnnotationPattern = regexp.MustCompile(`^@(GetMapping|PostMapping|PutMapping|DeleteMapping|PatchMapping|RequestMapping)\b(?:\s*\((.*)\))?`)
+	kotlinClassPattern      = regexp.MustCompile(`\b(?:class|object|interface)\s+(\w+)`)
+	kotlinFunPattern        = regexp.MustCompile(`^((?:(?:public|private|protected|internal|override|open|suspend|inline|operator|abstract)\s+)*)fun\s+(?:<[^>]*>\s*)?(?:([\w.]+(?:<[^>]*>)?)\.)?(\w+)\s*\(`)
+)
+
+// ExtractEndpoints finds HTTP endpoints in Kotlin code
+// Supports: Ktor routing DSL, Spring annotations with class-level prefixes
+func (d *KotlinDetector) ExtractEndpoints(filePath string, content []byte) ([]Endpoint, error) {
+	var endpoints []Endpoint
+	lines := strings.Split(string(content), "\n")
+
+	routes := &prefixStack{}
+	springPrefix := ""
+	var pending *springMapping
+
+	for lineNum, line := range lines {
+		trimmedLine := strings.TrimSpace(line)
+
+		// Spring annotations work as in Java
+		if match := kotlinAnnotationPattern.FindStringSubmatch(trimmedLine); match != nil {
+			pending = parseSpringMapping(match[1], match[2])
+		} else if kotlinClassPattern.MatchString(trimmedLine) && !strings.HasPrefix(trimmedLine, "@") {
+			springPrefix = ""
+			if pending != nil {
+				springPrefix = pending.path
+			}
+			pending = nil
+		} else if match := kotlinFunPattern.FindStringSubmatch(trimmedLine); match != nil && pending != nil {
+			for _, method := range pending.methods {
+				endpoints = append(endpoints, Endpoint{
+					Method:   method,
+					Path:     controllerRoutePath(springPrefix, pending.path),
+					Handler:  match[3],
+					File:     filePath,
+					Line:     lineNum + 1,
+					Language: string(LangKotlin),
+				})
+			}
+			pending = nil
+		} else if match := ktorRoutePattern.FindStringSubmatch(trimmedLine); match != nil {
+			routes.push(match[1])
+		} else if match := ktorVerbPattern.FindStringSubmatch(trimmedLine); match != nil {
+			endpoints = append(endpoints, Endpoint{
+				Method:   strings.ToUpper(match[1]),
+				Path:     controllerRoutePath(routes.current(), match[2]),
+				File:     filePath,
+				Line:     lineNum + 1,
+				Language: string(LangKotlin),
+			})
+		}
+
+		routes.advance(line)
+	}
+
+	return endpoints, nil
+}
+
+// ExtractFunctions finds function signatures in Kotlin code, including
+// suspend and extension functions with parameters spanning several lines
+func (d *KotlinDetector) ExtractFunctions(filePath string, content []byte) ([]FunctionSignature, error) {
+	var functions []FunctionSignature
+	text := string(content)
+
+	className := ""
+	offset := 0
+	for lineNum, line := range strings.Split(text, "\n") {
+		lineStart := offset
+		offset += len(line) + 1
+
+		trimmedLine := strings.TrimSpace(line)
+		indent := strings.Index(line, trimmedLine)
+
+		if match := kotlinClassPattern.FindStringSubmatch(trimmedLine); match != nil && !strings.Contains(trimmedLine, "fun ") {
+			className = match[1]
+			continue
+		}
+
+		match := kotlinFunPattern.FindStringSubmatchIndex(trimmedLine)
+		if match == nil {
+			continue
+		}
+		modifiers := trimmedLine[match[2]:match[3]]
+		name := trimmedLine[match[6]:match[7]]
+
+		// Extension functions are owned by the type they extend
+		receiver := className
+		if match[4] >= 0 {
+			receiver = trimmedLine[match[4]:match[5]]
+		}
+
+		open := lineStart + indent + match[1] - 1
+		closeIdx := matchingParen(text, open)
+		if closeIdx < 0 {
+			continue
+		}
+
+		var returns []ReturnSpec
+		if returnType := kotlinReturnType(text[closeIdx+1:]); returnType != "" && returnType != "Unit" {
+			returns = append(returns, ReturnSpec{Type: returnType})
+		}
+
+		visibility := "public" // default in Kotlin
+		for _, modifier := range strings.Fields(modifiers) {
+			if modifier == "private" || modifier == "protected" || modifier == "internal" {
+				visibility = modifier
+			}
+		}
+
+		functions = append(functions, FunctionSignature{
+			Name:       name,
+			Receiver:   receiver,
+			Parameters: d.parseKotlinParameters(text[open+1 : closeIdx]),
+			Returns:    returns,
+			File:       filePath,
+			Line:       lineNum + 1,
+			Visibility: visibility,
+		})
+	}
+
+	return functions, nil
+}
+
+// kotlinReturnType reads an optional ": Type" after a parameter list, up to
+// the body, an expression body or the end of the line
+func kotlinReturnType(rest string) string {
+	trimmed := strings.TrimLeft(rest, " \t")
+	if !strings.HasPrefix(trimmed, ":") {
+		return ""
+	}
+	trimmed = trimmed[1:]
+	if end := strings.IndexAny(trimmed, "{=\n"); end >= 0 {
+		trimmed = trimmed[:end]
+	}
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(trimmed), "where"))
+}
+
+// parseKotlinParameters parses "name: Type = default" parameters, ignoring
+// annotations such as @PathVariable
+func (d *KotlinDetector) parseKotlinParameters(paramsStr string) []ParameterSpec {
+	var params []ParameterSpec
+
+	for _, part := range splitTopLevel(paramsStr, ',') {
+		part = stripDecorators(strings.TrimSpace(part))
+		part = strings.TrimSpace(strings.TrimPrefix(part, "vararg "))
+		if part == "" {
+			continue
+		}
+		if pieces := splitTopLevel(part, '='); len(pieces) > 0 {
+			part = strings.TrimSpace(pieces[0])
+		}
+
+		paramName, paramType := part, "Any"
+		if colon := strings.Index(part, ":"); colon >= 0 {
+			paramName = strings.TrimSpace(part[:colon])
+			paramType = strings.TrimSpace(part[colon+1:])
+		}
+		params = append(params, ParameterSpec{
+			Name: paramName,
+			Type: paramType,
+		})
+	}
+
+	return params
+}
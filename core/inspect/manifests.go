@@ -0,0 +1,361 @@
+package inspect
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Dependency is a library declared in a package manifest
+type Dependency struct {
+	Name      string // Module path, package name or Maven groupId:artifactId
+	Version   string // Resolved or minimum version; empty if unknown
+	Ecosystem string // go, npm, pypi, maven or rubygems
+	File      string
+	Line      int
+}
+
+var (
+	// go.mod require lines, inside or outside a require block
+	goRequirePattern = regexp.MustCompile(`^\s*(?:require\s+)?([^\s()]+)\s+(v[^\s]+)(\s*//\s*indirect)?`)
+
+	// PEP 508 requirement: name, extras, then version specifiers
+	pepRequirementPattern = regexp.MustCompile(`^\s*([A-Za-z0-9][A-Za-z0-9._-]*)\s*(?:\[[^\]]*\])?\s*([^;#]*)`)
+
+	// Poetry dependency: name = "^1.2" or name = { version = "^1.2", ... }
+	poetryDependencyPattern = regexp.MustCompile(`^\s*"?([A-Za-z0-9][A-Za-z0-9._-]*)"?\s*=\s*(?:"([^"]*)"|\{.*?\bversion\s*=\s*"([^"]*)")?`)
+
+	// Gemfile.lock entries: "    rails (7.1.2)" in specs and "  rails (~> 7.1)" in DEPENDENCIES
+	gemSpecPattern       = regexp.MustCompile(`^    ([A-Za-z0-9_.-]+) \(([^)]+)\)`)
+	gemDependencyPattern = regexp.MustCompile(`^  ([A-Za-z0-9_.-]+)(?: \(|!?$)`)
+
+	// Quoted TOML strings
+	tomlStringPattern = regexp.MustCompile(`"([^"]*)"|'([^']*)'`)
+
+	// Leading version of a range such as "^4.18.2", ">=2.0,<3" or "~> 7.1"
+	leadingVersionPattern = regexp.MustCompile(`^[\s^~>=v]*(\d+(?:\.\d+)*)`)
+)
+
+// manifestExtractor reads package manifests during the repository scan.
+// Manifests of installed packages in node_modules are skipped.
+var manifestExtractor = FileExtractor{
+	Name:    "manifests",
+	Version: "1",
+	Match: func(path string) bool {
+		for _, dir := range strings.Split(filepath.ToSlash(filepath.Dir(path)), "/") {
+			if dir == "node_modules" {
+				return false
+			}
+		}
+		return manifestParser(filepath.Base(path)) != nil
+	},
+	Extract: func(path string, content []byte) interface{} {
+		return manifestParser(filepath.Base(path))(path, string(content))
+	},
+	Decode: decodeFacts[[]Dependency],
+}
+
+// FindDependencies returns the direct dependencies declared by the package
+// manifests found by a repository scan
+func FindDependencies(scan *ScanResult) []Dependency {
+	var dependencies []Dependency
+	for _, file := range scan.Facts[manifestExtractor.Name] {
+		for _, dependency := range file.Facts.([]Dependency) {
+			dependency.File = file.Path
+			dependencies = append(dependencies, dependency)
+		}
+	}
+	return dependencies
+}
+
+// manifestParser returns the parser for a manifest file name, or nil
+func manifestParser(name string) func(string, string) []Dependency {
+	switch {
+	case name == "go.mod":
+		return ParseGoMod
+	case name == "package.json":
+		return ParsePackageJSON
+	case name == "pyproject.toml":
+		return ParsePyproject
+	case name == "pom.xml":
+		return ParsePOM
+	case name == "Gemfile.lock":
+		return ParseGemfileLock
+	case strings.HasPrefix(name, "requirements") && strings.HasSuffix(name, ".txt"):
+		return ParseRequirements
+	}
+	return nil
+}
+
+// ParseGoMod returns the direct requirements of a go.mod file
+func ParseGoMod(filePath, text string) []Dependency {
+	var dependencies []Dependency
+	inBlock := false
+
+	for lineNum, line := range strings.Split(text, "\n") {
+		trimmedLine := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmedLine, "require ("):
+			inBlock = true
+			continue
+		case inBlock && strings.HasPrefix(trimmedLine, ")"):
+			inBlock = false
+			continue
+		case !inBlock && !strings.HasPrefix(trimmedLine, "require "):
+			continue
+		}
+
+		match := goRequirePattern.FindStringSubmatch(line)
+		if match == nil || match[3] != "" {
+			continue // Indirect requirements are not chosen by the project
+		}
+		dependencies = append(dependencies, Dependency{
+			Name:      match[1],
+			Version:   strings.TrimSuffix(strings.TrimPrefix(match[2], "v"), "+incompatible"),
+			Ecosystem: "go",
+			File:      filePath,
+			Line:      lineNum + 1,
+		})
+	}
+
+	return dependencies
+}
+
+// ParsePackageJSON returns the dependencies and devDependencies of a
+// package.json. Versions are the lower bound of the declared range.
+func ParsePackageJSON(filePath, text string) []Dependency {
+	var dependencies []Dependency
+
+	var manifest struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if err := json.Unmarshal([]byte(text), &manifest); err != nil {
+		return dependencies
+	}
+
+	for _, group := range []map[string]string{manifest.Dependencies, manifest.DevDependencies} {
+		var names []string
+		for name := range group {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			dependencies = append(dependencies, Dependency{
+				Name:      name,
+				Version:   leadingVersion(group[name]),
+				Ecosystem: "npm",
+				File:      filePath,
+				Line:      lineOf(text, `"`+name+`"`),
+			})
+		}
+	}
+
+	return dependencies
+}
+
+// ParseRequirements returns the packages of a pip requirements file
+func ParseRequirements(filePath, text string) []Dependency {
+	var dependencies []Dependency
+
+	for lineNum, line := range strings.Split(text, "\n") {
+		trimmedLine := strings.TrimSpace(line)
+		if trimmedLine == "" || strings.HasPrefix(trimmedLine, "#") || strings.HasPrefix(trimmedLine, "-") || strings.Contains(trimmedLine, "://") {
+			continue
+		}
+		if dependency, ok := parsePEP508(trimmedLine); ok {
+			dependency.File, dependency.Line = filePath, lineNum+1
+			dependencies = append(dependencies, dependency)
+		}
+	}
+
+	return dependencies
+}
+
+// ParsePyproject returns the [project] dependencies and Poetry dependencies of
+// a pyproject.toml
+func ParsePyproject(filePath, text string) []Dependency {
+	var dependencies []Dependency
+	section := ""
+	inArray := false
+
+	for lineNum, line := range strings.Split(text, "\n") {
+		trimmedLine := strings.TrimSpace(line)
+		if comment := strings.Index(trimmedLine, " #"); comment >= 0 {
+			trimmedLine = strings.TrimSpace(trimmedLine[:comment])
+		}
+		if trimmedLine == "" || strings.HasPrefix(trimmedLine, "#") {
+			continue
+		}
+
+		if inArray {
+			// One PEP 508 string per line of the dependencies array
+			for _, literal := range tomlStringPattern.FindAllStringSubmatch(trimmedLine, -1) {
+				if dependency, ok := parsePEP508(literal[1] + literal[2]); ok {
+					dependency.File, dependency.Line = filePath, lineNum+1
+					dependencies = append(dependencies, dependency)
+				}
+			}
+			if strings.Contains(trimmedLine, "]") && !strings.Contains(trimmedLine, "[") {
+				inArray = false
+			}
+			continue
+		}
+
+		if strings.HasPrefix(trimmedLine, "[") {
+			section = strings.Trim(trimmedLine, "[] ")
+			continue
+		}
+
+		switch {
+		case section == "project" && strings.HasPrefix(trimmedLine, "dependencies"):
+			_, value, _ := strings.Cut(trimmedLine, "=")
+			value = strings.TrimSpace(value)
+			for _, literal := range tomlStringPattern.FindAllStringSubmatch(value, -1) {
+				if dependency, ok := parsePEP508(literal[1] + literal[2]); ok {
+					dependency.File, dependency.Line = filePath, lineNum+1
+					dependencies = append(dependencies, dependency)
+				}
+			}
+			inArray = strings.HasPrefix(value, "[") && !strings.HasSuffix(value, "]")
+
+		case section == "tool.poetry.dependencies" || section == "tool.poetry.dev-dependencies" ||
+			strings.HasPrefix(section, "tool.poetry.group.") && strings.HasSuffix(section, ".dependencies"):
+			match := poetryDependencyPattern.FindStringSubmatch(trimmedLine)
+			if match == nil || strings.EqualFold(match[1], "python") {
+				continue
+			}
+			dependencies = append(dependencies, Dependency{
+				Name:      match[1],
+				Version:   leadingVersion(match[2] + match[3]),
+				Ecosystem: "pypi",
+				File:      filePath,
+				Line:      lineNum + 1,
+			})
+		}
+	}
+
+	return dependencies
+}
+
+// parsePEP508 parses a requirement such as "Django>=4.2,<5" or "uvicorn[standard]==0.23.2"
+func parsePEP508(requirement string) (Dependency, bool) {
+	match := pepRequirementPattern.FindStringSubmatch(requirement)
+	if match == nil {
+		return Dependency{}, false
+	}
+	return Dependency{
+		Name:      match[1],
+		Version:   leadingVersion(match[2]),
+		Ecosystem: "pypi",
+	}, true
+}
+
+// ParsePOM returns the dependencies of a Maven pom.xml as groupId:artifactId,
+// resolving ${property} versions from its properties. Entries of
+// dependencyManagement only pin versions and are not dependencies.
+func ParsePOM(filePath, text string) []Dependency {
+	var dependencies []Dependency
+
+	var pom struct {
+		Properties struct {
+			Entries []struct {
+				XMLName xml.Name
+				Value   string `xml:",chardata"`
+			} `xml:",any"`
+		} `xml:"properties"`
+		Dependencies []struct {
+			GroupID    string `xml:"groupId"`
+			ArtifactID string `xml:"artifactId"`
+			Version    string `xml:"version"`
+		} `xml:"dependencies>dependency"`
+	}
+	if err := xml.Unmarshal([]byte(text), &pom); err != nil {
+		return dependencies
+	}
+
+	properties := make(map[string]string)
+	for _, entry := range pom.Properties.Entries {
+		properties[entry.XMLName.Local] = strings.TrimSpace(entry.Value)
+	}
+
+	for _, dep := range pom.Dependencies {
+		version := strings.TrimSpace(dep.Version)
+		if strings.HasPrefix(version, "${") && strings.HasSuffix(version, "}") {
+			version = properties[version[2:len(version)-1]]
+		}
+		dependencies = append(dependencies, Dependency{
+			Name:      strings.TrimSpace(dep.GroupID) + ":" + strings.TrimSpace(dep.ArtifactID),
+			Version:   leadingVersion(strings.Trim(version, "[]()")),
+			Ecosystem: "maven",
+			File:      filePath,
+			Line:      lineOf(text, "<artifactId>"+strings.TrimSpace(dep.ArtifactID)+"</artifactId>"),
+		})
+	}
+
+	return dependencies
+}
+
+// ParseGemfileLock returns the gems listed under DEPENDENCIES of a
+// Gemfile.lock, with the versions the lockfile resolved
+func ParseGemfileLock(filePath, text string) []Dependency {
+	var dependencies []Dependency
+	resolved := make(map[string]string)
+	section := ""
+
+	lines := strings.Split(text, "\n")
+	for _, line := range lines {
+		if line != "" && !strings.HasPrefix(line, " ") {
+			section = strings.TrimSpace(line)
+			continue
+		}
+		if section == "GEM" || section == "PATH" || section == "GIT" {
+			if match := gemSpecPattern.FindStringSubmatch(line); match != nil {
+				version, _, _ := strings.Cut(match[2], "-") // Platform suffix, e.g. 1.15.4-x86_64-linux
+				resolved[match[1]] = version
+			}
+		}
+	}
+
+	section = ""
+	for lineNum, line := range lines {
+		if line != "" && !strings.HasPrefix(line, " ") {
+			section = strings.TrimSpace(line)
+			continue
+		}
+		if section != "DEPENDENCIES" {
+			continue
+		}
+		if match := gemDependencyPattern.FindStringSubmatch(line); match != nil {
+			dependencies = append(dependencies, Dependency{
+				Name:      match[1],
+				Version:   resolved[match[1]],
+				Ecosystem: "rubygems",
+				File:      filePath,
+				Line:      lineNum + 1,
+			})
+		}
+	}
+
+	return dependencies
+}
+
+// leadingVersion returns the first version of a range, e.g. "4.18.2" for "^4.18.2"
+func leadingVersion(constraint string) string {
+	if match := leadingVersionPattern.FindStringSubmatch(constraint); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// lineOf returns the line of the first occurrence of needle, or 0
+func lineOf(text, needle string) int {
+	offset := strings.Index(text, needle)
+	if offset < 0 {
+		return 0
+	}
+	return strings.Count(text[:offset], "\n") + 1
+}
@@ -16,73 +16,71 @@ type OpenAPISpec struct {
 	Endpoints []openapi.Endpoint
 }
 
-// ValidateOpenAPIContracts checks if code implements documented API endpoints
-func ValidateOpenAPIContracts(opts InspectOptions, analyzer *PolyglotAnalyzer) ([]Warning, error) {
+// ValidateOpenAPIContracts checks if the endpoints found by a repository scan
+// implement the documented API endpoints
+func ValidateOpenAPIContracts(opts InspectOptions, scan *ScanResult) ([]Warning, error) {
 	var warnings []Warning
 	
-	// Try to find openapi.yaml or architecture.md in foundation/blueprints
+	specEndpoints := collectSpecEndpoints(opts)
+	
+	// If no spec, nothing to validate
+	if len(specEndpoints) == 0 {
+		return warnings, nil
+	}
+	
+	// Compare documented vs implemented
+	warnings = append(warnings, compareEndpoints(specEndpoints, scan.Endpoints)...)
+	
+	// Compare documented bodies with the types handlers bind and return
+	warnings = append(warnings, ValidateRequestSchemas(opts, specEndpoints, scan)...)
+	
+	return warnings, nil
+}
+
+// isOpenAPIFile reports whether a blueprint file is an OpenAPI document
+func isOpenAPIFile(path string) bool {
+	name := filepath.Base(path)
+	return name == "openapi.yaml" || name == "openapi.yml"
+}
+
+// collectSpecEndpoints gathers documented endpoints from openapi.yaml and
+// architecture.md files in blueprints, falling back to the foundation ARCHITECTURE.md
+func collectSpecEndpoints(opts InspectOptions) []openapi.Endpoint {
 	var specEndpoints []openapi.Endpoint
-	var err error
 	
 	// Check for openapi.yaml in blueprints
-	blueprintsPath := filepath.Join(opts.RootDir, ".neev", "blueprints")
-	if stat, statErr := os.Stat(blueprintsPath); statErr == nil && stat.IsDir() {
-		// Search for openapi.yaml files in blueprint directories
-		filepath.Walk(blueprintsPath, func(path string, info os.FileInfo, err error) error {
-			if err != nil {
-				return nil
-			}
-			if !info.IsDir() && (info.Name() == "openapi.yaml" || info.Name() == "openapi.yml") {
-				endpoints, parseErr := parseOpenAPIFile(path)
-				if parseErr == nil {
-					specEndpoints = append(specEndpoints, endpoints...)
-				}
-			}
-			return nil
-		})
+	for _, path := range specFiles(opts, []string{blueprintsDir(opts)}, isOpenAPIFile) {
+		endpoints, parseErr := parseOpenAPIFile(path)
+		if parseErr == nil {
+			specEndpoints = append(specEndpoints, endpoints...)
+		}
 	}
 	
 	// Also check for ARCHITECTURE.md in blueprints
-	filepath.Walk(blueprintsPath, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return nil
-		}
-		if !info.IsDir() && strings.ToLower(info.Name()) == "architecture.md" {
-			endpoints, parseErr := openapi.ParseArchitecture(path)
-			if parseErr == nil {
-				specEndpoints = append(specEndpoints, endpoints...)
-			}
-		}
-		return nil
+	architectureFiles := specFiles(opts, []string{blueprintsDir(opts)}, func(path string) bool {
+		return strings.ToLower(filepath.Base(path)) == "architecture.md"
 	})
+	for _, path := range architectureFiles {
+		endpoints, parseErr := openapi.ParseArchitecture(path)
+		if parseErr == nil {
+			specEndpoints = append(specEndpoints, endpoints...)
+		}
+	}
 	
 	// If no spec found, check foundation directory
 	if len(specEndpoints) == 0 {
 		archPath := filepath.Join(opts.FoundationPath, "ARCHITECTURE.md")
 		if _, statErr := os.Stat(archPath); statErr == nil {
-			specEndpoints, err = openapi.ParseArchitecture(archPath)
+			endpoints, err := openapi.ParseArchitecture(archPath)
 			if err != nil {
 				// Not fatal, just no spec to validate against
-				return warnings, nil
+				return nil
 			}
+			specEndpoints = endpoints
 		}
 	}
 	
-	// If still no spec, nothing to validate
-	if len(specEndpoints) == 0 {
-		return warnings, nil
-	}
-	
-	// Extract implemented endpoints from code
-	implementedEndpoints, err := analyzer.ExtractAllEndpoints(opts.RootDir, opts.IgnoreDirs)
-	if err != nil {
-		return warnings, fmt.Errorf("failed to extract endpoints from code: %w", err)
-	}
-	
-	// Compare documented vs implemented
-	warnings = append(warnings, compareEndpoints(specEndpoints, implementedEndpoints)...)
-	
-	return warnings, nil
+	return specEndpoints
 }
 
 // parseOpenAPIFile parses an OpenAPI YAML file and extracts endpoints
@@ -150,18 +148,32 @@ func compareEndpoints(specEndpoints []openapi.Endpoint, implEndpoints []Endpoint
 		specMap[key] = ep
 	}
 	
+	specPaths := make(map[string]bool)
+	for _, ep := range specEndpoints {
+		specPaths[normalizePath(ep.Path)] = true
+	}
+	
 	implMap := make(map[string]Endpoint)
 	for _, ep := range implEndpoints {
 		key := fmt.Sprintf("%s %s", ep.Method, normalizePath(ep.Path))
 		implMap[key] = ep
 	}
 	
+	// Handlers registered without a method (e.g. http.HandleFunc) answer any method
+	anyMethod := make(map[string]bool)
+	for _, ep := range implEndpoints {
+		if ep.Method == "ANY" {
+			anyMethod[normalizePath(ep.Path)] = true
+		}
+	}
+	
 	// Check for missing implementations (documented but not implemented)
 	for key, specEp := range specMap {
-		if _, exists := implMap[key]; !exists {
+		if _, exists := implMap[key]; !exists && !anyMethod[normalizePath(specEp.Path)] {
 			warning := Warning{
 				Type:     WarningMissingEndpoint,
 				Module:   "api",
+				Subject:  fmt.Sprintf("%s %s", specEp.Method, normalizePath(specEp.Path)),
 				Message:  fmt.Sprintf("API endpoint %s %s is documented but not implemented", specEp.Method, specEp.Path),
 				Severity: "error",
 				Remediation: fmt.Sprintf("Implement handler for %s %s or remove from API documentation", 
@@ -173,15 +185,21 @@ func compareEndpoints(specEndpoints []openapi.Endpoint, implEndpoints []Endpoint
 	
 	// Check for undocumented implementations (implemented but not documented)
 	for key, implEp := range implMap {
+		if implEp.Method == "ANY" && specPaths[normalizePath(implEp.Path)] {
+			continue
+		}
 		if _, exists := specMap[key]; !exists {
 			warning := Warning{
 				Type:     WarningUndocumentedEndpoint,
 				Module:   "api",
+				Subject:  fmt.Sprintf("%s %s", implEp.Method, normalizePath(implEp.Path)),
 				Message:  fmt.Sprintf("API endpoint %s %s is implemented but not documented (found in %s:%d)", 
 					implEp.Method, implEp.Path, filepath.Base(implEp.File), implEp.Line),
 				Severity: "warning",
 				Remediation: fmt.Sprintf("Add %s %s to API documentation (OpenAPI/ARCHITECTURE.md)", 
 					implEp.Method, implEp.Path),
+				File: implEp.File,
+				Line: implEp.Line,
 			}
 			warnings = append(warnings, warning)
 		}
@@ -0,0 +1,320 @@
+package inspect
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// PHPDetector handles PHP language analysis
+type PHPDetector struct{}
+
+// NewPHPDetector creates a new PHP language detector
+func NewPHPDetector() *PHPDetector {
+	return &PHPDetector{}
+}
+
+// Detect returns true if this is a PHP file
+func (d *PHPDetector) Detect(filePath string) bool {
+	return strings.HasSuffix(strings.ToLower(filePath), ".php")
+}
+
+// Language returns the language identifier
+func (d *PHPDetector) Language() Language {
+	return LangPHP
+}
+
+var (
+	// Route::get('/users', [UserController::class, 'index'])
+	laravelRoutePattern = regexp.MustCompile(`Route::(get|post|put|patch|delete|options|any)\s*\(\s*['"]([^'"]*)['"]\s*,?\s*(.*)$`)
+
+	// Route::match(['get', 'post'], '/users', ...)
+	laravelMatchPattern = regexp.MustCompile(`Route::match\s*\(\s*\[([^\]]*)\]\s*,\s*['"]([^'"]*)['"]\s*,?\s*(.*)$`)
+
+	// Route::resource('photos', PhotoController::class) and Route::apiResource(...)
+	laravelResourcePattern = regexp.MustCompile(`Route::(resource|apiResource)\s*\(\s*['"]([^'"]+)['"]\s*,\s*['"]?([\w\\]+?)(?:::class)?['"]?\s*\)(.*)$`)
+
+	// Route::prefix('admin')->group(function () { and Route::group(['prefix' => 'admin'], function () {
+	laravelPrefixPattern      = regexp.MustCompile(`(?:::|->)prefix\s*\(\s*['"]([^'"]*)['"]`)
+	laravelGroupPrefixPattern = regexp.MustCompile(`['"]prefix['"]\s*=>\s*['"]([^'"]*)['"]`)
+
+	// [UserController::class, 'index'] or 'UserController@index'
+	laravelActionPattern = regexp.MustCompile(`^\[\s*([\w\\]+)::class\s*,\s*['"](\w+)['"]\s*\]|^['"]([\w\\]+@\w+)['"]|^([\w\\]+)::class`)
+	laravelOnlyPattern   = regexp.MustCompile(`->(only|except)\s*\(\s*\[([^\]]*)\]`)
+
+	phpClassPattern    = regexp.MustCompile(`^(?:(?:abstract|final|readonly)\s+)*(?:class|trait|interface|enum)\s+(\w+)`)
+	phpFunctionPattern = regexp.MustCompile(`^((?:(?:public|private|protected|static|abstract|final)\s+)*)function\s+&?\s*(\w+)\s*\(`)
+	phpQuotedPattern   = regexp.MustCompile(`['"](\w+)['"]`)
+)
+
+// laravelResourceActions are the routes of Route::resource in registration order
+var laravelResourceActions = []struct {
+	action, method, suffix string
+	member, api            bool
+}{
+	{"index", "GET", "", false, true},
+	{"create", "GET", "/create", false, false},
+	{"store", "POST", "", false, true},
+	{"show", "GET", "", true, true},
+	{"edit", "GET", "/edit", true, false},
+	{"update", "PUT", "", true, true},
+	{"update", "PATCH", "", true, true},
+	{"destroy", "DELETE", "", true, true},
+}
+
+// ExtractEndpoints finds HTTP endpoints in PHP code
+// Supports: Laravel routes, resources and prefixed groups
+func (d *PHPDetector) ExtractEndpoints(filePath string, content []byte) ([]Endpoint, error) {
+	var endpoints []Endpoint
+	lines := strings.Split(string(content), "\n")
+
+	// Laravel serves routes/api.php under /api
+	basePrefix := ""
+	if filepath.Base(filePath) == "api.php" && filepath.Base(filepath.Dir(filePath)) == "routes" {
+		basePrefix = "api"
+	}
+
+	groups := &prefixStack{}
+	emit := func(lineNum int, method, path, handler string) {
+		prefix := joinRoutePath(basePrefix, strings.Trim(groups.current(), "/"))
+		endpoints = append(endpoints, Endpoint{
+			Method:   method,
+			Path:     controllerRoutePath(prefix, path),
+			Handler:  handler,
+			File:     filePath,
+			Line:     lineNum + 1,
+			Language: string(LangPHP),
+		})
+	}
+
+	for lineNum, line := range lines {
+		trimmedLine := strings.TrimSpace(line)
+
+		if match := laravelRoutePattern.FindStringSubmatch(trimmedLine); match != nil {
+			emit(lineNum, strings.ToUpper(match[1]), match[2], laravelAction(match[3]))
+		} else if match := laravelMatchPattern.FindStringSubmatch(trimmedLine); match != nil {
+			handler := laravelAction(match[3])
+			for _, method := range phpQuotedPattern.FindAllStringSubmatch(match[1], -1) {
+				emit(lineNum, strings.ToUpper(method[1]), match[2], handler)
+			}
+		} else if match := laravelResourcePattern.FindStringSubmatch(trimmedLine); match != nil {
+			controller := phpShortName(match[3])
+			actions := laravelResourceFilter(match[4])
+			base, param := laravelResourcePath(match[2])
+			for _, route := range laravelResourceActions {
+				if match[1] == "apiResource" && !route.api {
+					continue
+				}
+				if actions != nil && !actions[route.action] {
+					continue
+				}
+				path := base
+				if route.member {
+					path += "/{" + param + "}"
+				}
+				emit(lineNum, route.method, path+route.suffix, controller+"@"+route.action)
+			}
+		} else if strings.Contains(trimmedLine, "group(") {
+			prefix := ""
+			if match := laravelPrefixPattern.FindStringSubmatch(trimmedLine); match != nil {
+				prefix = match[1]
+			} else if match := laravelGroupPrefixPattern.FindStringSubmatch(trimmedLine); match != nil {
+				prefix = match[1]
+			}
+			groups.push(prefix)
+		}
+
+		groups.advance(line)
+	}
+
+	return endpoints, nil
+}
+
+// laravelAction renders the action of a route: "UserController@index" for
+// controller actions and invokable controllers by name; closures have none
+func laravelAction(args string) string {
+	match := laravelActionPattern.FindStringSubmatch(strings.TrimSpace(args))
+	switch {
+	case match == nil:
+		return ""
+	case match[1] != "":
+		return phpShortName(match[1]) + "@" + match[2]
+	case match[3] != "":
+		return phpShortName(match[3])
+	}
+	return phpShortName(match[4])
+}
+
+// phpShortName strips the namespace from a class name
+func phpShortName(name string) string {
+	if i := strings.LastIndex(name, "\\"); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
+
+// laravelResourceFilter returns the actions kept by ->only() or ->except(),
+// or nil when every action is registered
+func laravelResourceFilter(chain string) map[string]bool {
+	match := laravelOnlyPattern.FindStringSubmatch(chain)
+	if match == nil {
+		return nil
+	}
+	listed := make(map[string]bool)
+	for _, action := range phpQuotedPattern.FindAllStringSubmatch(match[2], -1) {
+		listed[action[1]] = true
+	}
+	if match[1] == "only" {
+		return listed
+	}
+	actions := make(map[string]bool)
+	for _, route := range laravelResourceActions {
+		actions[route.action] = !listed[route.action]
+	}
+	return actions
+}
+
+// laravelResourcePath returns the collection path of a resource and the name
+// of its parameter. Nested resources such as "photos.comments" become
+// /photos/{photo}/comments with the parameter {comment}.
+func laravelResourcePath(name string) (string, string) {
+	parts := strings.Split(name, ".")
+	path := ""
+	for _, parent := range parts[:len(parts)-1] {
+		path += "/" + parent + "/{" + laravelParameter(parent) + "}"
+	}
+	last := parts[len(parts)-1]
+	return path + "/" + last, laravelParameter(last)
+}
+
+// laravelParameter is the route parameter of a resource: photos -> photo
+func laravelParameter(resource string) string {
+	return strings.ReplaceAll(singularize(resource), "-", "_")
+}
+
+// ExtractFunctions finds function signatures in PHP code, including methods
+// with parameters spanning several lines
+func (d *PHPDetector) ExtractFunctions(filePath string, content []byte) ([]FunctionSignature, error) {
+	var functions []FunctionSignature
+	text := string(content)
+
+	className := ""
+	classDepth := -1
+	inClass := false
+	depth := 0
+	offset := 0
+	for lineNum, line := range strings.Split(text, "\n") {
+		lineStart := offset
+		offset += len(line) + 1
+
+		trimmedLine := strings.TrimSpace(line)
+		indent := strings.Index(line, trimmedLine)
+		lineDepth := depth
+		depth += strings.Count(line, "{") - strings.Count(line, "}")
+
+		if match := phpClassPattern.FindStringSubmatch(trimmedLine); match != nil {
+			className = match[1]
+			classDepth = lineDepth
+			inClass = depth > classDepth
+			continue
+		}
+		if classDepth >= 0 {
+			// The brace of a class often sits on the next line
+			if inClass && lineDepth <= classDepth {
+				className = ""
+				classDepth = -1
+			}
+			if depth > classDepth {
+				inClass = true
+			}
+		}
+
+		match := phpFunctionPattern.FindStringSubmatchIndex(trimmedLine)
+		if match == nil {
+			continue
+		}
+		modifiers := trimmedLine[match[2]:match[3]]
+		name := trimmedLine[match[4]:match[5]]
+
+		open := lineStart + indent + match[1] - 1
+		closeIdx := matchingParen(text, open)
+		if closeIdx < 0 {
+			continue
+		}
+
+		var returns []ReturnSpec
+		if returnType := phpReturnType(text[closeIdx+1:]); returnType != "" && returnType != "void" {
+			returns = append(returns, ReturnSpec{Type: returnType})
+		}
+
+		visibility := "public" // Methods without a modifier are public
+		if strings.Contains(modifiers, "private") {
+			visibility = "private"
+		} else if strings.Contains(modifiers, "protected") {
+			visibility = "protected"
+		}
+
+		functions = append(functions, FunctionSignature{
+			Name:       name,
+			Receiver:   className,
+			Parameters: d.parsePHPParameters(text[open+1 : closeIdx]),
+			Returns:    returns,
+			File:       filePath,
+			Line:       lineNum + 1,
+			Visibility: visibility,
+		})
+	}
+
+	return functions, nil
+}
+
+// phpReturnType reads an optional ": Type" after a parameter list
+func phpReturnType(rest string) string {
+	trimmed := strings.TrimLeft(rest, " \t\r\n")
+	if !strings.HasPrefix(trimmed, ":") {
+		return ""
+	}
+	trimmed = trimmed[1:]
+	if end := strings.IndexAny(trimmed, "{;\n"); end >= 0 {
+		trimmed = trimmed[:end]
+	}
+	return strings.TrimSpace(trimmed)
+}
+
+// parsePHPParameters parses "Type $name = default" parameters, including
+// promoted constructor properties
+func (d *PHPDetector) parsePHPParameters(paramsStr string) []ParameterSpec {
+	var params []ParameterSpec
+
+	for _, part := range splitTopLevel(paramsStr, ',') {
+		part = strings.TrimSpace(part)
+		if eq := strings.Index(part, "="); eq >= 0 {
+			part = strings.TrimSpace(part[:eq])
+		}
+		dollar := strings.LastIndex(part, "$")
+		if dollar < 0 {
+			continue
+		}
+
+		var typeWords []string
+		for _, word := range strings.Fields(part[:dollar]) {
+			switch word {
+			case "public", "private", "protected", "readonly":
+				continue
+			}
+			typeWords = append(typeWords, word)
+		}
+		paramType := strings.TrimSuffix(strings.Join(typeWords, " "), "...")
+		paramType = strings.TrimSpace(strings.TrimSuffix(paramType, "&"))
+		if paramType == "" {
+			paramType = "mixed"
+		}
+
+		params = append(params, ParameterSpec{
+			Name: part[dollar+1:],
+			Type: paramType,
+		})
+	}
+
+	return params
+}
@@ -0,0 +1,341 @@
+package inspect
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PluginProtocolVersion is the version of the detector plugin protocol
+const PluginProtocolVersion = 1
+
+// DefaultPluginTimeout bounds how long a plugin may take to answer one request
+const DefaultPluginTimeout = 30 * time.Second
+
+// Plugin configures an external detector (inspect.plugins in neev.yaml). The
+// command is started once per inspection and speaks JSON over stdio: neev
+// writes one request per line and the plugin answers each with one line.
+//
+//	-> {"protocol":1,"action":"endpoints","path":"api/users.rpc","content":"..."}
+//	<- {"endpoints":[{"method":"GET","path":"/users","handler":"ListUsers","line":3}]}
+//	-> {"protocol":1,"action":"functions","path":"api/users.rpc","content":"..."}
+//	<- {"functions":[{"name":"ListUsers","parameters":[{"name":"req","type":"ListRequest"}],"returns":[{"type":"ListResponse"}],"line":3,"visibility":"public"}]}
+//
+// A plugin answers {"error":"..."} for a file it cannot parse; the file then
+// contributes nothing. Exiting, timing out or writing invalid JSON fails the
+// inspection.
+type Plugin struct {
+	Name     string   `yaml:"name"`
+	Command  string   `yaml:"command"` // Executable; relative paths are resolved from the project root
+	Args     []string `yaml:"args,omitempty"`
+	Language string   `yaml:"language"`          // Language reported for the files it handles, e.g. "rpc"
+	Files    []string `yaml:"files"`             // Globs of files it handles; patterns without "/" match file names
+	Version  string   `yaml:"version,omitempty"` // Bump to invalidate cached results; defaults to the executable's modification time
+	Timeout  string   `yaml:"timeout,omitempty"` // Per-request timeout, e.g. "10s" (default 30s)
+}
+
+// Validate checks that the plugin is well-formed
+func (p Plugin) Validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("inspect plugin name cannot be empty")
+	}
+	if p.Command == "" {
+		return fmt.Errorf("command cannot be empty for inspect plugin '%s'", p.Name)
+	}
+	if p.Language == "" {
+		return fmt.Errorf("language cannot be empty for inspect plugin '%s'", p.Name)
+	}
+	if len(p.Files) == 0 {
+		return fmt.Errorf("files cannot be empty for inspect plugin '%s'", p.Name)
+	}
+	for _, pattern := range p.Files {
+		if _, err := path.Match(strings.ReplaceAll(pattern, "**", "*"), ""); err != nil {
+			return fmt.Errorf("invalid file glob '%s' for inspect plugin '%s': %w", pattern, p.Name, err)
+		}
+	}
+	if p.Timeout != "" {
+		if timeout, err := time.ParseDuration(p.Timeout); err != nil || timeout <= 0 {
+			return fmt.Errorf("invalid timeout '%s' for inspect plugin '%s'", p.Timeout, p.Name)
+		}
+	}
+	return nil
+}
+
+// pluginRequest is one line written to a plugin
+type pluginRequest struct {
+	Protocol int    `json:"protocol"`
+	Action   string `json:"action"` // endpoints or functions
+	Path     string `json:"path"`   // Relative to the project root, with forward slashes
+	Content  string `json:"content"`
+}
+
+// pluginResponse is one line read from a plugin
+type pluginResponse struct {
+	Endpoints []Endpoint          `json:"endpoints"`
+	Functions []FunctionSignature `json:"functions"`
+	Error     string              `json:"error"`
+}
+
+// PluginDetector is a LanguageDetector backed by an external process. The
+// process is started by the first request and serves every scan worker, one
+// request at a time.
+type PluginDetector struct {
+	plugin  Plugin
+	rootDir string
+	timeout time.Duration
+
+	versionOnce sync.Once
+	version     string
+
+	mu     sync.Mutex
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *bufio.Reader
+	err    error // Set once the process failed; every later request fails with it
+}
+
+// NewPluginDetector creates a detector for a configured plugin
+func NewPluginDetector(plugin Plugin, rootDir string) *PluginDetector {
+	timeout := DefaultPluginTimeout
+	if parsed, err := time.ParseDuration(plugin.Timeout); err == nil && parsed > 0 {
+		timeout = parsed
+	}
+	return &PluginDetector{plugin: plugin, rootDir: rootDir, timeout: timeout}
+}
+
+// start launches the plugin process; the caller holds d.mu
+func (d *PluginDetector) start() error {
+	resolved, err := exec.LookPath(d.command())
+	if err != nil {
+		return fmt.Errorf("plugin '%s': %w", d.plugin.Name, err)
+	}
+
+	cmd := exec.Command(resolved, d.plugin.Args...)
+	cmd.Dir = d.rootDir
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("plugin '%s': %w", d.plugin.Name, err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("plugin '%s': %w", d.plugin.Name, err)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("plugin '%s': failed to start: %w", d.plugin.Name, err)
+	}
+
+	d.cmd, d.stdin, d.stdout = cmd, stdin, bufio.NewReader(stdout)
+	return nil
+}
+
+// command returns the plugin executable, resolving relative paths from the project root
+func (d *PluginDetector) command() string {
+	command := d.plugin.Command
+	if strings.ContainsAny(command, `/\`) && !filepath.IsAbs(command) {
+		command = filepath.Join(d.rootDir, command)
+	}
+	return command
+}
+
+// Close ends the plugin process, if it was started, by closing its input and
+// returns the first error the plugin caused
+func (d *PluginDetector) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.cmd == nil {
+		return d.err
+	}
+	_ = d.stdin.Close()
+
+	done := make(chan error, 1)
+	go func() { done <- d.cmd.Wait() }()
+	select {
+	case err := <-done:
+		if err != nil && d.err == nil {
+			d.err = fmt.Errorf("plugin '%s' exited: %w", d.plugin.Name, err)
+		}
+	case <-time.After(d.timeout):
+		_ = d.cmd.Process.Kill()
+		<-done
+	}
+	d.cmd = nil
+	return d.err
+}
+
+// Detect returns true if the file matches one of the plugin's globs
+func (d *PluginDetector) Detect(filePath string) bool {
+	rel := filepath.ToSlash(filePath)
+	if r, err := filepath.Rel(d.rootDir, filePath); err == nil {
+		rel = filepath.ToSlash(r)
+	}
+	for _, pattern := range d.plugin.Files {
+		if matchPluginGlob(pattern, rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// Language returns the language configured for the plugin
+func (d *PluginDetector) Language() Language {
+	return Language(d.plugin.Language)
+}
+
+// Version identifies the plugin's extraction logic in the analysis cache
+func (d *PluginDetector) Version() string {
+	d.versionOnce.Do(func() {
+		d.version = d.plugin.Version
+		if d.version != "" {
+			return
+		}
+		// Rebuilding the executable invalidates its cached results
+		if resolved, err := exec.LookPath(d.command()); err == nil {
+			if info, err := os.Stat(resolved); err == nil {
+				d.version = fmt.Sprintf("%d-%d", info.ModTime().UnixNano(), info.Size())
+			}
+		}
+	})
+	return "plugin-" + d.plugin.Name + "-" + d.version
+}
+
+// ExtractEndpoints asks the plugin for the endpoints of a file
+func (d *PluginDetector) ExtractEndpoints(filePath string, content []byte) ([]Endpoint, error) {
+	response, err := d.request("endpoints", filePath, content)
+	if err != nil {
+		return nil, err
+	}
+	return response.Endpoints, nil
+}
+
+// ExtractFunctions asks the plugin for the function signatures of a file
+func (d *PluginDetector) ExtractFunctions(filePath string, content []byte) ([]FunctionSignature, error) {
+	response, err := d.request("functions", filePath, content)
+	if err != nil {
+		return nil, err
+	}
+	return response.Functions, nil
+}
+
+// request sends one request and waits for its answer
+func (d *PluginDetector) request(action, filePath string, content []byte) (*pluginResponse, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.err != nil {
+		return nil, d.err
+	}
+	if d.cmd == nil {
+		if d.err = d.start(); d.err != nil {
+			return nil, d.err
+		}
+	}
+
+	rel := filePath
+	if r, err := filepath.Rel(d.rootDir, filePath); err == nil {
+		rel = r
+	}
+	line, err := json.Marshal(pluginRequest{
+		Protocol: PluginProtocolVersion,
+		Action:   action,
+		Path:     filepath.ToSlash(rel),
+		Content:  string(content),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	type answer struct {
+		line []byte
+		err  error
+	}
+	answers := make(chan answer, 1)
+	go func() {
+		if _, err := d.stdin.Write(append(line, '\n')); err != nil {
+			answers <- answer{err: err}
+			return
+		}
+		reply, err := d.stdout.ReadBytes('\n')
+		answers <- answer{line: reply, err: err}
+	}()
+
+	var reply answer
+	select {
+	case reply = <-answers:
+	case <-time.After(d.timeout):
+		_ = d.cmd.Process.Kill()
+		d.err = fmt.Errorf("plugin '%s' did not answer within %s", d.plugin.Name, d.timeout)
+		return nil, d.err
+	}
+	if reply.err != nil {
+		d.err = fmt.Errorf("plugin '%s' stopped responding: %w", d.plugin.Name, reply.err)
+		return nil, d.err
+	}
+
+	var response pluginResponse
+	if err := json.Unmarshal(reply.line, &response); err != nil {
+		d.err = fmt.Errorf("plugin '%s' sent an invalid response: %w", d.plugin.Name, err)
+		return nil, d.err
+	}
+	if response.Error != "" {
+		return nil, fmt.Errorf("plugin '%s': %s", d.plugin.Name, response.Error)
+	}
+	return &response, nil
+}
+
+// isSourceFile reports whether a root-relative file is handled by a built-in
+// detector or one of the configured plugins
+func isSourceFile(opts InspectOptions, file string) bool {
+	if DetectLanguageByExtension(file) != "" {
+		return true
+	}
+	for _, plugin := range opts.Plugins {
+		for _, pattern := range plugin.Files {
+			if matchPluginGlob(pattern, filepath.ToSlash(file)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchPluginGlob matches a root-relative path against a glob in which "**"
+// spans any number of directories. Patterns without "/" match the file name.
+func matchPluginGlob(pattern, rel string) bool {
+	if !strings.Contains(pattern, "/") {
+		matched, _ := path.Match(pattern, path.Base(rel))
+		return matched
+	}
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(rel, "/"))
+}
+
+// matchSegments matches path segments against glob segments
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	if matched, _ := path.Match(pattern[0], segments[0]); !matched {
+		return false
+	}
+	return matchSegments(pattern[1:], segments[1:])
+}
@@ -0,0 +1,204 @@
+package inspect
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestPluginHelperProcess is not a real test: the plugin tests run the test
+// binary with NEEV_TEST_PLUGIN=1 as a detector plugin. It reports
+// "rpc METHOD /path Handler" lines as endpoints and "fn Name(arg Type) Ret"
+// lines as functions, rejects files containing "broken" and exits on "crash".
+func TestPluginHelperProcess(t *testing.T) {
+	if os.Getenv("NEEV_TEST_PLUGIN") != "1" {
+		return
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	encoder := json.NewEncoder(os.Stdout)
+	for scanner.Scan() {
+		var request pluginRequest
+		if err := json.Unmarshal(scanner.Bytes(), &request); err != nil {
+			os.Exit(2)
+		}
+		if strings.Contains(request.Content, "crash") {
+			os.Exit(3)
+		}
+		if strings.Contains(request.Content, "broken") {
+			_ = encoder.Encode(map[string]string{"error": "syntax error"})
+			continue
+		}
+
+		response := map[string]interface{}{}
+		var endpoints, functions []map[string]interface{}
+		for i, line := range strings.Split(request.Content, "\n") {
+			fields := strings.Fields(line)
+			switch {
+			case len(fields) == 4 && fields[0] == "rpc":
+				endpoints = append(endpoints, map[string]interface{}{
+					"method": fields[1], "path": fields[2], "handler": fields[3], "line": i + 1,
+				})
+			case len(fields) == 4 && fields[0] == "fn" && strings.Contains(fields[1], "("):
+				name, param, _ := strings.Cut(fields[1], "(")
+				functions = append(functions, map[string]interface{}{
+					"name":       name,
+					"parameters": []map[string]string{{"name": param, "type": strings.TrimSuffix(fields[2], ")")}},
+					"returns":    []map[string]string{{"type": fields[3]}},
+					"line":       i + 1,
+					"visibility": "public",
+				})
+			}
+		}
+		response[request.Action] = endpoints
+		if request.Action == "functions" {
+			response[request.Action] = functions
+		}
+		_ = encoder.Encode(response)
+	}
+	os.Exit(0)
+}
+
+// testPlugin configures the test binary as a detector plugin for .rpc files
+func testPlugin(t *testing.T) Plugin {
+	t.Setenv("NEEV_TEST_PLUGIN", "1")
+	return Plugin{
+		Name:     "rpc",
+		Command:  os.Args[0],
+		Args:     []string{"-test.run=^TestPluginHelperProcess$"},
+		Language: "rpc",
+		Files:    []string{"*.rpc"},
+		Version:  "test",
+	}
+}
+
+func TestPluginDetector_Extract(t *testing.T) {
+	rootDir := t.TempDir()
+	detector := NewPluginDetector(testPlugin(t), rootDir)
+	defer detector.Close()
+
+	file := filepath.Join(rootDir, "api", "users.rpc")
+	if !detector.Detect(file) {
+		t.Fatal("Expected plugin to handle .rpc files")
+	}
+	if detector.Detect(filepath.Join(rootDir, "api", "users.go")) {
+		t.Error("Expected plugin to ignore .go files")
+	}
+	if detector.Language() != "rpc" {
+		t.Errorf("Expected language rpc, got %s", detector.Language())
+	}
+
+	content := []byte("service Users\nrpc GET /users ListUsers\nfn ListUsers(req ListRequest) ListResponse\n")
+	endpoints, err := detector.ExtractEndpoints(file, content)
+	if err != nil {
+		t.Fatalf("ExtractEndpoints failed: %v", err)
+	}
+	if len(endpoints) != 1 || endpoints[0].Method != "GET" || endpoints[0].Path != "/users" ||
+		endpoints[0].Handler != "ListUsers" || endpoints[0].Line != 2 {
+		t.Errorf("Unexpected endpoints: %+v", endpoints)
+	}
+
+	functions, err := detector.ExtractFunctions(file, content)
+	if err != nil {
+		t.Fatalf("ExtractFunctions failed: %v", err)
+	}
+	if len(functions) != 1 || functions[0].Name != "ListUsers" || len(functions[0].Parameters) != 1 ||
+		functions[0].Parameters[0].Type != "ListRequest" || functions[0].Returns[0].Type != "ListResponse" {
+		t.Errorf("Unexpected functions: %+v", functions)
+	}
+
+	// A file the plugin rejects contributes nothing but does not stop the plugin
+	if _, err := detector.ExtractEndpoints(file, []byte("broken")); err == nil {
+		t.Error("Expected an error for a file the plugin cannot parse")
+	}
+	if _, err := detector.ExtractEndpoints(file, content); err != nil {
+		t.Errorf("Expected plugin to keep serving after a rejected file: %v", err)
+	}
+
+	if err := detector.Close(); err != nil {
+		t.Errorf("Close failed: %v", err)
+	}
+}
+
+func TestInspect_Plugins(t *testing.T) {
+	rootDir := t.TempDir()
+	writeFiles(t, rootDir, map[string]string{
+		".neev/foundation/api.md": "# API\n",
+		"api/users.rpc":           "rpc GET /users ListUsers\nrpc POST /users CreateUser\n",
+		"api/main.go":             "package api\n",
+	})
+
+	opts := InspectOptions{
+		RootDir:        rootDir,
+		FoundationPath: filepath.Join(rootDir, ".neev", "foundation"),
+		IgnoreDirs:     map[string]bool{},
+		Depth:          2,
+		Plugins:        []Plugin{testPlugin(t)},
+	}
+	result, err := Inspect(opts)
+	if err != nil {
+		t.Fatalf("Inspect failed: %v", err)
+	}
+	if result.Summary.Languages["rpc"] != 1 || result.Summary.Languages["go"] != 1 {
+		t.Errorf("Expected one rpc and one go file, got %v", result.Summary.Languages)
+	}
+
+	// A plugin that dies fails the inspection instead of hiding endpoints
+	writeFiles(t, rootDir, map[string]string{"api/orders.rpc": "crash\n"})
+	if _, err := Inspect(opts); err == nil || !strings.Contains(err.Error(), "plugin 'rpc'") {
+		t.Errorf("Expected a plugin error, got %v", err)
+	}
+
+	opts.Plugins[0].Command = "./missing-detector"
+	if _, err := Inspect(opts); err == nil {
+		t.Error("Expected an error for a missing plugin executable")
+	}
+}
+
+func TestPluginScan_ReportsEndpoints(t *testing.T) {
+	rootDir := t.TempDir()
+	writeFiles(t, rootDir, map[string]string{
+		"api/users.rpc": "rpc GET /users ListUsers\n",
+	})
+
+	detector := NewPluginDetector(testPlugin(t), rootDir)
+	analyzer := NewPolyglotAnalyzer()
+	analyzer.RegisterDetector(detector)
+	scan, err := analyzer.Scan(rootDir, nil, ScanOptions{Endpoints: true, Functions: true})
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if err := detector.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	if len(scan.Endpoints) != 1 || scan.Endpoints[0].Language != "rpc" ||
+		scan.Endpoints[0].File != filepath.Join(rootDir, "api", "users.rpc") {
+		t.Errorf("Unexpected endpoints: %+v", scan.Endpoints)
+	}
+}
+
+func TestMatchPluginGlob(t *testing.T) {
+	tests := []struct {
+		pattern, path string
+		want          bool
+	}{
+		{"*.rpc", "api/users.rpc", true},
+		{"*.rpc", "users.rpc", true},
+		{"*.rpc", "api/users.go", false},
+		{"services/**/handlers.go", "services/billing/v1/handlers.go", true},
+		{"services/**/handlers.go", "services/handlers.go", true},
+		{"services/**/handlers.go", "lib/handlers.go", false},
+		{"api/*.rpc", "api/v1/users.rpc", false},
+		{"**/*.rpc", "users.rpc", true},
+	}
+	for _, tt := range tests {
+		if got := matchPluginGlob(tt.pattern, tt.path); got != tt.want {
+			t.Errorf("matchPluginGlob(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
@@ -1,9 +1,15 @@
 package inspect
 
 import (
+	"encoding/json"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"reflect"
+	"runtime"
+	"sort"
 	"strings"
+	"sync"
 )
 
 // LanguageDetector defines the interface for language-specific code analysis
@@ -24,11 +30,13 @@ type LanguageDetector interface {
 // FunctionSignature represents a parsed function signature
 type FunctionSignature struct {
 	Name       string
+	Receiver   string // Receiver or owning type for methods, if known
 	Parameters []ParameterSpec
 	Returns    []ReturnSpec
 	File       string
 	Line       int
 	Visibility string // public, private, protected
+	Language   string // Language of the detector that found it
 }
 
 // PolyglotAnalyzer manages multi-language code analysis
@@ -50,125 +58,411 @@ func (pa *PolyglotAnalyzer) RegisterDetector(detector LanguageDetector) {
 	pa.detectors = append(pa.detectors, detector)
 }
 
-// DetectLanguages scans a directory and returns language statistics
-func (pa *PolyglotAnalyzer) DetectLanguages(rootDir string, ignoreDirs map[string]bool) (map[string]int, error) {
-	languages := make(map[string]int)
-	
-	err := filepath.Walk(rootDir, func(path string, info os.FileInfo, err error) error {
+// ScanOptions selects what a repository scan extracts
+type ScanOptions struct {
+	Endpoints  bool                   // Extract HTTP endpoints
+	Functions  bool                   // Extract function signatures
+	Workers    int                    // Files parsed concurrently; defaults to GOMAXPROCS
+	Cache      *AnalysisCache         // Reuse results for unchanged files; nil disables caching
+	Languages  map[string][]string    // Directory -> the only languages analysed below it
+	Extractors []FileExtractor        // Further per-file facts, collected in the same pass
+	Only       func(path string) bool // If set, only these files are extracted; languages count every file
+}
+
+// extracts reports whether a scan extracts anything beyond languages
+func (opts ScanOptions) extracts() bool {
+	return opts.Endpoints || opts.Functions || len(opts.Extractors) > 0
+}
+
+// sameExtraction reports whether two scans select the same extractions, so
+// that one can be updated with Rescan under the options of the other
+func (opts ScanOptions) sameExtraction(other ScanOptions) bool {
+	if opts.Endpoints != other.Endpoints || opts.Functions != other.Functions || len(opts.Extractors) != len(other.Extractors) ||
+		!reflect.DeepEqual(opts.Languages, other.Languages) {
+		return false
+	}
+	for i := range opts.Extractors {
+		if opts.Extractors[i].Name != other.Extractors[i].Name {
+			return false
+		}
+	}
+	return true
+}
+
+// ScanResult holds everything extracted in a single pass over a directory tree
+type ScanResult struct {
+	Languages map[string]int
+	Endpoints []Endpoint
+	Functions []FunctionSignature
+	Facts     map[string][]FileFacts // Extractor name -> facts of the files it matched, in walk order
+
+	files []scannedFile // Per-file results, so that a rescan only extracts changed files
+}
+
+// FileExtractor collects facts from the files it matches during a scan, so
+// that checks beyond endpoints and functions need no walk of their own. Cached
+// facts are reused for files with the same content, so consumers take file
+// paths from FileFacts rather than from the facts.
+type FileExtractor struct {
+	Name    string                                        // Key of the facts in ScanResult.Facts
+	Version string                                        // Bump when extraction changes, to discard cached facts
+	Match   func(path string) bool                        // Files the extractor reads
+	Extract func(path string, content []byte) interface{} // Facts of one file; nil if there are none
+	Decode  func(data []byte) (interface{}, error)        // Reads cached facts back; nil disables caching
+}
+
+// FileFacts are the facts an extractor found in one file
+type FileFacts struct {
+	Path  string
+	Facts interface{}
+}
+
+// decodeFacts decodes cached facts of type T, for FileExtractor.Decode
+func decodeFacts[T any](data []byte) (interface{}, error) {
+	var facts T
+	err := json.Unmarshal(data, &facts)
+	return facts, err
+}
+
+// scannedFile is a source file and what its detector extracted from it
+type scannedFile struct {
+	path      string
+	detector  LanguageDetector
+	endpoints []Endpoint
+	includes  []djangoInclude
+	functions []FunctionSignature
+
+	extractors []int         // Indexes of the ScanOptions.Extractors matching the file
+	facts      []interface{} // Facts of each matching extractor
+
+	content []byte // Content to extract instead of the file on disk, for rescans
+}
+
+// routeMountExtractor is implemented by detectors whose frameworks mount other
+// route files, so mounts can be resolved once the whole tree is scanned
+type routeMountExtractor interface {
+	extractRoutes(filePath string, content []byte) ([]Endpoint, []djangoInclude, error)
+}
+
+// Scan walks a directory once, reads each source file once and feeds it to the
+// first detector that handles it. Files are parsed by a bounded worker pool and
+// results are merged in walk order, so output does not depend on scheduling.
+func (pa *PolyglotAnalyzer) Scan(rootDir string, ignoreDirs map[string]bool, opts ScanOptions) (*ScanResult, error) {
+	var files []scannedFile
+	err := filepath.WalkDir(rootDir, func(path string, entry fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
-		
-		// Skip directories
-		if info.IsDir() {
-			// Skip ignored directories
-			if ignoreDirs[info.Name()] || strings.HasPrefix(info.Name(), ".") {
+
+		// Skip ignored and hidden directories
+		if entry.IsDir() {
+			if path != rootDir && (ignoreDirs[entry.Name()] || strings.HasPrefix(entry.Name(), ".")) {
 				return filepath.SkipDir
 			}
 			return nil
 		}
-		
-		// Check if any detector can handle this file
-		for _, detector := range pa.detectors {
-			if detector.Detect(path) {
-				langName := string(detector.Language())
-				languages[langName]++
-				break
-			}
+
+		if file, ok := pa.scanFile(path, opts); ok {
+			files = append(files, file)
 		}
-		
 		return nil
 	})
-	
-	return languages, err
+	if err != nil {
+		return mergeScan(files, opts), err
+	}
+
+	extractFiles(files, rootDir, opts)
+	return mergeScan(files, opts), nil
 }
 
-// ExtractAllEndpoints finds all HTTP endpoints in a directory
-func (pa *PolyglotAnalyzer) ExtractAllEndpoints(rootDir string, ignoreDirs map[string]bool) ([]Endpoint, error) {
-	var allEndpoints []Endpoint
-	
-	err := filepath.Walk(rootDir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
+// Rescan updates a scan of rootDir after files changed. Changes map absolute
+// paths to their new content, or to nil for removed files; only those files are
+// extracted again, the others keep the results of prev. Options must select
+// the same extractions as those of prev.
+func (pa *PolyglotAnalyzer) Rescan(prev *ScanResult, rootDir string, ignoreDirs map[string]bool, opts ScanOptions, changes map[string][]byte) *ScanResult {
+	var files, updated []scannedFile
+	for _, file := range prev.files {
+		if _, changed := changes[file.path]; !changed {
+			files = append(files, file)
 		}
-		
-		// Skip directories
-		if info.IsDir() {
-			if ignoreDirs[info.Name()] || strings.HasPrefix(info.Name(), ".") {
-				return filepath.SkipDir
+	}
+	for path, content := range changes {
+		if content == nil || !walkedPath(rootDir, ignoreDirs, path) {
+			continue
+		}
+		if file, ok := pa.scanFile(path, opts); ok {
+			file.content = content
+			updated = append(updated, file)
+		}
+	}
+
+	extractFiles(updated, rootDir, opts)
+	files = append(files, updated...)
+	sort.SliceStable(files, func(i, j int) bool { return walkLess(files[i].path, files[j].path) })
+	return mergeScan(files, opts)
+}
+
+// scanFile decides who reads a file: the first detector that handles it, and
+// the extractors that match it. Files that nothing reads, or whose language
+// is not analysed in their directory, are skipped.
+func (pa *PolyglotAnalyzer) scanFile(path string, opts ScanOptions) (scannedFile, bool) {
+	file := scannedFile{path: path}
+	for _, detector := range pa.detectors {
+		if detector.Detect(path) {
+			if !languageAllowed(opts.Languages, path, detector.Language()) {
+				return file, false
 			}
-			return nil
+			file.detector = detector
+			break
 		}
-		
-		// Check if any detector can handle this file
-		for _, detector := range pa.detectors {
-			if detector.Detect(path) {
-				content, err := os.ReadFile(path)
-				if err != nil {
-					continue // Skip files we can't read
-				}
-				
-				endpoints, err := detector.ExtractEndpoints(path, content)
-				if err != nil {
-					continue // Skip files with parsing errors
-				}
-				
-				// Add language info to endpoints
-				for i := range endpoints {
-					endpoints[i].Language = string(detector.Language())
-					endpoints[i].File = path
-				}
-				
-				allEndpoints = append(allEndpoints, endpoints...)
-				break
+	}
+	for index, extractor := range opts.Extractors {
+		if extractor.Match(path) {
+			file.extractors = append(file.extractors, index)
+		}
+	}
+	return file, file.detector != nil || len(file.extractors) > 0
+}
+
+// extractFiles extracts files with a bounded worker pool
+func extractFiles(files []scannedFile, rootDir string, opts ScanOptions) {
+	if !opts.extracts() {
+		return
+	}
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(files) {
+		workers = len(files)
+	}
+
+	// Each worker only writes the slots of the files it receives
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				extractFile(&files[index], rootDir, opts)
+			}
+		}()
+	}
+	for index := range files {
+		jobs <- index
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// mergeScan combines per-file results, in the order of files
+func mergeScan(files []scannedFile, opts ScanOptions) *ScanResult {
+	result := &ScanResult{Languages: make(map[string]int), Facts: make(map[string][]FileFacts), files: files}
+	for _, file := range files {
+		if file.detector != nil {
+			result.Languages[string(file.detector.Language())]++
+		}
+		result.Functions = append(result.Functions, file.functions...)
+		for i, index := range file.extractors {
+			if i < len(file.facts) && file.facts[i] != nil {
+				name := opts.Extractors[index].Name
+				result.Facts[name] = append(result.Facts[name], FileFacts{Path: file.path, Facts: file.facts[i]})
 			}
 		}
-		
-		return nil
-	})
-	
-	return allEndpoints, err
+	}
+	result.Endpoints = resolveDjangoIncludes(files)
+	return result
 }
 
-// ExtractAllFunctions finds all function signatures in a directory
-func (pa *PolyglotAnalyzer) ExtractAllFunctions(rootDir string, ignoreDirs map[string]bool) ([]FunctionSignature, error) {
-	var allFunctions []FunctionSignature
-	
-	err := filepath.Walk(rootDir, func(path string, info os.FileInfo, err error) error {
+// walkedPath reports whether a scan of rootDir visits path, i.e. whether it
+// lies below rootDir outside ignored and hidden directories
+func walkedPath(rootDir string, ignoreDirs map[string]bool, path string) bool {
+	rel, err := filepath.Rel(rootDir, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return false
+	}
+	dirs := strings.Split(filepath.ToSlash(rel), "/")
+	for _, dir := range dirs[:len(dirs)-1] {
+		if ignoreDirs[dir] || strings.HasPrefix(dir, ".") {
+			return false
+		}
+	}
+	return true
+}
+
+// walkLess orders paths the way filepath.WalkDir visits them
+func walkLess(a, b string) bool {
+	as, bs := strings.Split(filepath.ToSlash(a), "/"), strings.Split(filepath.ToSlash(b), "/")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if as[i] != bs[i] {
+			return as[i] < bs[i]
+		}
+	}
+	return len(as) < len(bs)
+}
+
+// extractFile reads a file and runs the requested extractions of its detector
+// and extractors, reusing cached results when the file content is unchanged
+func extractFile(file *scannedFile, rootDir string, opts ScanOptions) {
+	detect := file.detector != nil && (opts.Endpoints || opts.Functions)
+	if !detect && len(file.extractors) == 0 {
+		return
+	}
+	if opts.Only != nil && !opts.Only(file.path) {
+		if opts.Cache != nil {
+			opts.Cache.keep(cacheKey(rootDir, file.path)) // Still present, so not pruned
+		}
+		return
+	}
+	var err error
+	content := file.content
+	if content == nil {
+		if content, err = os.ReadFile(file.path); err != nil {
+			return // Skip files we can't read
+		}
+	}
+	file.content = nil
+
+	var entry cacheEntry
+	var key string
+	changed := false
+	if opts.Cache != nil {
+		key = cacheKey(rootDir, file.path)
+		hash, detector := contentHash(content), ""
+		if file.detector != nil {
+			detector = detectorKey(file.detector)
+		}
+		if cached, ok := opts.Cache.lookup(key, hash, detector); ok {
+			entry = cached
+		} else {
+			entry = cacheEntry{Hash: hash, Detector: detector}
+		}
+	}
+
+	if detect && opts.Endpoints && !entry.HasEndpoints {
+		// Files with parsing errors contribute nothing
+		var endpoints []Endpoint
+		var includes []djangoInclude
+		if mounts, ok := file.detector.(routeMountExtractor); ok {
+			endpoints, includes, err = mounts.extractRoutes(file.path, content)
+		} else {
+			endpoints, err = file.detector.ExtractEndpoints(file.path, content)
+		}
 		if err != nil {
-			return err
+			endpoints, includes = nil, nil
 		}
-		
-		// Skip directories
-		if info.IsDir() {
-			if ignoreDirs[info.Name()] || strings.HasPrefix(info.Name(), ".") {
-				return filepath.SkipDir
-			}
-			return nil
+		entry.Endpoints, entry.Includes, entry.HasEndpoints = endpoints, includes, true
+		changed = true
+	}
+
+	if detect && opts.Functions && !entry.HasFunctions {
+		functions, err := file.detector.ExtractFunctions(file.path, content)
+		if err != nil {
+			functions = nil
 		}
-		
-		// Check if any detector can handle this file
-		for _, detector := range pa.detectors {
-			if detector.Detect(path) {
-				content, err := os.ReadFile(path)
-				if err != nil {
-					continue // Skip files we can't read
-				}
-				
-				functions, err := detector.ExtractFunctions(path, content)
-				if err != nil {
-					continue // Skip files with parsing errors
+		entry.Functions, entry.HasFunctions = functions, true
+		changed = true
+	}
+
+	for _, index := range file.extractors {
+		extractor := opts.Extractors[index]
+		factsKey := extractor.Name + "@" + extractor.Version
+		facts, cached := cachedFacts(entry, factsKey, extractor)
+		if !cached {
+			facts = extractor.Extract(file.path, content)
+			if data, err := json.Marshal(facts); err == nil && opts.Cache != nil && extractor.Decode != nil {
+				// Cached entries are shared, so their facts are never modified in place
+				all := make(map[string]json.RawMessage, len(entry.Facts)+1)
+				for name, value := range entry.Facts {
+					all[name] = value
 				}
-				
-				allFunctions = append(allFunctions, functions...)
-				break
+				all[factsKey] = data
+				entry.Facts = all
+				changed = true
 			}
 		}
-		
-		return nil
-	})
-	
-	return allFunctions, err
+		file.facts = append(file.facts, facts)
+	}
+
+	if changed && opts.Cache != nil {
+		opts.Cache.store(key, entry)
+	}
+
+	// Copy results so cached entries are never modified
+	if detect && opts.Endpoints {
+		for _, endpoint := range entry.Endpoints {
+			endpoint.Language = string(file.detector.Language())
+			endpoint.File = file.path
+			file.endpoints = append(file.endpoints, endpoint)
+		}
+		file.includes = append(file.includes, entry.Includes...)
+	}
+	if detect && opts.Functions {
+		for _, function := range entry.Functions {
+			function.Language = string(file.detector.Language())
+			function.File = file.path
+			file.functions = append(file.functions, function)
+		}
+	}
+}
+
+// cachedFacts returns the facts an extractor found in a cached file, if any
+func cachedFacts(entry cacheEntry, key string, extractor FileExtractor) (interface{}, bool) {
+	data, ok := entry.Facts[key]
+	if !ok || extractor.Decode == nil {
+		return nil, false
+	}
+	if string(data) == "null" {
+		return nil, true // The file has no facts
+	}
+	facts, err := extractor.Decode(data)
+	return facts, err == nil
+}
+
+// languageAllowed reports whether a file of the language is analysed, given
+// the languages declared for the directories containing it
+func languageAllowed(languages map[string][]string, path string, language Language) bool {
+	for dir, allowed := range languages {
+		if !pathContains(dir, path) {
+			continue
+		}
+		found := false
+		for _, name := range allowed {
+			found = found || strings.EqualFold(name, string(language))
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// cacheKey identifies a file in the analysis cache by its path relative to the root
+func cacheKey(rootDir, path string) string {
+	if rel, err := filepath.Rel(rootDir, path); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.ToSlash(path)
+}
+
+// DetectLanguages scans a directory and returns language statistics
+func (pa *PolyglotAnalyzer) DetectLanguages(rootDir string, ignoreDirs map[string]bool) (map[string]int, error) {
+	scan, err := pa.Scan(rootDir, ignoreDirs, ScanOptions{})
+	return scan.Languages, err
+}
+
+// ExtractAllEndpoints finds all HTTP endpoints in a directory
+func (pa *PolyglotAnalyzer) ExtractAllEndpoints(rootDir string, ignoreDirs map[string]bool) ([]Endpoint, error) {
+	scan, err := pa.Scan(rootDir, ignoreDirs, ScanOptions{Endpoints: true})
+	return scan.Endpoints, err
+}
+
+// ExtractAllFunctions finds all function signatures in a directory
+func (pa *PolyglotAnalyzer) ExtractAllFunctions(rootDir string, ignoreDirs map[string]bool) ([]FunctionSignature, error) {
+	scan, err := pa.Scan(rootDir, ignoreDirs, ScanOptions{Functions: true})
+	return scan.Functions, err
 }
 
 // DetectLanguageByExtension is a helper to detect language from file extension
@@ -179,9 +473,9 @@ func DetectLanguageByExtension(filePath string) Language {
 		return LangGo
 	case ".py":
 		return LangPython
-	case ".js":
+	case ".js", ".jsx", ".mjs", ".cjs":
 		return LangJavaScript
-	case ".ts":
+	case ".ts", ".tsx", ".mts", ".cts":
 		return LangTypeScript
 	case ".java":
 		return LangJava
@@ -189,7 +483,58 @@ func DetectLanguageByExtension(filePath string) Language {
 		return LangCSharp
 	case ".rb":
 		return LangRuby
+	case ".kt", ".kts":
+		return LangKotlin
+	case ".rs":
+		return LangRust
+	case ".php":
+		return LangPHP
+	case ".ex", ".exs":
+		return LangElixir
 	default:
 		return ""
 	}
 }
+
+// controllerRoutePath composes a controller prefix and a handler route,
+// e.g. "api/users" and "{id}" into "/api/users/{id}"
+func controllerRoutePath(prefix, path string) string {
+	return "/" + joinRoutePath(strings.Trim(prefix, "/"), strings.Trim(path, "/"))
+}
+
+// prefixStack tracks the route prefixes of nested brace-delimited blocks,
+// e.g. Ktor route("/api") { ... } or Laravel Route::prefix('api')->group(...)
+type prefixStack struct {
+	depth   int
+	entries []prefixEntry
+}
+
+// prefixEntry is the prefix of a block and the brace depth inside it
+type prefixEntry struct {
+	prefix string
+	depth  int
+}
+
+// current returns the combined prefix of the open blocks
+func (s *prefixStack) current() string {
+	if len(s.entries) == 0 {
+		return ""
+	}
+	return s.entries[len(s.entries)-1].prefix
+}
+
+// push opens a block whose brace is on the current line, below the current prefix
+func (s *prefixStack) push(prefix string) {
+	s.entries = append(s.entries, prefixEntry{
+		prefix: "/" + joinRoutePath(strings.Trim(s.current(), "/"), strings.Trim(prefix, "/")),
+		depth:  s.depth + 1,
+	})
+}
+
+// advance counts the braces of a line and closes the blocks that ended
+func (s *prefixStack) advance(line string) {
+	s.depth += strings.Count(line, "{") - strings.Count(line, "}")
+	for len(s.entries) > 0 && s.entries[len(s.entries)-1].depth > s.depth {
+		s.entries = s.entries[:len(s.entries)-1]
+	}
+}
@@ -1,7 +1,12 @@
 package inspect
 
 import (
+	"fmt"
 	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
 )
 
@@ -96,6 +101,111 @@ func CreateUser(ctx context.Context, user *User) (*User, error) {
 	}
 }
 
+func TestGoDetector_ExtractEndpoints_Groups(t *testing.T) {
+	detector := &GoDetector{}
+
+	code := `package main
+
+const usersPath = "/users"
+
+func setupRoutes(r *gin.Engine, mux *http.ServeMux) {
+	api := r.Group("/api")
+	v1 := api.Group("/v1")
+	v1.GET(usersPath,
+		authMiddleware,
+		handlers.ListUsers,
+	)
+	v1.POST("", handlers.Root)
+
+	mux.HandleFunc("GET /items/{id}", getItem)
+	mux.HandleFunc("/health", health)
+
+	cr.Route("/orders", func(r chi.Router) {
+		r.Get("/{id}", getOrder)
+	})
+
+	gr.HandleFunc("/products", listProducts).Methods("GET", http.MethodPost)
+
+	cache.Get("key", &value)
+	client.Post("/webhooks", "application/json", body)
+	http.Post("/events", "text/plain", body)
+}
+`
+
+	endpoints, err := detector.ExtractEndpoints("routes.go", []byte(code))
+	if err != nil {
+		t.Fatalf("ExtractEndpoints failed: %v", err)
+	}
+
+	expected := map[string]string{
+		"GET /api/v1/users": "handlers.ListUsers",
+		"POST /api/v1":      "handlers.Root",
+		"GET /items/{id}":   "getItem",
+		"ANY /health":       "health",
+		"GET /orders/{id}":  "getOrder",
+		"GET /products":     "listProducts",
+		"POST /products":    "listProducts",
+	}
+
+	if len(endpoints) != len(expected) {
+		t.Errorf("Expected %d endpoints, got %d: %+v", len(expected), len(endpoints), endpoints)
+	}
+
+	for _, ep := range endpoints {
+		key := ep.Method + " " + ep.Path
+		handler, ok := expected[key]
+		if !ok {
+			t.Errorf("Unexpected endpoint %s", key)
+			continue
+		}
+		if ep.Handler != handler {
+			t.Errorf("Endpoint %s: handler = %q, want %q", key, ep.Handler, handler)
+		}
+	}
+}
+
+func TestGoDetector_ExtractFunctions_MultilineAndMethods(t *testing.T) {
+	detector := &GoDetector{}
+
+	code := `package users
+
+func NewService(
+	repo Repository,
+	logger *slog.Logger,
+) *Service {
+	return nil
+}
+
+func (s *Service) Create(ctx context.Context, a, b string) (user *User, err error) {
+	return nil, nil
+}
+`
+
+	functions, err := detector.ExtractFunctions("service.go", []byte(code))
+	if err != nil {
+		t.Fatalf("ExtractFunctions failed: %v", err)
+	}
+
+	if len(functions) != 2 {
+		t.Fatalf("Expected 2 functions, got %d", len(functions))
+	}
+
+	if len(functions[0].Parameters) != 2 || functions[0].Parameters[1].Type != "*slog.Logger" {
+		t.Errorf("NewService parameters: got %+v", functions[0].Parameters)
+	}
+
+	create := functions[1]
+	if create.Receiver != "*Service" {
+		t.Errorf("Create receiver: got %q, want *Service", create.Receiver)
+	}
+	if len(create.Parameters) != 3 || create.Parameters[2].Name != "b" || create.Parameters[2].Type != "string" {
+		t.Errorf("Create parameters: got %+v", create.Parameters)
+	}
+	if len(create.Returns) != 2 || create.Returns[0].Name != "user" {
+		t.Errorf("Create returns: got %+v", create.Returns)
+	}
+}
+
 func TestPythonDetector_Detect(t *testing.T) {
 	detector := &PythonDetector{}
 	
@@ -205,6 +315,390 @@ app.delete('/api/users/:id', deleteUser);
 	}
 }
 
+func TestTypeScriptDetector_Detect(t *testing.T) {
+	detector := &TypeScriptDetector{}
+
+	tests := map[string]bool{
+		"users.controller.ts": true,
+		"App.tsx":             true,
+		"server.mts":          true,
+		"types.d.ts":          false,
+		"app.js":              false,
+	}
+
+	for path, expected := range tests {
+		if result := detector.Detect(path); result != expected {
+			t.Errorf("Detect(%q) = %v, want %v", path, result, expected)
+		}
+	}
+}
+
+func TestTypeScriptDetector_ExtractEndpoints_NestJS(t *testing.T) {
+	detector := &TypeScriptDetector{}
+
+	code := `import { Controller, Get, Post, Param, Body, HttpCode } from '@nestjs/common';
+
+@Controller('users')
+export class UsersController {
+  constructor(private readonly usersService: UsersService) {}
+
+  @Get()
+  findAll(): Promise<User[]> {
+    return this.usersService.findAll();
+  }
+
+  @Get(':id')
+  async findOne(@Param('id') id: string): Promise<User> {
+    return this.usersService.findOne(id);
+  }
+
+  @Post()
+  @HttpCode(201)
+  create(@Body() dto: CreateUserDto) {}
+}
+
+@Controller({ path: '/admin/' })
+export class AdminController {
+  @Delete('users/:id') remove(@Param('id') id: string) {}
+}
+`
+
+	endpoints, err := detector.ExtractEndpoints("users.controller.ts", []byte(code))
+	if err != nil {
+		t.Fatalf("ExtractEndpoints failed: %v", err)
+	}
+
+	want := []string{"GET /users findAll", "GET /users/:id findOne", "POST /users create", "DELETE /admin/users/:id remove"}
+	var got []string
+	for _, ep := range endpoints {
+		got = append(got, ep.Method+" "+ep.Path+" "+ep.Handler)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestTypeScriptDetector_ExtractEndpoints_ExpressFastify(t *testing.T) {
+	detector := &TypeScriptDetector{}
+
+	code := `const router = Router();
+router.get('/orders', authenticate, listOrders);
+router.post('/orders', async (req: Request<{}, Order, CreateOrder>, res: Response) => {});
+fastify.get<{ Params: { id: string }; Reply: Array<Order> }>('/orders/:id', getOrder);
+`
+
+	endpoints, err := detector.ExtractEndpoints("orders.ts", []byte(code))
+	if err != nil {
+		t.Fatalf("ExtractEndpoints failed: %v", err)
+	}
+
+	if len(endpoints) != 3 {
+		t.Fatalf("Expected 3 endpoints, got %+v", endpoints)
+	}
+	if endpoints[0].Handler != "listOrders" || endpoints[1].Handler != "" {
+		t.Errorf("Unexpected handlers: %q, %q", endpoints[0].Handler, endpoints[1].Handler)
+	}
+	if endpoints[2].Method != "GET" || endpoints[2].Path != "/orders/:id" || endpoints[2].Handler != "getOrder" {
+		t.Errorf("Fastify endpoint: got %+v", endpoints[2])
+	}
+}
+
+func TestTypeScriptDetector_ExtractFunctions(t *testing.T) {
+	detector := &TypeScriptDetector{}
+
+	code := `export async function findUsers<T extends User>(
+  repo: Repository<T>,
+  filter: Map<string, number> = new Map(),
+  ...tags: string[]
+): Promise<T[]> {
+  return repo.find(filter);
+}
+
+export const toDto = (user: User, map: (u: User) => Dto): Dto => map(user);
+
+export class UsersService {
+  constructor(private readonly repo: Repository<User>) {}
+
+  @Get(':id')
+  async findOne(@Param('id', ParseIntPipe) id: number): Promise<{ user: User } | null> {
+    validate(id);
+    return null;
+  }
+
+  private log(message?: string): void {}
+}
+`
+
+	functions, err := detector.ExtractFunctions("users.service.ts", []byte(code))
+	if err != nil {
+		t.Fatalf("ExtractFunctions failed: %v", err)
+	}
+
+	var names []string
+	for _, fn := range functions {
+		names = append(names, fn.Name)
+	}
+	if want := []string{"findUsers", "toDto", "findOne", "log"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("Expected %v, got %v", want, names)
+	}
+
+	findUsers := functions[0]
+	wantParams := []ParameterSpec{{Name: "repo", Type: "Repository<T>"}, {Name: "filter", Type: "Map<string, number>"}, {Name: "tags", Type: "string[]"}}
+	if !reflect.DeepEqual(findUsers.Parameters, wantParams) {
+		t.Errorf("findUsers parameters: got %+v", findUsers.Parameters)
+	}
+	if len(findUsers.Returns) != 1 || findUsers.Returns[0].Type != "Promise<T[]>" {
+		t.Errorf("findUsers returns: got %+v", findUsers.Returns)
+	}
+
+	if toDto := functions[1]; len(toDto.Parameters) != 2 || toDto.Parameters[1].Type != "(u: User) => Dto" || toDto.Returns[0].Type != "Dto" {
+		t.Errorf("toDto: got %+v", toDto)
+	}
+
+	findOne := functions[2]
+	if findOne.Receiver != "UsersService" || findOne.Line != 15 {
+		t.Errorf("findOne: got receiver %q line %d", findOne.Receiver, findOne.Line)
+	}
+	if len(findOne.Parameters) != 1 || findOne.Parameters[0] != (ParameterSpec{Name: "id", Type: "number"}) {
+		t.Errorf("findOne parameters: got %+v", findOne.Parameters)
+	}
+	if len(findOne.Returns) != 1 || findOne.Returns[0].Type != "Promise<{ user: User } | null>" {
+		t.Errorf("findOne returns: got %+v", findOne.Returns)
+	}
+
+	if log := functions[3]; log.Visibility != "private" || len(log.Returns) != 0 || log.Parameters[0].Name != "message" {
+		t.Errorf("log: got %+v", log)
+	}
+}
+
+func TestJavaDetector_ExtractEndpoints_ClassPrefix(t *testing.T) {
+	detector := &JavaDetector{}
+
+	code := `@RestController
+@RequestMapping("/api/users")
+public class UserController {
+
+    @GetMapping
+    public List<User> list() {
+        return service.findAll();
+    }
+
+    @GetMapping("/{id}")
+    @PreAuthorize("hasRole('USER')")
+    public ResponseEntity<Map<String, User>> get(@PathVariable Long id) {
+        return null;
+    }
+
+    @RequestMapping(value = "/{id}", method = {RequestMethod.PUT, RequestMethod.PATCH})
+    public User update(@PathVariable Long id, @RequestBody User user) {
+        return user;
+    }
+}
+
+@RestController
+public class HealthController {
+    @RequestMapping(path = "/health")
+    public String health() {
+        return "ok";
+    }
+}
+`
+
+	endpoints, err := detector.ExtractEndpoints("UserController.java", []byte(code))
+	if err != nil {
+		t.Fatalf("ExtractEndpoints failed: %v", err)
+	}
+
+	want := []string{
+		"GET /api/users list",
+		"GET /api/users/{id} get",
+		"PUT /api/users/{id} update",
+		"PATCH /api/users/{id} update",
+		"ANY /health health",
+	}
+	var got []string
+	for _, ep := range endpoints {
+		got = append(got, ep.Method+" "+ep.Path+" "+ep.Handler)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestCSharpDetector_ExtractEndpoints_ControllerRoute(t *testing.T) {
+	detector := &CSharpDetector{}
+
+	code := `[ApiController]
+[Route("api/[controller]")]
+public class UsersController : ControllerBase
+{
+    [HttpGet]
+    public async Task<ActionResult<IEnumerable<User>>> GetAll()
+    {
+        return Ok();
+    }
+
+    [HttpGet("{id}"), Authorize]
+    public ActionResult<User> GetById(int id) => Ok();
+
+    [HttpPost]
+    [Route("[action]")]
+    public IActionResult Import([FromBody] ImportRequest request) => Ok();
+
+    [HttpDelete("/admin/users/{id}")]
+    public IActionResult Remove(int id) => Ok();
+}
+`
+
+	endpoints, err := detector.ExtractEndpoints("UsersController.cs", []byte(code))
+	if err != nil {
+		t.Fatalf("ExtractEndpoints failed: %v", err)
+	}
+
+	want := []string{
+		"GET /api/Users GetAll",
+		"GET /api/Users/{id} GetById",
+		"POST /api/Users/Import Import",
+		"DELETE /admin/users/{id} Remove",
+	}
+	var got []string
+	for _, ep := range endpoints {
+		got = append(got, ep.Method+" "+ep.Path+" "+ep.Handler)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestRubyDetector_ExtractEndpoints_Resources(t *testing.T) {
+	detector := &RubyDetector{}
+
+	code := `Rails.application.routes.draw do
+  root 'home#index'
+
+  resources :users, only: [:index, :show] do
+    resources :posts, except: %i[new edit destroy]
+    member do
+      post :activate
+    end
+  end
+
+  namespace :api do
+    resource :profile, only: :show
+    resources :categories, only: :index do
+      collection do
+        get :search
+      end
+    end
+  end
+
+  if Rails.env.development?
+    get '/debug', to: 'debug#show'
+  end
+
+  match 'ping', to: 'health#ping', via: [:get, :head]
+end
+`
+
+	endpoints, err := detector.ExtractEndpoints("config/routes.rb", []byte(code))
+	if err != nil {
+		t.Fatalf("ExtractEndpoints failed: %v", err)
+	}
+
+	want := []string{
+		"GET / home#index",
+		"GET /users users#index",
+		"GET /users/:id users#show",
+		"GET /users/:user_id/posts posts#index",
+		"POST /users/:user_id/posts posts#create",
+		"GET /users/:user_id/posts/:id posts#show",
+		"PATCH /users/:user_id/posts/:id posts#update",
+		"PUT /users/:user_id/posts/:id posts#update",
+		"POST /users/:id/activate users#activate",
+		"GET /api/profile api/profiles#show",
+		"GET /api/categories api/categories#index",
+		"GET /api/categories/search api/categories#search",
+		"GET /debug debug#show",
+		"GET /ping health#ping",
+		"HEAD /ping health#ping",
+	}
+	var got []string
+	for _, ep := range endpoints {
+		got = append(got, ep.Method+" "+ep.Path+" "+ep.Handler)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected:\n%v\ngot:\n%v", strings.Join(want, "\n"), strings.Join(got, "\n"))
+	}
+}
+
+func TestRubyDetector_ExtractEndpoints_AllResourceRoutes(t *testing.T) {
+	detector := &RubyDetector{}
+
+	endpoints, err := detector.ExtractEndpoints("config/routes.rb", []byte("resources :photos\n"))
+	if err != nil {
+		t.Fatalf("ExtractEndpoints failed: %v", err)
+	}
+
+	// Seven actions; update answers both PATCH and PUT
+	if len(endpoints) != 8 {
+		t.Errorf("Expected 8 routes for resources :photos, got %d: %+v", len(endpoints), endpoints)
+	}
+}
+
+func TestPythonDetector_DjangoIncludes(t *testing.T) {
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"mysite/urls.py": `from django.urls import include, path
+
+urlpatterns = [
+    path('admin/', admin.site.urls),
+    path('api/', include('users.urls')),
+    path('', views.home),
+]
+`,
+		"users/urls.py": `from django.urls import include, path, re_path
+
+urlpatterns = [
+    path('users/<int:pk>/', views.user_detail),
+    re_path(r'^users/(?P<slug>[-\w]+)/posts/$', views.user_posts),
+    path('billing/', include(('billing.urls', 'billing'), namespace='billing')),
+]
+`,
+		"billing/urls.py": `urlpatterns = [
+    path('invoices/', views.invoices),
+]
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir, filepath.FromSlash(name))
+		os.MkdirAll(filepath.Dir(path), 0755)
+		os.WriteFile(path, []byte(content), 0644)
+	}
+
+	analyzer := NewPolyglotAnalyzer()
+	analyzer.RegisterDetector(&PythonDetector{})
+	endpoints, err := analyzer.ExtractAllEndpoints(tmpDir, map[string]bool{})
+	if err != nil {
+		t.Fatalf("ExtractAllEndpoints failed: %v", err)
+	}
+
+	var got []string
+	for _, ep := range endpoints {
+		got = append(got, ep.Method+" "+normalizePath(ep.Path))
+	}
+	sort.Strings(got)
+	want := []string{
+		"ANY /",
+		"ANY /admin",
+		"ANY /api/billing/invoices",
+		"ANY /api/users/{pk}",
+		"ANY /api/users/{slug}/posts",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
 func TestPolyglotAnalyzer_DetectLanguages(t *testing.T) {
 	tmpDir := t.TempDir()
 	
@@ -248,3 +742,75 @@ func TestPolyglotAnalyzer_DetectLanguages(t *testing.T) {
 	}
 }
 
+
+func TestPolyglotAnalyzer_ScanDeterministic(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Enough files that several workers are busy at once
+	for i := 0; i < 40; i++ {
+		dir := filepath.Join(tmpDir, fmt.Sprintf("svc%02d", i))
+		os.MkdirAll(dir, 0755)
+		goContent := fmt.Sprintf("package svc\n\nfunc Register(r *gin.Engine) {\n\tr.GET(\"/api/v%d/items\", ListItems)\n}\n\nfunc ListItems%d() {}\n", i, i)
+		os.WriteFile(filepath.Join(dir, "routes.go"), []byte(goContent), 0644)
+		jsContent := fmt.Sprintf("app.get('/api/v%d/health', health);\n", i)
+		os.WriteFile(filepath.Join(dir, "app.js"), []byte(jsContent), 0644)
+	}
+	os.MkdirAll(filepath.Join(tmpDir, "node_modules", "dep"), 0755)
+	os.WriteFile(filepath.Join(tmpDir, "node_modules", "dep", "index.js"), []byte("app.get('/ignored', h);\n"), 0644)
+
+	analyzer := NewPolyglotAnalyzer()
+	analyzer.RegisterDetector(&GoDetector{})
+	analyzer.RegisterDetector(&JavaScriptDetector{})
+
+	ignore := map[string]bool{"node_modules": true}
+	serial, err := analyzer.Scan(tmpDir, ignore, ScanOptions{Endpoints: true, Functions: true, Workers: 1})
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	if serial.Languages["go"] != 40 || serial.Languages["javascript"] != 40 {
+		t.Errorf("Unexpected language counts: %v", serial.Languages)
+	}
+	if len(serial.Endpoints) != 80 {
+		t.Fatalf("Expected 80 endpoints, got %d", len(serial.Endpoints))
+	}
+	if serial.Endpoints[0].Path != "/api/v0/health" || serial.Endpoints[1].Path != "/api/v0/items" {
+		t.Errorf("Expected endpoints in walk order, got %s then %s", serial.Endpoints[0].Path, serial.Endpoints[1].Path)
+	}
+
+	for run := 0; run < 5; run++ {
+		parallel, err := analyzer.Scan(tmpDir, ignore, ScanOptions{Endpoints: true, Functions: true, Workers: 8})
+		if err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+		if !reflect.DeepEqual(serial, parallel) {
+			t.Fatalf("Parallel scan differs from serial scan on run %d", run)
+		}
+	}
+}
+
+func TestPolyglotAnalyzer_ScanLanguagesOnly(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0644)
+
+	analyzer := NewPolyglotAnalyzer()
+	analyzer.RegisterDetector(&GoDetector{})
+
+	scan, err := analyzer.Scan(tmpDir, map[string]bool{}, ScanOptions{})
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if scan.Languages["go"] != 1 || len(scan.Functions) != 0 || len(scan.Endpoints) != 0 {
+		t.Errorf("Expected only language counts, got %+v", scan)
+	}
+}
+
+// scanFacts scans a directory with the given extractors only
+func scanFacts(t *testing.T, rootDir string, ignoreDirs map[string]bool, extractors ...FileExtractor) *ScanResult {
+	t.Helper()
+	scan, err := NewPolyglotAnalyzer().Scan(rootDir, ignoreDirs, ScanOptions{Extractors: extractors})
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	return scan
+}
@@ -1,6 +1,7 @@
 package inspect
 
 import (
+	"path/filepath"
 	"regexp"
 	"strings"
 )
@@ -26,7 +27,14 @@ func (d *PythonDetector) Language() Language {
 // ExtractEndpoints finds HTTP endpoints in Python code
 // Supports: Flask, FastAPI, Django
 func (d *PythonDetector) ExtractEndpoints(filePath string, content []byte) ([]Endpoint, error) {
+	endpoints, _, err := d.extractRoutes(filePath, content)
+	return endpoints, err
+}
+
+// extractRoutes finds HTTP endpoints and the Django urlconfs mounted with include()
+func (d *PythonDetector) extractRoutes(filePath string, content []byte) ([]Endpoint, []djangoInclude, error) {
 	var endpoints []Endpoint
+	var includes []djangoInclude
 	contentStr := string(content)
 	lines := strings.Split(contentStr, "\n")
 	
@@ -38,11 +46,11 @@ func (d *PythonDetector) ExtractEndpoints(filePath string, content []byte) ([]En
 		// FastAPI: @app.get("/path") or @router.post("/path")
 		regexp.MustCompile(`@(app|router)\.(get|post|put|delete|patch|options|head)\s*\(\s*["']([^"']+)["']`),
 		
-		// Django URL patterns: path('api/users', views.list_users)
-		regexp.MustCompile(`path\s*\(\s*["']([^"']+)["']\s*,\s*([^,)]+)`),
+		// Django URL patterns: path('api/users', views.list_users) or re_path(r'^api/users$', ...)
+		regexp.MustCompile(`path\s*\(\s*r?["']([^"']*)["']\s*,\s*([^,)]+)`),
 		
 		// Django URL patterns: url(r'^api/users$', views.list_users)
-		regexp.MustCompile(`url\s*\(\s*r?["']([^"']+)["']\s*,\s*([^,)]+)`),
+		regexp.MustCompile(`url\s*\(\s*r?["']([^"']*)["']\s*,\s*([^,)]+)`),
 	}
 	
 	for lineNum, line := range lines {
@@ -52,6 +60,7 @@ func (d *PythonDetector) ExtractEndpoints(filePath string, content []byte) ([]En
 			matches := pattern.FindStringSubmatch(trimmedLine)
 			if matches != nil {
 				var method, path, handler string
+				django := false // Django routes may be empty: path('', views.home)
 				
 				if strings.Contains(trimmedLine, "@app") || strings.Contains(trimmedLine, "@router") {
 					// Flask/FastAPI decorator
@@ -83,13 +92,28 @@ func (d *PythonDetector) ExtractEndpoints(filePath string, content []byte) ([]En
 				} else if strings.Contains(trimmedLine, "path(") || strings.Contains(trimmedLine, "url(") {
 					// Django URL pattern
 					if len(matches) >= 3 {
-						method = "GET" // Django doesn't specify method in URL patterns
-						path = matches[1]
+						method = "ANY" // Django views answer any method
+						django = true
+						path = djangoRoute(matches[1])
 						handler = strings.TrimSpace(matches[2])
+						
+						// include('users.urls') mounts another urlconf, resolved after scanning
+						if strings.HasPrefix(handler, "include(") {
+							include := djangoIncludePattern.FindStringSubmatch(trimmedLine)
+							if include == nil {
+								break // e.g. include(router.urls)
+							}
+							includes = append(includes, djangoInclude{
+								Path:   path,
+								Module: include[1],
+								Line:   lineNum + 1,
+							})
+							break
+						}
 					}
 				}
 				
-				if method != "" && path != "" {
+				if method != "" && (path != "" || django) {
 					endpoint := Endpoint{
 						Method:   method,
 						Path:     path,
@@ -104,7 +128,126 @@ func (d *PythonDetector) ExtractEndpoints(filePath string, content []byte) ([]En
 		}
 	}
 	
-	return endpoints, nil
+	return endpoints, includes, nil
+}
+
+var (
+	// include('users.urls') or include(('users.urls', 'users'), namespace='users')
+	djangoIncludePattern = regexp.MustCompile(`include\s*\(\s*\(?\s*["']([\w.]+)["']`)
+
+	// Path converters such as <int:pk>
+	djangoConverterPattern = regexp.MustCompile(`<\w+:(\w+)>`)
+
+	// Named regex groups such as (?P<pk>[0-9]+)
+	djangoGroupPattern = regexp.MustCompile(`\(\?P<(\w+)>[^)]*\)`)
+)
+
+// djangoRoute normalizes a path() route or re_path() regex: anchors are
+// dropped and parameters become <name>
+func djangoRoute(route string) string {
+	route = strings.TrimPrefix(route, "^")
+	route = strings.TrimSuffix(route, "$")
+	route = djangoConverterPattern.ReplaceAllString(route, "<$1>")
+	return djangoGroupPattern.ReplaceAllString(route, "<$1>")
+}
+
+// djangoInclude is an include() of another urlconf, mounted at Path. It is
+// only used while scanning and never reported.
+type djangoInclude struct {
+	Path   string `json:"path"`
+	Module string `json:"module"` // Dotted module of the included urlconf
+	Line   int    `json:"line"`
+}
+
+// resolveDjangoIncludes merges the endpoints of the scanned files, mounting
+// urlconfs referenced by include() below the route of the include, recursively.
+// Included urlconfs are only reported through their mounts, so their routes
+// carry the full prefix.
+func resolveDjangoIncludes(scanned []scannedFile) []Endpoint {
+	var endpoints []Endpoint
+	byFile := make(map[string]*scannedFile)
+	var files []string
+	hasIncludes := false
+	for i := range scanned {
+		file := &scanned[i]
+		endpoints = append(endpoints, file.endpoints...)
+		if len(file.endpoints) > 0 || len(file.includes) > 0 {
+			byFile[file.path] = file
+			files = append(files, file.path)
+		}
+		hasIncludes = hasIncludes || len(file.includes) > 0
+	}
+	if !hasIncludes {
+		return endpoints
+	}
+
+	// Resolve each include to the urlconf file it names
+	targets := make(map[string]string) // File and module -> included file
+	included := make(map[string]bool)
+	for _, file := range files {
+		for _, inc := range byFile[file].includes {
+			if target := djangoModuleFile(inc.Module, file, files); target != "" {
+				targets[file+"\x00"+inc.Module] = target
+				included[target] = true
+			}
+		}
+	}
+
+	var resolved []Endpoint
+	var mount func(file, prefix string, visiting map[string]bool)
+	mount = func(file, prefix string, visiting map[string]bool) {
+		if visiting[file] {
+			return // Circular include
+		}
+		visiting[file] = true
+		defer delete(visiting, file)
+
+		// Endpoints and includes are both in line order; keep them interleaved
+		eps, incs := byFile[file].endpoints, byFile[file].includes
+		for len(eps) > 0 || len(incs) > 0 {
+			if len(incs) == 0 || (len(eps) > 0 && eps[0].Line < incs[0].Line) {
+				ep := eps[0]
+				ep.Path = prefix + ep.Path
+				resolved = append(resolved, ep)
+				eps = eps[1:]
+				continue
+			}
+			if target, ok := targets[file+"\x00"+incs[0].Module]; ok {
+				mount(target, prefix+incs[0].Path, visiting)
+			}
+			incs = incs[1:]
+		}
+	}
+
+	for _, file := range files {
+		if !included[file] {
+			mount(file, "", make(map[string]bool))
+		}
+	}
+	return resolved
+}
+
+// djangoModuleFile finds the file of a dotted urlconf module among the scanned
+// files, preferring the one closest to the including file
+func djangoModuleFile(module, from string, files []string) string {
+	suffix := "/" + strings.ReplaceAll(module, ".", "/") + ".py"
+	fromDir := filepath.ToSlash(filepath.Dir(from))
+
+	best, bestShared := "", -1
+	for _, file := range files {
+		slashed := filepath.ToSlash(file)
+		if !strings.HasSuffix("/"+slashed, suffix) || file == from {
+			continue
+		}
+		shared := 0
+		for shared < len(fromDir) && shared < len(slashed) && fromDir[shared] == slashed[shared] {
+			shared++
+		}
+		if shared > bestShared {
+			best, bestShared = file, shared
+		}
+	}
+	return best
 }
 
 // ExtractFunctions finds function signatures in Python code
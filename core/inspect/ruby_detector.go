@@ -24,88 +24,254 @@ func (d *RubyDetector) Language() Language {
 }
 
 // ExtractEndpoints finds HTTP endpoints in Ruby code
-// Supports: Rails routes, Sinatra routes
+// Supports: Rails routes (resources, namespaces, scopes and nesting), Sinatra routes
 func (d *RubyDetector) ExtractEndpoints(filePath string, content []byte) ([]Endpoint, error) {
 	var endpoints []Endpoint
 	contentStr := string(content)
 	lines := strings.Split(contentStr, "\n")
 	
-	// Patterns for different Ruby web frameworks
-	patterns := []*regexp.Regexp{
-		// Rails routes: get '/api/users', to: 'users#index'
-		regexp.MustCompile(`(get|post|put|delete|patch)\s+['"]([^'"]+)['"],?\s*(?:to:\s*['"]([^'"]+)['"])?`),
-		
-		// Sinatra: get '/api/users' do
-		regexp.MustCompile(`^(get|post|put|delete|patch)\s+['"]([^'"]+)['"]\s+do`),
-		
-		// Rails resources: resources :users
-		regexp.MustCompile(`resources\s+:(\w+)`),
-	}
+	// Scopes of the open blocks; every "do", "if", "def", ... is closed by an "end"
+	stack := []railsScope{{}}
 	
 	for lineNum, line := range lines {
 		trimmedLine := strings.TrimSpace(line)
+		if trimmedLine == "" || strings.HasPrefix(trimmedLine, "#") {
+			continue
+		}
 		
-		for _, pattern := range patterns {
-			matches := pattern.FindStringSubmatch(trimmedLine)
-			if matches != nil {
-				method := strings.ToUpper(matches[1])
-				path := matches[2]
-				handler := ""
-				
-				if len(matches) > 3 && matches[3] != "" {
-					handler = matches[3]
-				}
-				
-				// Handle resources shorthand
-				if strings.Contains(trimmedLine, "resources") {
-					// Rails resources create standard RESTful routes
-					resourceMatch := regexp.MustCompile(`resources\s+:(\w+)`).FindStringSubmatch(trimmedLine)
-					if resourceMatch != nil {
-						resource := resourceMatch[1]
-						// We'll just record the basic ones
-						restMethods := []struct {
-							method string
-							path   string
-							action string
-						}{
-							{"GET", "/" + resource, "index"},
-							{"POST", "/" + resource, "create"},
-							{"GET", "/" + resource + "/:id", "show"},
-							{"PUT", "/" + resource + "/:id", "update"},
-							{"DELETE", "/" + resource + "/:id", "destroy"},
-						}
-						
-						for _, rm := range restMethods {
-							endpoint := Endpoint{
-								Method:   rm.method,
-								Path:     rm.path,
-								Handler:  resource + "#" + rm.action,
-								File:     filePath,
-								Line:     lineNum + 1,
-								Language: string(LangRuby),
-							}
-							endpoints = append(endpoints, endpoint)
-						}
-						continue
-					}
-				}
-				
-				endpoint := Endpoint{
-					Method:   method,
-					Path:     path,
-					Handler:  handler,
-					File:     filePath,
-					Line:     lineNum + 1,
-					Language: string(LangRuby),
+		scope := stack[len(stack)-1]
+		nested := scope // Scope of a block opened by this line
+		
+		emit := func(method, path, handler string) {
+			endpoints = append(endpoints, Endpoint{
+				Method:   method,
+				Path:     path,
+				Handler:  handler,
+				File:     filePath,
+				Line:     lineNum + 1,
+				Language: string(LangRuby),
+			})
+		}
+		
+		if rubyEndPattern.MatchString(trimmedLine) {
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+			continue
+		}
+		
+		if match := railsResourcesPattern.FindStringSubmatch(trimmedLine); match != nil {
+			// resources :users, only: [:index, :show] do
+			singular := match[1] == "resource"
+			names := railsSymbolPattern.FindAllStringSubmatch(match[2], -1)
+			options := match[3]
+			for _, name := range names {
+				nested = scope.expandResource(name[1], singular, options, emit)
+			}
+		} else if match := railsNamespacePattern.FindStringSubmatch(trimmedLine); match != nil {
+			nested.path = scope.path + "/" + match[1]
+			nested.module = scope.module + match[1] + "/"
+		} else if strings.HasPrefix(trimmedLine, "scope ") || strings.HasPrefix(trimmedLine, "scope(") {
+			if match := railsScopePathPattern.FindStringSubmatch(trimmedLine); match != nil {
+				nested.path = scope.path + "/" + strings.Trim(match[1]+match[2], "/")
+			}
+			if match := railsModuleOptionPattern.FindStringSubmatch(trimmedLine); match != nil {
+				nested.module = scope.module + match[1] + "/"
+			}
+		} else if match := railsMemberPattern.FindStringSubmatch(trimmedLine); match != nil {
+			nested.path = scope.memberPath
+			if match[1] == "collection" {
+				nested.path = scope.collectionPath
+			}
+		} else if match := rubyVerbPattern.FindStringSubmatch(trimmedLine); match != nil {
+			// get 'photos/:id', to: 'photos#show' / get :search / Sinatra get '/x' do
+			path := strings.Trim(match[3], "/")
+			handler := ""
+			if match[2] != "" {
+				path = match[2]
+				if scope.controller != "" {
+					handler = scope.controller + "#" + match[2]
 				}
-				endpoints = append(endpoints, endpoint)
 			}
+			if target := railsTargetPattern.FindStringSubmatch(match[4]); target != nil {
+				handler = scope.module + target[1]
+			}
+			
+			methods := []string{strings.ToUpper(match[1])}
+			if match[1] == "match" {
+				methods = railsViaMethods(match[4])
+			}
+			for _, method := range methods {
+				emit(method, scope.path+"/"+path, handler)
+			}
+		} else if match := railsRootPattern.FindStringSubmatch(trimmedLine); match != nil {
+			path := scope.path
+			if path == "" {
+				path = "/"
+			}
+			emit("GET", path, scope.module+match[1])
+		}
+		
+		opensKeywordBlock := rubyKeywordBlockPattern.MatchString(trimmedLine) && !rubyOneLinerPattern.MatchString(trimmedLine)
+		if rubyBlockPattern.MatchString(trimmedLine) || opensKeywordBlock {
+			stack = append(stack, nested)
 		}
 	}
 	
 	return endpoints, nil
 }
 
+// railsScope is the routing context of a block in config/routes.rb
+type railsScope struct {
+	path           string // Path prefix, e.g. "/api/users/:user_id"
+	module         string // Controller namespace, e.g. "api/"
+	controller     string // Controller of member and collection routes, e.g. "api/users"
+	memberPath     string // Path of member routes, e.g. "/api/users/:id"
+	collectionPath string // Path of collection routes, e.g. "/api/users"
+}
+
+var (
+	rubyEndPattern          = regexp.MustCompile(`^end\b`)
+	rubyBlockPattern        = regexp.MustCompile(`\bdo\s*(\|[^|]*\|)?\s*$`)
+	rubyKeywordBlockPattern = regexp.MustCompile(`^(if|unless|case|while|until|begin|def|class|module)\b`)
+	rubyOneLinerPattern     = regexp.MustCompile(`[;\s]end$`)
+	rubyVerbPattern         = regexp.MustCompile(`^(get|post|put|patch|delete|match)\s*\(?\s*(?::(\w+)|['"]([^'"]*)['"])(.*)$`)
+	
+	railsResourcesPattern = regexp.MustCompile(`^(resources?)\s*\(?\s*((?::\w+\s*,\s*)*:\w+)(.*)$`)
+	railsNamespacePattern = regexp.MustCompile(`^namespace\s*\(?\s*:(\w+)`)
+	railsScopePathPattern = regexp.MustCompile(`^scope\s*\(?\s*(?:['"]([^'"]+)['"]|.*\bpath:\s*['"]([^'"]+)['"])`)
+	railsMemberPattern    = regexp.MustCompile(`^(member|collection)\s+do\b`)
+	railsRootPattern      = regexp.MustCompile(`^root\s*\(?\s*(?:to:\s*)?['"]([^'"]+)['"]`)
+	railsTargetPattern    = regexp.MustCompile(`(?:\bto:|=>)\s*['"]([\w/]+#\w+)['"]`)
+	railsSymbolPattern    = regexp.MustCompile(`:(\w+)`)
+	railsWordPattern      = regexp.MustCompile(`\w+`)
+
+	// Symbol or string options such as controller: 'images'
+	railsModuleOptionPattern     = regexp.MustCompile(`\bmodule:\s*['":]?(\w[\w/]*)`)
+	railsPathOptionPattern       = regexp.MustCompile(`\bpath:\s*['":]?(\w[\w/]*)`)
+	railsControllerOptionPattern = regexp.MustCompile(`\bcontroller:\s*['":]?(\w[\w/]*)`)
+	railsParamOptionPattern      = regexp.MustCompile(`\bparam:\s*['":]?(\w[\w/]*)`)
+
+	// Action lists such as only: [:index, :show] and methods such as via: [:get, :post]
+	railsOnlyOptionPattern   = regexp.MustCompile(`\bonly:\s*(\[[^\]]*\]|%i\[[^\]]*\]|:\w+)`)
+	railsExceptOptionPattern = regexp.MustCompile(`\bexcept:\s*(\[[^\]]*\]|%i\[[^\]]*\]|:\w+)`)
+	railsViaOptionPattern    = regexp.MustCompile(`\bvia:\s*(\[[^\]]*\]|:\w+)`)
+)
+
+// railsActions are the routes generated by resources, in the order Rails lists them
+var railsActions = []struct {
+	action string
+	method string
+	member bool   // Route on a single record (/:id)
+	suffix string // Path after the resource or record
+}{
+	{"index", "GET", false, ""},
+	{"create", "POST", false, ""},
+	{"new", "GET", false, "/new"},
+	{"edit", "GET", true, "/edit"},
+	{"show", "GET", true, ""},
+	{"update", "PATCH", true, ""},
+	{"update", "PUT", true, ""},
+	{"destroy", "DELETE", true, ""},
+}
+
+// expandResource emits the routes of resources :name (or resource :name when
+// singular), honouring only:, except:, path:, controller: and param:, and
+// returns the scope of a nested block
+func (s railsScope) expandResource(name string, singular bool, options string, emit func(method, path, handler string)) railsScope {
+	segment := name
+	if match := railsPathOptionPattern.FindStringSubmatch(options); match != nil {
+		segment = match[1]
+	}
+	controller := name
+	if singular {
+		controller = name + "s" // Singular resources still use plural controllers
+	}
+	if match := railsControllerOptionPattern.FindStringSubmatch(options); match != nil {
+		controller = match[1]
+	}
+	controller = s.module + controller
+	param := "id"
+	if match := railsParamOptionPattern.FindStringSubmatch(options); match != nil {
+		param = match[1]
+	}
+	
+	collectionPath := s.path + "/" + segment
+	memberPath := collectionPath + "/:" + param
+	if singular {
+		memberPath = collectionPath
+	}
+	
+	only := railsActionList(options, railsOnlyOptionPattern)
+	except := railsActionList(options, railsExceptOptionPattern)
+	for _, route := range railsActions {
+		if singular && route.action == "index" {
+			continue
+		}
+		if (only != nil && !only[route.action]) || except[route.action] {
+			continue
+		}
+		path := collectionPath + route.suffix
+		if route.member {
+			path = memberPath + route.suffix
+		}
+		emit(route.method, path, controller+"#"+route.action)
+	}
+	
+	// Nested resources hang below the parent record: /users/:user_id/posts
+	nested := s
+	nested.controller = controller
+	nested.collectionPath = collectionPath
+	nested.memberPath = memberPath
+	nested.path = collectionPath
+	if !singular {
+		nested.path = collectionPath + "/:" + singularize(name) + "_id"
+	}
+	return nested
+}
+
+// railsActionList parses an only: or except: option into a set of actions,
+// or returns nil if the option is absent
+func railsActionList(options string, option *regexp.Regexp) map[string]bool {
+	match := option.FindStringSubmatch(options)
+	if match == nil {
+		return nil
+	}
+	actions := make(map[string]bool)
+	for _, word := range railsWordPattern.FindAllString(strings.TrimPrefix(match[1], "%i"), -1) {
+		actions[word] = true
+	}
+	return actions
+}
+
+// railsViaMethods returns the methods of a match route's via: option
+func railsViaMethods(options string) []string {
+	match := railsViaOptionPattern.FindStringSubmatch(options)
+	if match == nil || strings.Contains(match[1], "all") {
+		return []string{"ANY"}
+	}
+	var methods []string
+	for _, word := range railsWordPattern.FindAllString(match[1], -1) {
+		methods = append(methods, strings.ToUpper(word))
+	}
+	return methods
+}
+
+// singularize returns the singular of a plural resource name, as Rails
+// derives nested parameter names: users -> user, categories -> category
+func singularize(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies"):
+		return strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "sses"), strings.HasSuffix(name, "xes"),
+		strings.HasSuffix(name, "ches"), strings.HasSuffix(name, "shes"):
+		return strings.TrimSuffix(name, "es")
+	case strings.HasSuffix(name, "s") && !strings.HasSuffix(name, "ss"):
+		return strings.TrimSuffix(name, "s")
+	}
+	return name
+}
+
 // ExtractFunctions finds function signatures in Ruby code
 func (d *RubyDetector) ExtractFunctions(filePath string, content []byte) ([]FunctionSignature, error) {
 	var functions []FunctionSignature
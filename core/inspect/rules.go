@@ -0,0 +1,117 @@
+package inspect
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Severity levels, from least to most severe
+const (
+	SeverityInfo    = "info"
+	SeverityWarning = "warning"
+	SeverityError   = "error"
+)
+
+// Rule overrides how a warning type is reported. Rules are applied in order
+// and the last matching rule wins.
+type Rule struct {
+	Type     WarningType `yaml:"type"`               // Warning type, or "*" for every type
+	Severity string      `yaml:"severity,omitempty"` // error, warning or info
+	Disabled bool        `yaml:"disabled,omitempty"` // Drop matching warnings entirely
+	Modules  []string    `yaml:"modules,omitempty"`  // Module globs the rule is scoped to; empty means all modules
+}
+
+// Validate checks that the rule is well-formed
+func (r Rule) Validate() error {
+	if r.Type == "" {
+		return fmt.Errorf("inspect rule type cannot be empty")
+	}
+	if r.Type != "*" && !r.Type.Known() {
+		return fmt.Errorf("unknown warning type '%s' for inspect rule", r.Type)
+	}
+	if r.Severity != "" && SeverityRank(r.Severity) < 0 {
+		return fmt.Errorf("invalid severity '%s' for inspect rule %s (expected error, warning or info)", r.Severity, r.Type)
+	}
+	for _, pattern := range r.Modules {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid module glob '%s' for inspect rule %s: %w", pattern, r.Type, err)
+		}
+	}
+	return nil
+}
+
+// matches reports whether the rule applies to the warning
+func (r Rule) matches(w Warning) bool {
+	if r.Type != "*" && r.Type != w.Type {
+		return false
+	}
+	if len(r.Modules) == 0 {
+		return true
+	}
+	for _, pattern := range r.Modules {
+		if matched, err := path.Match(pattern, w.Module); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// applyRules returns the warnings left after disabling and re-grading them by rules
+func applyRules(warnings []Warning, rules []Rule) []Warning {
+	if len(rules) == 0 {
+		return warnings
+	}
+
+	remaining := []Warning{}
+	for _, w := range warnings {
+		disabled := false
+		for _, rule := range rules {
+			if !rule.matches(w) {
+				continue
+			}
+			disabled = rule.Disabled
+			if rule.Severity != "" {
+				w.Severity = strings.ToLower(rule.Severity)
+			}
+		}
+		if !disabled {
+			remaining = append(remaining, w)
+		}
+	}
+
+	return remaining
+}
+
+// SeverityRank orders severities: info=0, warning=1, error=2, unknown=-1
+func SeverityRank(severity string) int {
+	switch strings.ToLower(severity) {
+	case SeverityInfo:
+		return 0
+	case SeverityWarning:
+		return 1
+	case SeverityError:
+		return 2
+	default:
+		return -1
+	}
+}
+
+// ExceedsThreshold reports whether any warning is at least as severe as threshold.
+// An empty threshold means any warning at all. Pre-existing warnings of a
+// --since inspection never count, so PR gates only fail on new drift.
+func (r *InspectResult) ExceedsThreshold(threshold string) bool {
+	if threshold == "" {
+		threshold = SeverityInfo
+	}
+	minRank := SeverityRank(threshold)
+	for _, w := range r.Warnings {
+		if w.Change == ChangePreexisting {
+			continue
+		}
+		if SeverityRank(w.Severity) >= minRank {
+			return true
+		}
+	}
+	return false
+}
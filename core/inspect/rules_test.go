@@ -0,0 +1,72 @@
+package inspect
+
+import "testing"
+
+func TestApplyRules(t *testing.T) {
+	warnings := []Warning{
+		{Type: WarningExtraCode, Module: "legacy-billing", Severity: "info"},
+		{Type: WarningExtraCode, Module: "orders", Severity: "info"},
+		{Type: WarningMissingModule, Module: "auth", Severity: "warning"},
+		{Type: WarningMissingEndpoint, Module: "api", Severity: "error"},
+	}
+
+	rules := []Rule{
+		{Type: WarningExtraCode, Severity: "error"},
+		{Type: WarningExtraCode, Disabled: true, Modules: []string{"legacy-*"}},
+		{Type: "*", Severity: "info", Modules: []string{"api"}},
+	}
+
+	got := applyRules(warnings, rules)
+
+	if len(got) != 3 {
+		t.Fatalf("Expected 3 warnings after disabling legacy-*, got %d", len(got))
+	}
+	if got[0].Module != "orders" || got[0].Severity != "error" {
+		t.Errorf("Expected orders EXTRA_CODE raised to error, got %+v", got[0])
+	}
+	if got[1].Severity != "warning" {
+		t.Errorf("Expected MISSING_MODULE unchanged, got %s", got[1].Severity)
+	}
+	if got[2].Severity != "info" {
+		t.Errorf("Expected api warning lowered to info, got %s", got[2].Severity)
+	}
+}
+
+func TestInspectResult_ExceedsThreshold(t *testing.T) {
+	result := &InspectResult{Warnings: []Warning{{Severity: "warning"}}}
+
+	tests := map[string]bool{
+		"":        true,
+		"info":    true,
+		"warning": true,
+		"error":   false,
+	}
+
+	for threshold, want := range tests {
+		if got := result.ExceedsThreshold(threshold); got != want {
+			t.Errorf("ExceedsThreshold(%q) = %v, want %v", threshold, got, want)
+		}
+	}
+
+	empty := &InspectResult{}
+	if empty.ExceedsThreshold("info") {
+		t.Errorf("Expected no threshold breach without warnings")
+	}
+}
+
+func TestRule_Validate(t *testing.T) {
+	valid := []Rule{
+		{Type: WarningExtraCode, Severity: "error"},
+		{Type: WarningDependencyVersion, Disabled: true},
+		{Type: "*", Severity: "info"},
+	}
+	for _, rule := range valid {
+		if err := rule.Validate(); err != nil {
+			t.Errorf("Expected %s to be valid, got %v", rule.Type, err)
+		}
+	}
+
+	if err := (Rule{Type: "EXTRA_COD", Severity: "error"}).Validate(); err == nil {
+		t.Errorf("Expected an error for an unknown warning type")
+	}
+}
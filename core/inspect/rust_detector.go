@@ -0,0 +1,406 @@
+package inspect
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// RustDetector handles Rust language analysis
+type RustDetector struct{}
+
+// NewRustDetector creates a new Rust language detector
+func NewRustDetector() *RustDetector {
+	return &RustDetector{}
+}
+
+// Detect returns true if this is a Rust file
+func (d *RustDetector) Detect(filePath string) bool {
+	return strings.HasSuffix(strings.ToLower(filePath), ".rs")
+}
+
+// Language returns the language identifier
+func (d *RustDetector) Language() Language {
+	return LangRust
+}
+
+var (
+	// axum and actix: .route("/path", ...), .nest("/api", ...), web::scope("/api"), web::resource("/path")
+	rustRoutePattern    = regexp.MustCompile(`\.route\s*\(\s*"([^"]*)"\s*,`)
+	rustNestPattern     = regexp.MustCompile(`\.nest\s*\(\s*"([^"]*)"\s*,\s*(?:(\w+)\s*(\(\s*\))?\s*\))?`)
+	rustScopePattern    = regexp.MustCompile(`\bweb::scope\s*\(\s*"([^"]*)"`)
+	rustResourcePattern = regexp.MustCompile(`\bweb::resource\s*\(\s*"([^"]*)"`)
+	rustServicePattern  = regexp.MustCompile(`\.service\s*\(\s*([\w:]+)\s*\)`)
+
+	// Method routers: get(handler).post(other) and get(|| async { ... }) in axum,
+	// web::get().to(handler) in actix
+	rustMethodRouterPattern = regexp.MustCompile(`\b(get|post|put|patch|delete|head|options|any)\s*\(\s*\)\s*\.to\s*\(\s*([\w:]+)` +
+		`|\b(get|post|put|patch|delete|head|options|any)\s*\(\s*([\w:]+)\s*\)` +
+		`|\b(get|post|put|patch|delete|head|options|any)\s*\(\s*(?:move\s+)?(?:async\s+)?\|`)
+
+	// actix attribute macros: #[get("/path")] and #[route("/path", method = "GET")]
+	rustAttributePattern   = regexp.MustCompile(`^#\[(get|post|put|patch|delete|head|options|route)\s*\(\s*"([^"]*)"(.*)\]`)
+	rustRouteMethodPattern = regexp.MustCompile(`method\s*=\s*"(\w+)"`)
+
+	rustFnPattern   = regexp.MustCompile(`^((?:pub(?:\s*\([^)]*\))?\s+)?)(?:(?:default|const|async|unsafe|extern\s+"[^"]*")\s+)*fn\s+(\w+)\s*(?:<[^(]*>)?\s*\(`)
+	rustImplPattern = regexp.MustCompile(`^impl\b(?:\s*<[^{]*?>)?\s+(?:[\w:]+(?:<[^{]*?>)?\s+for\s+)?(?:[\w]+::)*(\w+)`)
+)
+
+// rustSpan is a region of source with the route prefix it applies
+type rustSpan struct {
+	start, end int
+	prefix     string
+}
+
+// ExtractEndpoints finds HTTP endpoints in Rust code
+// Supports: axum Router (route, nest), actix-web attribute macros, scopes and resources
+func (d *RustDetector) ExtractEndpoints(filePath string, content []byte) ([]Endpoint, error) {
+	var endpoints []Endpoint
+	text := maskLifetimes(string(content))
+
+	// Prefixes of nested routers and scopes
+	var spans []rustSpan
+	for _, match := range rustNestPattern.FindAllStringSubmatchIndex(text, -1) {
+		prefix := text[match[2]:match[3]]
+		if match[4] >= 0 {
+			// A router built elsewhere in the file: let api = ... or fn api() -> Router
+			if span, ok := rustRouterDefinition(text, text[match[4]:match[5]], match[6] >= 0); ok {
+				span.prefix = prefix
+				spans = append(spans, span)
+			}
+			continue
+		}
+		open := strings.Index(text[match[0]:], "(") + match[0]
+		if closeIdx := matchingParen(text, open); closeIdx >= 0 {
+			spans = append(spans, rustSpan{start: match[0], end: closeIdx, prefix: prefix})
+		}
+	}
+	for _, match := range rustScopePattern.FindAllStringSubmatchIndex(text, -1) {
+		spans = append(spans, rustSpan{start: match[0], end: rustChainEnd(text, match[0]), prefix: text[match[2]:match[3]]})
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+	prefixAt := func(pos int) string {
+		prefix := ""
+		for _, span := range spans {
+			if pos > span.start && pos < span.end {
+				prefix = joinRoutePath(strings.Trim(prefix, "/"), strings.Trim(span.prefix, "/"))
+			}
+		}
+		return prefix
+	}
+
+	emit := func(pos int, method, path, handler string) {
+		endpoints = append(endpoints, Endpoint{
+			Method:   method,
+			Path:     controllerRoutePath(prefixAt(pos), path),
+			Handler:  handler,
+			File:     filePath,
+			Line:     strings.Count(text[:pos], "\n") + 1,
+			Language: string(LangRust),
+		})
+	}
+
+	// .route("/path", get(h).post(h2)) and .route("/path", web::get().to(h))
+	for _, match := range rustRoutePattern.FindAllStringSubmatchIndex(text, -1) {
+		open := strings.Index(text[match[0]:], "(") + match[0]
+		closeIdx := matchingParen(text, open)
+		if closeIdx < 0 {
+			continue
+		}
+		for _, router := range rustMethodRouters(text[match[1]:closeIdx]) {
+			emit(match[0], router[0], text[match[2]:match[3]], router[1])
+		}
+	}
+
+	// web::resource("/path").route(web::get().to(h))
+	for _, match := range rustResourcePattern.FindAllStringSubmatchIndex(text, -1) {
+		end := rustChainEnd(text, match[0])
+		for _, router := range rustMethodRouters(text[match[1]:end]) {
+			emit(match[0], router[0], text[match[2]:match[3]], router[1])
+		}
+	}
+
+	// Attribute-routed handlers take the prefix of the scope they are registered in
+	servicePrefixes := make(map[string]string)
+	for _, match := range rustServicePattern.FindAllStringSubmatchIndex(text, -1) {
+		if prefix := prefixAt(match[0]); prefix != "" {
+			servicePrefixes[text[match[2]:match[3]]] = prefix
+		}
+	}
+
+	lines := strings.Split(text, "\n")
+	var pending []string
+	pendingPath := ""
+	pendingLine := 0
+	for lineNum, line := range lines {
+		trimmedLine := strings.TrimSpace(line)
+
+		if match := rustAttributePattern.FindStringSubmatch(trimmedLine); match != nil {
+			pendingPath = match[2]
+			pendingLine = lineNum + 1
+			pending = nil
+			if match[1] == "route" {
+				for _, method := range rustRouteMethodPattern.FindAllStringSubmatch(match[3], -1) {
+					pending = append(pending, strings.ToUpper(method[1]))
+				}
+			} else {
+				pending = []string{strings.ToUpper(match[1])}
+			}
+			continue
+		}
+
+		if match := rustFnPattern.FindStringSubmatch(trimmedLine); match != nil && pending != nil {
+			for _, method := range pending {
+				endpoints = append(endpoints, Endpoint{
+					Method:   method,
+					Path:     controllerRoutePath(servicePrefixes[match[2]], pendingPath),
+					Handler:  match[2],
+					File:     filePath,
+					Line:     pendingLine,
+					Language: string(LangRust),
+				})
+			}
+			pending = nil
+		}
+	}
+
+	// Report routes in source order
+	sort.SliceStable(endpoints, func(i, j int) bool { return endpoints[i].Line < endpoints[j].Line })
+	return endpoints, nil
+}
+
+// rustMethodRouters returns the method and handler of each method router in
+// the arguments of a route registration
+func rustMethodRouters(args string) [][2]string {
+	var routers [][2]string
+	for _, match := range rustMethodRouterPattern.FindAllStringSubmatchIndex(args, -1) {
+		// Chained method routers follow a call; other method calls are not routers
+		before := strings.TrimRight(args[:match[0]], " \t\r\n")
+		if strings.HasSuffix(before, ".") && !strings.HasSuffix(strings.TrimRight(strings.TrimSuffix(before, "."), " \t\r\n"), ")") {
+			continue
+		}
+
+		method, handler := "", ""
+		for group := 2; group < len(match); group += 4 {
+			if match[group] >= 0 {
+				method = args[match[group]:match[group+1]]
+				if group+2 < len(match) && match[group+2] >= 0 {
+					handler = args[match[group+2]:match[group+3]]
+				}
+				break
+			}
+		}
+		routers = append(routers, [2]string{strings.ToUpper(method), handler})
+	}
+	return routers
+}
+
+// rustRouterDefinition finds the source of a router passed by name to nest():
+// the statement of a let binding, or the body of a function when called
+func rustRouterDefinition(text, name string, called bool) (rustSpan, bool) {
+	pattern := `\blet\s+(?:mut\s+)?` + regexp.QuoteMeta(name) + `\b[^=;]*=`
+	if called {
+		pattern = `\bfn\s+` + regexp.QuoteMeta(name) + `\s*\(`
+	}
+	loc := regexp.MustCompile(pattern).FindStringIndex(text)
+	if loc == nil {
+		return rustSpan{}, false
+	}
+	if !called {
+		return rustSpan{start: loc[0], end: rustChainEnd(text, loc[1])}, true
+	}
+	body := strings.Index(text[loc[1]:], "{")
+	if body < 0 {
+		return rustSpan{}, false
+	}
+	return rustSpan{start: loc[0], end: rustChainEnd(text, loc[1]+body+1)}, true
+}
+
+// rustChainEnd returns where the builder chain starting at pos ends: at the
+// parenthesis enclosing it or the end of its statement
+func rustChainEnd(text string, pos int) int {
+	depth := 0
+	for i := pos; i < len(text); i++ {
+		switch text[i] {
+		case '"':
+			for i++; i < len(text) && text[i] != '"'; i++ {
+				if text[i] == '\\' {
+					i++
+				}
+			}
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+			if depth < 0 {
+				return i
+			}
+		case ';':
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(text)
+}
+
+// lifetimeMark replaces the quote of lifetimes while a file is parsed
+const lifetimeMark = "\x01"
+
+// maskLifetimes marks the quote of lifetimes such as 'a and 'static so that
+// they are not mistaken for character literals
+func maskLifetimes(s string) string {
+	b := []byte(s)
+	for i := 0; i+1 < len(b); i++ {
+		if b[i] != '\'' {
+			continue
+		}
+		if b[i+1] == '\\' {
+			i += 2 // Escaped character literal
+			continue
+		}
+		j := i + 1
+		for j < len(b) && (b[j] == '_' || isAlnum(b[j])) {
+			j++
+		}
+		if j == i+1 {
+			continue
+		}
+		if j < len(b) && b[j] == '\'' {
+			i = j // Character literal
+			continue
+		}
+		b[i] = lifetimeMark[0]
+	}
+	return string(b)
+}
+
+// ExtractFunctions finds function signatures in Rust code, including methods
+// of impl blocks and parameter lists spanning several lines
+func (d *RustDetector) ExtractFunctions(filePath string, content []byte) ([]FunctionSignature, error) {
+	var functions []FunctionSignature
+	text := maskLifetimes(string(content))
+
+	implType := ""
+	implDepth := -1
+	inImpl := false
+	depth := 0
+	offset := 0
+	for lineNum, line := range strings.Split(text, "\n") {
+		lineStart := offset
+		offset += len(line) + 1
+
+		trimmedLine := strings.TrimSpace(line)
+		indent := strings.Index(line, trimmedLine)
+		lineDepth := depth
+		depth += strings.Count(line, "{") - strings.Count(line, "}")
+
+		if match := rustImplPattern.FindStringSubmatch(trimmedLine); match != nil {
+			implType = match[1]
+			implDepth = lineDepth
+			inImpl = depth > implDepth
+			continue
+		}
+		if implDepth >= 0 {
+			// The brace of an impl block may follow its where clause
+			if inImpl && lineDepth <= implDepth {
+				implType = ""
+				implDepth = -1
+			}
+			if depth > implDepth {
+				inImpl = true
+			}
+		}
+
+		match := rustFnPattern.FindStringSubmatchIndex(trimmedLine)
+		if match == nil {
+			continue
+		}
+		name := trimmedLine[match[4]:match[5]]
+
+		open := lineStart + indent + match[1] - 1
+		closeIdx := matchingParen(text, open)
+		if closeIdx < 0 {
+			continue
+		}
+
+		var returns []ReturnSpec
+		if returnType := rustReturnType(text[closeIdx+1:]); returnType != "" && returnType != "()" {
+			returns = append(returns, ReturnSpec{Type: returnType})
+		}
+
+		visibility := "private" // Items are private unless marked pub
+		if match[3] > match[2] {
+			visibility = "public"
+		}
+
+		functions = append(functions, FunctionSignature{
+			Name:       name,
+			Receiver:   implType,
+			Parameters: d.parseRustParameters(text[open+1 : closeIdx]),
+			Returns:    returns,
+			File:       filePath,
+			Line:       lineNum + 1,
+			Visibility: visibility,
+		})
+	}
+
+	return functions, nil
+}
+
+// rustReturnType reads an optional "-> Type" after a parameter list, up to the
+// body, a where clause or the end of a declaration
+func rustReturnType(rest string) string {
+	trimmed := strings.TrimLeft(rest, " \t\r\n")
+	if !strings.HasPrefix(trimmed, "->") {
+		return ""
+	}
+	trimmed = trimmed[2:]
+	if end := strings.IndexAny(trimmed, "{;"); end >= 0 {
+		trimmed = trimmed[:end]
+	}
+	if where := strings.Index(trimmed, " where"); where >= 0 {
+		trimmed = trimmed[:where]
+	}
+	return rustType(trimmed)
+}
+
+// parseRustParameters parses "name: Type" parameters, skipping the self
+// receiver and attributes
+func (d *RustDetector) parseRustParameters(paramsStr string) []ParameterSpec {
+	var params []ParameterSpec
+
+	for _, part := range splitTopLevel(paramsStr, ',') {
+		part = strings.TrimSpace(part)
+		for strings.HasPrefix(part, "#[") {
+			end := strings.Index(part, "]")
+			if end < 0 {
+				break
+			}
+			part = strings.TrimSpace(part[end+1:])
+		}
+		if part == "" {
+			continue
+		}
+
+		colon := strings.Index(part, ":")
+		if colon < 0 || strings.HasPrefix(part, "::") {
+			continue // self, &self, &mut self
+		}
+		paramName := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(part[:colon]), "mut "))
+		if paramName == "self" {
+			continue
+		}
+		params = append(params, ParameterSpec{
+			Name: paramName,
+			Type: rustType(part[colon+1:]),
+		})
+	}
+
+	return params
+}
+
+// rustType normalizes the spacing of a type and restores its lifetimes
+func rustType(s string) string {
+	return strings.ReplaceAll(strings.Join(strings.Fields(s), " "), lifetimeMark, "'")
+}
@@ -0,0 +1,229 @@
+package inspect
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// SARIFVersion is the SARIF specification version emitted by ToSARIF
+const SARIFVersion = "2.1.0"
+
+const sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+// SARIFLog is the top-level SARIF 2.1.0 document
+type SARIFLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SARIFRun `json:"runs"`
+}
+
+// SARIFRun describes a single analysis run
+type SARIFRun struct {
+	Tool    SARIFTool     `json:"tool"`
+	Results []SARIFResult `json:"results"`
+}
+
+// SARIFTool describes the tool that produced the run
+type SARIFTool struct {
+	Driver SARIFDriver `json:"driver"`
+}
+
+// SARIFDriver describes the analysis driver and its rules
+type SARIFDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []SARIFRule `json:"rules"`
+}
+
+// SARIFRule describes one warning type
+type SARIFRule struct {
+	ID                   string             `json:"id"`
+	Name                 string             `json:"name"`
+	ShortDescription     SARIFMessage       `json:"shortDescription"`
+	DefaultConfiguration SARIFConfiguration `json:"defaultConfiguration"`
+}
+
+// SARIFConfiguration holds the default reporting level of a rule
+type SARIFConfiguration struct {
+	Level string `json:"level"`
+}
+
+// SARIFMessage is a plain-text SARIF message
+type SARIFMessage struct {
+	Text string `json:"text"`
+}
+
+// SARIFResult is a single reported warning
+type SARIFResult struct {
+	RuleID     string            `json:"ruleId"`
+	RuleIndex  int               `json:"ruleIndex"`
+	Level      string            `json:"level"`
+	Message    SARIFMessage      `json:"message"`
+	Locations  []SARIFLocation   `json:"locations,omitempty"`
+	Properties map[string]string `json:"properties,omitempty"`
+	// BaselineState is "new" or "unchanged" for --since inspections
+	BaselineState string `json:"baselineState,omitempty"`
+}
+
+// SARIFLocation points at the source of a result
+type SARIFLocation struct {
+	PhysicalLocation SARIFPhysicalLocation `json:"physicalLocation"`
+}
+
+// SARIFPhysicalLocation is a file and optional region
+type SARIFPhysicalLocation struct {
+	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
+	Region           *SARIFRegion          `json:"region,omitempty"`
+}
+
+// SARIFArtifactLocation is a URI relative to the source root
+type SARIFArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+// SARIFRegion is a line range within an artifact
+type SARIFRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// ToSARIF converts an inspection result to a SARIF 2.1.0 log.
+// Each WarningType becomes a rule; file locations are made relative to rootDir.
+func ToSARIF(result *InspectResult, rootDir string) *SARIFLog {
+	// Collect rules in a stable order
+	ruleLevels := make(map[WarningType]string)
+	for _, w := range result.Warnings {
+		level := sarifLevel(w.Severity)
+		if existing, ok := ruleLevels[w.Type]; !ok || sarifLevelRank(level) > sarifLevelRank(existing) {
+			ruleLevels[w.Type] = level
+		}
+	}
+
+	var ruleIDs []string
+	for t := range ruleLevels {
+		ruleIDs = append(ruleIDs, string(t))
+	}
+	sort.Strings(ruleIDs)
+
+	rules := []SARIFRule{}
+	ruleIndex := make(map[WarningType]int)
+	for i, id := range ruleIDs {
+		t := WarningType(id)
+		ruleIndex[t] = i
+		rules = append(rules, SARIFRule{
+			ID:                   id,
+			Name:                 sarifRuleName(t),
+			ShortDescription:     SARIFMessage{Text: t.Description()},
+			DefaultConfiguration: SARIFConfiguration{Level: ruleLevels[t]},
+		})
+	}
+
+	results := []SARIFResult{}
+	for _, w := range result.Warnings {
+		res := SARIFResult{
+			RuleID:    string(w.Type),
+			RuleIndex: ruleIndex[w.Type],
+			Level:     sarifLevel(w.Severity),
+			Message:   SARIFMessage{Text: w.Message},
+			Properties: map[string]string{
+				"module": w.Module,
+			},
+		}
+		if w.Remediation != "" {
+			res.Properties["remediation"] = w.Remediation
+		}
+		if len(w.Owners) > 0 {
+			res.Properties["owners"] = strings.Join(w.Owners, ",")
+		}
+		switch w.Change {
+		case ChangeNew:
+			res.BaselineState = "new"
+		case ChangePreexisting:
+			res.BaselineState = "unchanged"
+		}
+
+		if w.File != "" {
+			location := SARIFPhysicalLocation{
+				ArtifactLocation: SARIFArtifactLocation{
+					URI:       sarifURI(rootDir, w.File),
+					URIBaseID: "%SRCROOT%",
+				},
+			}
+			if w.Line > 0 {
+				location.Region = &SARIFRegion{StartLine: w.Line}
+			}
+			res.Locations = []SARIFLocation{{PhysicalLocation: location}}
+		}
+
+		results = append(results, res)
+	}
+
+	return &SARIFLog{
+		Schema:  sarifSchema,
+		Version: SARIFVersion,
+		Runs: []SARIFRun{
+			{
+				Tool: SARIFTool{
+					Driver: SARIFDriver{
+						Name:           "neev",
+						InformationURI: "https://github.com/neev-kit/neev",
+						Rules:          rules,
+					},
+				},
+				Results: results,
+			},
+		},
+	}
+}
+
+// MarshalSARIF renders an inspection result as indented SARIF JSON
+func MarshalSARIF(result *InspectResult, rootDir string) ([]byte, error) {
+	return json.MarshalIndent(ToSARIF(result, rootDir), "", "  ")
+}
+
+// sarifLevel maps neev severities to SARIF levels
+func sarifLevel(severity string) string {
+	switch severity {
+	case "error":
+		return "error"
+	case "warning":
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+func sarifLevelRank(level string) int {
+	switch level {
+	case "error":
+		return 2
+	case "warning":
+		return 1
+	default:
+		return 0
+	}
+}
+
+// sarifRuleName converts MISSING_ENDPOINT to MissingEndpoint
+func sarifRuleName(t WarningType) string {
+	var b strings.Builder
+	for _, part := range strings.Split(strings.ToLower(string(t)), "_") {
+		if part == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+// sarifURI returns a forward-slash path relative to rootDir when possible
+func sarifURI(rootDir, file string) string {
+	if rootDir != "" && filepath.IsAbs(file) {
+		if rel, err := filepath.Rel(rootDir, file); err == nil && !strings.HasPrefix(rel, "..") {
+			file = rel
+		}
+	}
+	return filepath.ToSlash(file)
+}
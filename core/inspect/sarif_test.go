@@ -0,0 +1,100 @@
+package inspect
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+)
+
+func TestToSARIF(t *testing.T) {
+	rootDir := t.TempDir()
+
+	result := &InspectResult{
+		Warnings: []Warning{
+			{
+				Type:        WarningUndocumentedEndpoint,
+				Module:      "api",
+				Message:     "API endpoint GET /api/users is implemented but not documented",
+				Severity:    "warning",
+				Remediation: "Add GET /api/users to API documentation",
+				File:        filepath.Join(rootDir, "src", "api", "routes.go"),
+				Line:        12,
+			},
+			{
+				Type:     WarningMissingEndpoint,
+				Module:   "api",
+				Message:  "API endpoint POST /api/users is documented but not implemented",
+				Severity: "error",
+			},
+			{
+				Type:     WarningExtraCode,
+				Module:   "legacy",
+				Message:  "Code directory 'legacy/' exists but no foundation spec 'legacy.md' found",
+				Severity: "info",
+			},
+		},
+	}
+
+	log := ToSARIF(result, rootDir)
+
+	if log.Version != SARIFVersion {
+		t.Errorf("Version = %q, want %q", log.Version, SARIFVersion)
+	}
+	if len(log.Runs) != 1 {
+		t.Fatalf("Expected 1 run, got %d", len(log.Runs))
+	}
+
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != 3 {
+		t.Fatalf("Expected 3 rules, got %d", len(run.Tool.Driver.Rules))
+	}
+	if len(run.Results) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(run.Results))
+	}
+
+	undocumented := run.Results[0]
+	if run.Tool.Driver.Rules[undocumented.RuleIndex].ID != string(WarningUndocumentedEndpoint) {
+		t.Errorf("RuleIndex %d does not point at %s", undocumented.RuleIndex, WarningUndocumentedEndpoint)
+	}
+	if undocumented.Level != "warning" {
+		t.Errorf("Level = %q, want warning", undocumented.Level)
+	}
+	if len(undocumented.Locations) != 1 {
+		t.Fatalf("Expected 1 location, got %d", len(undocumented.Locations))
+	}
+	location := undocumented.Locations[0].PhysicalLocation
+	if location.ArtifactLocation.URI != "src/api/routes.go" {
+		t.Errorf("URI = %q, want src/api/routes.go", location.ArtifactLocation.URI)
+	}
+	if location.Region == nil || location.Region.StartLine != 12 {
+		t.Errorf("Expected region starting at line 12, got %+v", location.Region)
+	}
+
+	if run.Results[1].Level != "error" {
+		t.Errorf("Level = %q, want error", run.Results[1].Level)
+	}
+	if len(run.Results[1].Locations) != 0 {
+		t.Errorf("Expected no locations for warning without file")
+	}
+	if run.Results[2].Level != "note" {
+		t.Errorf("Level = %q, want note", run.Results[2].Level)
+	}
+}
+
+func TestMarshalSARIF_EmptyResult(t *testing.T) {
+	data, err := MarshalSARIF(&InspectResult{Success: true, Warnings: []Warning{}}, "")
+	if err != nil {
+		t.Fatalf("MarshalSARIF failed: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+
+	runs := decoded["runs"].([]interface{})
+	results := runs[0].(map[string]interface{})["results"].([]interface{})
+	if len(results) != 0 {
+		t.Errorf("Expected empty results, got %d", len(results))
+	}
+}
@@ -0,0 +1,1268 @@
+package inspect
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/neev-kit/neev/core/openapi"
+	"gopkg.in/yaml.v3"
+)
+
+// BodySchema is the shape of a JSON request or response body
+type BodySchema struct {
+	Name   string // Type that declares the body in code; empty for documented bodies
+	Array  bool   // The body is a list of objects with these fields
+	Fields []SchemaField
+	File   string
+	Line   int
+}
+
+// SchemaField is a property of a JSON body
+type SchemaField struct {
+	Name string
+	Type string // JSON type: string, integer, number, boolean, array or object; empty if unknown
+}
+
+// endpointBodies holds the request and response bodies of one endpoint
+type endpointBodies struct {
+	Request  *BodySchema
+	Response *BodySchema
+}
+
+// ValidateRequestSchemas compares the documented request and response bodies
+// of endpoints found by a repository scan with the types their handlers bind
+// and return
+func ValidateRequestSchemas(opts InspectOptions, specEndpoints []openapi.Endpoint, scan *ScanResult) []Warning {
+	var warnings []Warning
+
+	specBodies := collectSpecBodies(opts, specEndpoints)
+	if len(specBodies) == 0 {
+		return warnings
+	}
+
+	index := newSchemaIndex(scan)
+	seen := make(map[string]bool)
+	for _, ep := range scan.Endpoints {
+		key := fmt.Sprintf("%s %s", ep.Method, normalizePath(ep.Path))
+		spec, documented := specBodies[key]
+		if !documented || seen[key] || ep.Handler == "" {
+			continue
+		}
+		seen[key] = true
+
+		code := index.handlerBodies(ep)
+		warnings = append(warnings, compareBodies(key, "request", spec.Request, code.Request)...)
+		warnings = append(warnings, compareBodies(key, "response", spec.Response, code.Response)...)
+	}
+
+	return warnings
+}
+
+// collectSpecBodies gathers documented bodies by "METHOD /path": OpenAPI
+// schemas in blueprints first, then JSON examples from architecture.md
+func collectSpecBodies(opts InspectOptions, specEndpoints []openapi.Endpoint) map[string]endpointBodies {
+	bodies := make(map[string]endpointBodies)
+
+	for _, path := range specFiles(opts, []string{blueprintsDir(opts)}, isOpenAPIFile) {
+		for key, body := range parseOpenAPIBodies(path) {
+			bodies[key] = body
+		}
+	}
+
+	for _, ep := range specEndpoints {
+		key := fmt.Sprintf("%s %s", ep.Method, normalizePath(ep.Path))
+		request, response := ep.Request, ep.Response
+
+		// ParseArchitecture takes the first JSON block as the request; for
+		// methods without a body it is the response example
+		if response == "" && (ep.Method == "GET" || ep.Method == "DELETE" || ep.Method == "HEAD") {
+			request, response = "", request
+		}
+
+		body := bodies[key]
+		if body.Request == nil {
+			body.Request = exampleSchema(request)
+		}
+		if body.Response == nil {
+			body.Response = exampleSchema(response)
+		}
+		if body.Request != nil || body.Response != nil {
+			bodies[key] = body
+		}
+	}
+
+	return bodies
+}
+
+// parseOpenAPIBodies extracts the application/json request body and success
+// response schema of each operation in an OpenAPI file
+func parseOpenAPIBodies(filePath string) map[string]endpointBodies {
+	bodies := make(map[string]endpointBodies)
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return bodies
+	}
+	var spec map[string]interface{}
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return bodies
+	}
+
+	components, _ := spec["components"].(map[string]interface{})
+	schemas, _ := components["schemas"].(map[string]interface{})
+	paths, _ := spec["paths"].(map[string]interface{})
+
+	for path, pathItem := range paths {
+		pathMap, ok := pathItem.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for method, operation := range pathMap {
+			opMap, ok := operation.(map[string]interface{})
+			if !ok || !isHTTPMethod(strings.ToUpper(method)) {
+				continue
+			}
+
+			var body endpointBodies
+			if requestBody, ok := opMap["requestBody"].(map[string]interface{}); ok {
+				body.Request = openAPIBodySchema(requestBody, schemas)
+			}
+
+			// The lowest 2xx response describes success
+			responses, _ := opMap["responses"].(map[string]interface{})
+			var codes []string
+			for code := range responses {
+				if strings.HasPrefix(code, "2") {
+					codes = append(codes, code)
+				}
+			}
+			sort.Strings(codes)
+			for _, code := range codes {
+				if response, ok := responses[code].(map[string]interface{}); ok {
+					if body.Response = openAPIBodySchema(response, schemas); body.Response != nil {
+						break
+					}
+				}
+			}
+
+			if body.Request != nil || body.Response != nil {
+				bodies[fmt.Sprintf("%s %s", strings.ToUpper(method), normalizePath(path))] = body
+			}
+		}
+	}
+
+	return bodies
+}
+
+// openAPIBodySchema returns the application/json schema of a request body or response
+func openAPIBodySchema(body map[string]interface{}, schemas map[string]interface{}) *BodySchema {
+	content, _ := body["content"].(map[string]interface{})
+	media, ok := content["application/json"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	schema := resolveOpenAPISchema(media["schema"], schemas, 0)
+	if schema == nil {
+		return nil
+	}
+
+	result := &BodySchema{}
+	if schema["type"] == "array" {
+		result.Array = true
+		if schema = resolveOpenAPISchema(schema["items"], schemas, 0); schema == nil {
+			return nil
+		}
+	}
+
+	properties := openAPIProperties(schema, schemas, 0)
+	if len(properties) == 0 {
+		return nil
+	}
+	var names []string
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		result.Fields = append(result.Fields, SchemaField{Name: name, Type: openAPIType(properties[name], schemas)})
+	}
+	return result
+}
+
+// resolveOpenAPISchema follows $ref to components/schemas
+func resolveOpenAPISchema(schema interface{}, schemas map[string]interface{}, depth int) map[string]interface{} {
+	schemaMap, ok := schema.(map[string]interface{})
+	if !ok || depth > 10 {
+		return nil
+	}
+	if ref, ok := schemaMap["$ref"].(string); ok {
+		name := strings.TrimPrefix(ref, "#/components/schemas/")
+		return resolveOpenAPISchema(schemas[name], schemas, depth+1)
+	}
+	return schemaMap
+}
+
+// openAPIProperties returns the properties of an object schema, merging allOf parts
+func openAPIProperties(schema map[string]interface{}, schemas map[string]interface{}, depth int) map[string]interface{} {
+	properties := make(map[string]interface{})
+	if depth > 10 {
+		return properties
+	}
+	if parts, ok := schema["allOf"].([]interface{}); ok {
+		for _, part := range parts {
+			if resolved := resolveOpenAPISchema(part, schemas, depth+1); resolved != nil {
+				for name, property := range openAPIProperties(resolved, schemas, depth+1) {
+					properties[name] = property
+				}
+			}
+		}
+	}
+	if own, ok := schema["properties"].(map[string]interface{}); ok {
+		for name, property := range own {
+			properties[name] = property
+		}
+	}
+	return properties
+}
+
+// openAPIType returns the JSON type of a property schema
+func openAPIType(property interface{}, schemas map[string]interface{}) string {
+	schema := resolveOpenAPISchema(property, schemas, 0)
+	if schema == nil {
+		return ""
+	}
+	if typ, ok := schema["type"].(string); ok {
+		return typ
+	}
+	// OpenAPI 3.1 allows type: [string, "null"]
+	if types, ok := schema["type"].([]interface{}); ok {
+		for _, typ := range types {
+			if s, ok := typ.(string); ok && s != "null" {
+				return s
+			}
+		}
+	}
+	if _, ok := schema["properties"]; ok {
+		return "object"
+	}
+	if _, ok := schema["allOf"]; ok {
+		return "object"
+	}
+	return ""
+}
+
+// exampleSchema derives a body schema from a JSON example
+func exampleSchema(example string) *BodySchema {
+	if strings.TrimSpace(example) == "" {
+		return nil
+	}
+	var value interface{}
+	if err := json.Unmarshal([]byte(example), &value); err != nil {
+		return nil // Examples with comments or placeholders are not checked
+	}
+
+	result := &BodySchema{}
+	if list, ok := value.([]interface{}); ok {
+		if len(list) == 0 {
+			return nil
+		}
+		result.Array = true
+		value = list[0]
+	}
+	object, ok := value.(map[string]interface{})
+	if !ok || len(object) == 0 {
+		return nil
+	}
+
+	var names []string
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		result.Fields = append(result.Fields, SchemaField{Name: name, Type: exampleType(object[name])})
+	}
+	return result
+}
+
+// exampleType returns the JSON type of an example value
+func exampleType(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return "string"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case bool:
+		return "boolean"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return ""
+	}
+}
+
+// compareBodies reports fields a handler's type lacks, fields it adds and
+// fields whose JSON types differ from the documented body
+func compareBodies(key, part string, spec, code *BodySchema) []Warning {
+	var warnings []Warning
+	if spec == nil || code == nil {
+		return warnings
+	}
+
+	mismatch := func(subject, message string) {
+		warnings = append(warnings, Warning{
+			Type:        WarningSchemaMismatch,
+			Module:      "api",
+			Subject:     subject,
+			Message:     message,
+			Severity:    "warning",
+			Remediation: fmt.Sprintf("Align %s with the documented %s body of %s or update the API spec", code.Name, part, key),
+			File:        code.File,
+			Line:        code.Line,
+		})
+	}
+
+	if spec.Array != code.Array {
+		documented, actual := "a single object", "a list"
+		if spec.Array {
+			documented, actual = "a list", "a single object"
+		}
+		mismatch(key+" "+part, fmt.Sprintf("The %s body of %s is documented as %s but the handler uses %s of %s", part, key, documented, actual, code.Name))
+	}
+
+	codeFields := make(map[string]SchemaField)
+	for _, field := range code.Fields {
+		codeFields[strings.ToLower(field.Name)] = field
+	}
+	specFields := make(map[string]bool)
+	for _, field := range spec.Fields {
+		specFields[strings.ToLower(field.Name)] = true
+
+		actual, ok := codeFields[strings.ToLower(field.Name)]
+		switch {
+		case !ok:
+			mismatch(key+" "+part+"."+field.Name, fmt.Sprintf("The %s field '%s' of %s is documented but %s has no such field", part, field.Name, key, code.Name))
+		case !jsonTypesCompatible(field.Type, actual.Type):
+			mismatch(key+" "+part+"."+field.Name, fmt.Sprintf("The %s field '%s' of %s is documented as %s but %s declares %s", part, field.Name, key, field.Type, code.Name, actual.Type))
+		}
+	}
+	for _, field := range code.Fields {
+		if !specFields[strings.ToLower(field.Name)] {
+			mismatch(key+" "+part+"."+field.Name, fmt.Sprintf("The %s field '%s' of %s is not documented (declared by %s)", part, field.Name, key, code.Name))
+		}
+	}
+
+	return warnings
+}
+
+// jsonTypesCompatible reports whether two JSON types agree. Unknown types
+// match anything, and integers are numbers.
+func jsonTypesCompatible(documented, actual string) bool {
+	if documented == "" || actual == "" || documented == actual {
+		return true
+	}
+	return documented == "number" && actual == "integer" || documented == "integer" && actual == "number"
+}
+
+// schemaIndex holds the body types declared in code and the source of
+// handlers. Sources are only parsed when a name they mention is looked up, so
+// only the handlers of documented endpoints and the types they use are indexed.
+type schemaIndex struct {
+	goStructs map[string][]*goStruct
+	goTypes   map[string]ast.Expr // Named Go types other than structs -> their underlying type
+	goFuncs   map[string][]*goFunc
+	pyClasses map[string]*sourceClass
+	javaClass map[string]*sourceClass
+	sources   map[string]string // File -> content of every Go, Python and Java source
+	files     []string          // Sources in walk order
+	order     map[string]int    // File -> position in files
+	parsed    map[string]bool   // Files indexed so far
+	loaded    map[string]bool   // Names whose declarations are all indexed
+}
+
+// goStruct is a Go struct type and the types it embeds
+type goStruct struct {
+	schema BodySchema
+	embeds []string
+	types  []ast.Expr // Type of each field, resolved when a schema is built
+}
+
+// goFunc is a Go function or method declaration
+type goFunc struct {
+	decl *ast.FuncDecl
+	file string
+}
+
+// sourceClass is a Python or Java class and its base classes
+type sourceClass struct {
+	schema      BodySchema
+	bases       []string
+	annotations []string // Python annotation of each field, resolved when a model is built
+}
+
+var (
+	// Python class headers and annotated attributes
+	pyClassPattern = regexp.MustCompile(`^class\s+(\w+)\s*(?:\(([^)]*)\))?\s*:`)
+	pyFieldPattern = regexp.MustCompile(`^(\w+)\s*:\s*([^=]+?)\s*(?:=.*)?$`)
+
+	// response_model=UserOut in a FastAPI route decorator
+	pyResponseModelPattern = regexp.MustCompile(`response_model\s*=\s*([\w.\[\], |]+?)\s*[,)]`)
+
+	// Java classes and records
+	javaClassPattern        = regexp.MustCompile(`\bclass\s+(\w+)(?:<[^>]*>)?(?:\s+extends\s+([\w.]+))?[^{;]*\{`)
+	javaRecordPattern       = regexp.MustCompile(`\brecord\s+(\w+)\s*\(`)
+	javaFieldPattern        = regexp.MustCompile(`^((?:@[\w.]+(?:\([^)]*\))?\s+)*)((?:(?:private|protected|public|final|transient|volatile)\s+)*)([\w.]+(?:<.*>)?(?:\[\])?)\s+(\w+)\s*(?:=[^;]*)?;`)
+	javaJSONPropertyPattern = regexp.MustCompile(`@JsonProperty\(\s*(?:value\s*=\s*)?"([^"]*)"`)
+	javaRequestBodyPattern  = regexp.MustCompile(`@RequestBody\s+(?:@[\w.]+(?:\([^)]*\))?\s+)*(?:final\s+)?([\w.]+(?:<[^>]*>)?(?:\[\])?)\s+\w+`)
+)
+
+// schemaSourceExtractor reads the Go, Python and Java sources that declare
+// handlers and body types during the repository scan. Sources are parsed
+// after the scan, so their text is kept rather than cached.
+var schemaSourceExtractor = FileExtractor{
+	Name: "schema-sources",
+	Match: func(path string) bool {
+		language := DetectLanguageByExtension(path)
+		return (language == LangGo || language == LangPython || language == LangJava) && !strings.HasSuffix(path, "_test.go")
+	},
+	Extract: func(path string, content []byte) interface{} {
+		return string(content)
+	},
+}
+
+// newSchemaIndex prepares an index of the sources found by a repository scan
+func newSchemaIndex(scan *ScanResult) *schemaIndex {
+	index := &schemaIndex{
+		goStructs: make(map[string][]*goStruct),
+		goTypes:   make(map[string]ast.Expr),
+		goFuncs:   make(map[string][]*goFunc),
+		pyClasses: make(map[string]*sourceClass),
+		javaClass: make(map[string]*sourceClass),
+		sources:   make(map[string]string),
+		order:     make(map[string]int),
+		parsed:    make(map[string]bool),
+		loaded:    make(map[string]bool),
+	}
+
+	for _, file := range scan.Facts[schemaSourceExtractor.Name] {
+		index.order[file.Path] = len(index.files)
+		index.files = append(index.files, file.Path)
+		index.sources[file.Path] = file.Facts.(string)
+	}
+
+	return index
+}
+
+// load indexes every source that mentions name, so that all declarations of
+// it are known, in walk order
+func (x *schemaIndex) load(name string) {
+	if name == "" || x.loaded[name] {
+		return
+	}
+	x.loaded[name] = true
+
+	for _, path := range x.files {
+		content := x.sources[path]
+		if x.parsed[path] || !strings.Contains(content, name) {
+			continue
+		}
+		x.parsed[path] = true
+		switch DetectLanguageByExtension(path) {
+		case LangGo:
+			x.addGoFile(path, []byte(content))
+		case LangPython:
+			x.addPythonFile(path, content)
+		case LangJava:
+			x.addJavaFile(path, content)
+		}
+	}
+
+	// Earlier loads may have indexed declarations of name out of walk order
+	sort.SliceStable(x.goStructs[name], func(i, j int) bool {
+		return x.order[x.goStructs[name][i].schema.File] < x.order[x.goStructs[name][j].schema.File]
+	})
+	sort.SliceStable(x.goFuncs[name], func(i, j int) bool {
+		return x.order[x.goFuncs[name][i].file] < x.order[x.goFuncs[name][j].file]
+	})
+}
+
+// addClass indexes a Python or Java class; of several with a name, the last
+// in walk order wins
+func (x *schemaIndex) addClass(classes map[string]*sourceClass, class *sourceClass) {
+	if prev, ok := classes[class.schema.Name]; ok && x.order[prev.schema.File] > x.order[class.schema.File] {
+		return
+	}
+	classes[class.schema.Name] = class
+}
+
+// handlerBodies returns the request and response types of an endpoint's handler
+func (x *schemaIndex) handlerBodies(ep Endpoint) endpointBodies {
+	switch ep.Language {
+	case string(LangGo):
+		return x.goHandlerBodies(ep)
+	case string(LangPython):
+		return x.pythonHandlerBodies(ep)
+	case string(LangJava):
+		return x.javaHandlerBodies(ep)
+	}
+	return endpointBodies{}
+}
+
+// addGoFile indexes the struct types and functions of a Go file
+func (x *schemaIndex) addGoFile(path string, content []byte) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, content, parser.SkipObjectResolution)
+	if err != nil {
+		return
+	}
+
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Body != nil {
+				x.goFuncs[decl.Name.Name] = append(x.goFuncs[decl.Name.Name], &goFunc{decl: decl, file: path})
+			}
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				typeSpec, ok := spec.(*ast.TypeSpec)
+				if !ok {
+					continue
+				}
+				structType, ok := typeSpec.Type.(*ast.StructType)
+				if !ok {
+					x.goTypes[typeSpec.Name.Name] = typeSpec.Type
+					continue
+				}
+				s := &goStruct{schema: BodySchema{
+					Name: typeSpec.Name.Name,
+					File: path,
+					Line: fset.Position(typeSpec.Pos()).Line,
+				}}
+				for _, field := range structType.Fields.List {
+					name, skip := goJSONName(field)
+					if skip {
+						continue
+					}
+					if len(field.Names) == 0 && name == "" {
+						s.embeds = append(s.embeds, goTypeName(field.Type))
+						continue
+					}
+					for _, ident := range field.Names {
+						if !ident.IsExported() {
+							continue
+						}
+						fieldName := name
+						if fieldName == "" {
+							fieldName = ident.Name
+						}
+						s.schema.Fields = append(s.schema.Fields, SchemaField{Name: fieldName})
+						s.types = append(s.types, field.Type)
+					}
+				}
+				x.goStructs[s.schema.Name] = append(x.goStructs[s.schema.Name], s)
+			}
+		}
+	}
+}
+
+// goJSONName returns the name a json struct tag gives a field, and whether
+// the tag hides it
+func goJSONName(field *ast.Field) (string, bool) {
+	if field.Tag == nil {
+		return "", false
+	}
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return "", false
+	}
+	name, _, _ := strings.Cut(reflect.StructTag(tag).Get("json"), ",")
+	return name, name == "-"
+}
+
+// goTypeName returns the name of a named type, without package or pointer
+func goTypeName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	case *ast.StarExpr:
+		return goTypeName(t.X)
+	}
+	return ""
+}
+
+// goJSONType returns the JSON type encoding/json produces for a Go type.
+// Named types are resolved through the index; unknown ones have no type.
+func (x *schemaIndex) goJSONType(expr ast.Expr, depth int) string {
+	name := ""
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return x.goJSONType(t.X, depth)
+	case *ast.ArrayType:
+		if ident, ok := t.Elt.(*ast.Ident); ok && ident.Name == "byte" {
+			return "string"
+		}
+		return "array"
+	case *ast.MapType, *ast.StructType:
+		return "object"
+	case *ast.SelectorExpr:
+		switch t.Sel.Name {
+		case "Time", "UUID":
+			return "string"
+		case "Duration":
+			return "integer"
+		case "RawMessage":
+			return ""
+		}
+		name = t.Sel.Name
+	case *ast.Ident:
+		switch t.Name {
+		case "string":
+			return "string"
+		case "bool":
+			return "boolean"
+		case "float32", "float64":
+			return "number"
+		case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "uintptr":
+			return "integer"
+		case "any", "error", "rune", "byte":
+			return ""
+		}
+		name = t.Name
+	default:
+		return ""
+	}
+
+	x.load(name)
+	if len(x.goStructs[name]) > 0 {
+		return "object"
+	}
+	if underlying, ok := x.goTypes[name]; ok && depth < 5 {
+		return x.goJSONType(underlying, depth+1)
+	}
+	return ""
+}
+
+// goStructSchema returns the struct named name, preferring one declared in
+// dir, with the fields of embedded structs promoted
+func (x *schemaIndex) goStructSchema(name, dir string, depth int) *BodySchema {
+	x.load(name)
+	candidates := x.goStructs[name]
+	if len(candidates) == 0 || depth > 5 {
+		return nil
+	}
+	s := candidates[0]
+	for _, candidate := range candidates {
+		if filepath.Dir(candidate.schema.File) == dir {
+			s = candidate
+			break
+		}
+	}
+
+	schema := s.schema
+	schema.Fields = append([]SchemaField{}, s.schema.Fields...)
+	for i := range schema.Fields {
+		schema.Fields[i].Type = x.goJSONType(s.types[i], 0)
+	}
+	for _, embedded := range s.embeds {
+		if inner := x.goStructSchema(embedded, dir, depth+1); inner != nil {
+			schema.Fields = append(schema.Fields, inner.Fields...)
+		}
+	}
+	return &schema
+}
+
+// goBindMethods decode a request body into their argument
+var goBindMethods = map[string]bool{
+	"ShouldBindJSON": true, "BindJSON": true, "ShouldBind": true, "Bind": true,
+	"BodyParser": true, "Decode": true,
+}
+
+// goRenderMethods encode their last argument as the response body
+var goRenderMethods = map[string]bool{
+	"JSON": true, "IndentedJSON": true, "Encode": true,
+}
+
+// goSuccessStatuses are the names net/http and its look-alikes give 2xx statuses
+var goSuccessStatuses = map[string]bool{
+	"StatusOK": true, "StatusCreated": true, "StatusAccepted": true, "StatusNonAuthoritativeInfo": true,
+	"StatusNoContent": true, "StatusResetContent": true, "StatusPartialContent": true,
+	"StatusMultiStatus": true, "StatusAlreadyReported": true, "StatusIMUsed": true,
+}
+
+// goRenderStatus returns the status a render call sends, if it names one: the
+// first of several arguments, as in c.JSON(http.StatusOK, user), or that of a
+// chained c.Status(201).JSON(user)
+func goRenderStatus(call *ast.CallExpr, sel *ast.SelectorExpr) ast.Expr {
+	if len(call.Args) > 1 {
+		return call.Args[0]
+	}
+	if chained, ok := sel.X.(*ast.CallExpr); ok && len(chained.Args) > 0 {
+		if status, ok := chained.Fun.(*ast.SelectorExpr); ok && status.Sel.Name == "Status" {
+			return chained.Args[0]
+		}
+	}
+	return nil
+}
+
+// goErrorStatus reports whether a status expression is known not to be 2xx
+func goErrorStatus(expr ast.Expr) bool {
+	name := ""
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		code, err := strconv.Atoi(e.Value)
+		return err == nil && (code < 200 || code > 299)
+	case *ast.SelectorExpr:
+		name = e.Sel.Name
+	case *ast.Ident:
+		name = e.Name
+	}
+	return strings.HasPrefix(name, "Status") && !goSuccessStatuses[name]
+}
+
+// goHandlerBodies finds the types a Go handler binds with c.ShouldBindJSON(&req),
+// json.NewDecoder(r.Body).Decode(&req) and the like, and renders with c.JSON
+// or json.NewEncoder(w).Encode. Error responses come first in most handlers,
+// so the response is the last render call that does not send an error status.
+func (x *schemaIndex) goHandlerBodies(ep Endpoint) endpointBodies {
+	var bodies endpointBodies
+
+	name := ep.Handler[strings.LastIndex(ep.Handler, ".")+1:]
+	x.load(name)
+	candidates := x.goFuncs[name]
+	if len(candidates) == 0 {
+		return bodies
+	}
+	fn := candidates[0]
+	for _, candidate := range candidates {
+		if candidate.file == ep.File {
+			fn = candidate
+			break
+		} else if filepath.Dir(candidate.file) == filepath.Dir(ep.File) {
+			fn = candidate
+		}
+	}
+	dir := filepath.Dir(fn.file)
+
+	ast.Inspect(fn.decl.Body, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok || len(call.Args) == 0 {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+
+		switch {
+		case goBindMethods[sel.Sel.Name] && bodies.Request == nil:
+			if typeName, array := goValueType(fn.decl.Body, call.Args[0]); typeName != "" {
+				if schema := x.goStructSchema(typeName, dir, 0); schema != nil {
+					schema.Array = array
+					bodies.Request = schema
+				}
+			}
+		case goRenderMethods[sel.Sel.Name]:
+			if status := goRenderStatus(call, sel); status != nil && goErrorStatus(status) {
+				return true
+			}
+			if typeName, array := goValueType(fn.decl.Body, call.Args[len(call.Args)-1]); typeName != "" {
+				if schema := x.goStructSchema(typeName, dir, 0); schema != nil {
+					schema.Array = array
+					bodies.Response = schema
+				}
+			}
+		}
+		return true
+	})
+
+	return bodies
+}
+
+// goValueType returns the type name of a value passed to a bind or render
+// call, looking up local variables in the handler body, and whether it is a slice
+func goValueType(body *ast.BlockStmt, expr ast.Expr) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.UnaryExpr:
+		return goValueType(body, e.X)
+	case *ast.CompositeLit:
+		return goTypeExpr(e.Type)
+	case *ast.Ident:
+		var name string
+		var array bool
+		ast.Inspect(body, func(node ast.Node) bool {
+			if name != "" {
+				return false
+			}
+			switch n := node.(type) {
+			case *ast.ValueSpec:
+				for i, ident := range n.Names {
+					if ident.Name != e.Name {
+						continue
+					}
+					if n.Type != nil {
+						name, array = goTypeExpr(n.Type)
+					} else if i < len(n.Values) {
+						name, array = goValueType(body, n.Values[i])
+					}
+				}
+			case *ast.AssignStmt:
+				if n.Tok != token.DEFINE {
+					return true
+				}
+				for i, lhs := range n.Lhs {
+					if ident, ok := lhs.(*ast.Ident); ok && ident.Name == e.Name && i < len(n.Rhs) {
+						if _, isIdent := n.Rhs[i].(*ast.Ident); !isIdent {
+							name, array = goValueType(body, n.Rhs[i])
+						}
+					}
+				}
+			}
+			return true
+		})
+		return name, array
+	}
+	return "", false
+}
+
+// goTypeExpr returns the element type name of a type expression and whether it is a slice
+func goTypeExpr(expr ast.Expr) (string, bool) {
+	if array, ok := expr.(*ast.ArrayType); ok {
+		return goTypeName(array.Elt), true
+	}
+	return goTypeName(expr), false
+}
+
+// addPythonFile indexes the classes of a Python file and their annotated attributes
+func (x *schemaIndex) addPythonFile(path, text string) {
+	var current *sourceClass
+	classIndent, bodyIndent := 0, -1
+
+	for lineNum, line := range strings.Split(text, "\n") {
+		trimmedLine := strings.TrimSpace(line)
+		if trimmedLine == "" || strings.HasPrefix(trimmedLine, "#") {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+
+		if current != nil && indent <= classIndent {
+			current = nil
+		}
+		if match := pyClassPattern.FindStringSubmatch(trimmedLine); match != nil {
+			current = &sourceClass{schema: BodySchema{Name: match[1], File: path, Line: lineNum + 1}}
+			for _, base := range strings.Split(match[2], ",") {
+				if base = strings.TrimSpace(base); base != "" {
+					current.bases = append(current.bases, base[strings.LastIndex(base, ".")+1:])
+				}
+			}
+			x.addClass(x.pyClasses, current)
+			classIndent, bodyIndent = indent, -1
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		if bodyIndent < 0 {
+			bodyIndent = indent
+		}
+		if indent != bodyIndent {
+			continue
+		}
+		if match := pyFieldPattern.FindStringSubmatch(trimmedLine); match != nil && !strings.HasPrefix(match[2], "ClassVar") && match[1] != "model_config" {
+			current.schema.Fields = append(current.schema.Fields, SchemaField{Name: match[1]})
+			current.annotations = append(current.annotations, match[2])
+		}
+	}
+}
+
+// pythonModelBases are the base classes of request and response models
+var pythonModelBases = map[string]bool{"BaseModel": true, "SQLModel": true, "Schema": true}
+
+// pythonModel returns a Pydantic model with the fields of its base models
+func (x *schemaIndex) pythonModel(name string, depth int) *BodySchema {
+	x.load(name)
+	class, ok := x.pyClasses[name]
+	if !ok || depth > 5 {
+		return nil
+	}
+
+	schema := class.schema
+	schema.Fields = nil
+	isModel := false
+	for _, base := range class.bases {
+		if pythonModelBases[base] {
+			isModel = true
+		} else if inherited := x.pythonModel(base, depth+1); inherited != nil {
+			isModel = true
+			schema.Fields = append(schema.Fields, inherited.Fields...)
+		}
+	}
+	if !isModel {
+		return nil
+	}
+	for i, field := range class.schema.Fields {
+		field.Type = x.pythonJSONType(class.annotations[i])
+		schema.Fields = append(schema.Fields, field)
+	}
+	return &schema
+}
+
+// pythonTypeName unwraps Optional, Annotated and list annotations to the
+// model they carry, reporting whether it is a list
+func pythonTypeName(annotation string) (string, bool) {
+	annotation = strings.TrimSpace(annotation)
+	array := false
+	for {
+		open := strings.Index(annotation, "[")
+		if open < 0 || !strings.HasSuffix(annotation, "]") {
+			break
+		}
+		outer := annotation[strings.LastIndex(annotation[:open], ".")+1 : open]
+		inner := strings.TrimSpace(splitTopLevel(annotation[open+1:len(annotation)-1], ',')[0])
+		switch outer {
+		case "List", "list", "Sequence", "Set", "set":
+			array = true
+		case "Optional", "Annotated":
+		default:
+			return outer, array
+		}
+		annotation = inner
+	}
+	if parts := strings.Split(annotation, "|"); len(parts) > 1 {
+		for _, part := range parts {
+			if part = strings.TrimSpace(part); part != "None" {
+				annotation = part
+				break
+			}
+		}
+	}
+	return annotation[strings.LastIndex(annotation, ".")+1:], array
+}
+
+// pythonJSONType returns the JSON type Pydantic serializes an annotation as
+func (x *schemaIndex) pythonJSONType(annotation string) string {
+	name, array := pythonTypeName(annotation)
+	if array {
+		return "array"
+	}
+	switch name {
+	case "str", "EmailStr", "HttpUrl", "AnyUrl", "UUID", "datetime", "date", "time", "SecretStr":
+		return "string"
+	case "int", "PositiveInt", "NonNegativeInt", "conint":
+		return "integer"
+	case "float", "Decimal", "PositiveFloat", "confloat":
+		return "number"
+	case "bool", "StrictBool":
+		return "boolean"
+	case "tuple", "Tuple", "frozenset", "FrozenSet":
+		return "array"
+	case "dict", "Dict", "Mapping":
+		return "object"
+	case "Any", "Json":
+		return ""
+	}
+	x.load(name)
+	if _, ok := x.pyClasses[name]; ok {
+		return "object"
+	}
+	return ""
+}
+
+// pythonHandlerBodies finds the Pydantic models a FastAPI handler takes and
+// declares with response_model or its return annotation
+func (x *schemaIndex) pythonHandlerBodies(ep Endpoint) endpointBodies {
+	var bodies endpointBodies
+
+	text, ok := x.sources[ep.File]
+	if !ok {
+		return bodies
+	}
+	start := lineOffset(text, ep.Line)
+	defPattern := regexp.MustCompile(`(?m)^[ \t]*(?:async\s+)?def\s+` + regexp.QuoteMeta(ep.Handler) + `\s*\(`)
+	loc := defPattern.FindStringIndex(text[start:])
+	if loc == nil {
+		return bodies
+	}
+	open := start + loc[1] - 1
+	end := matchingParen(text, open)
+	if end < 0 {
+		return bodies
+	}
+
+	for _, param := range splitTopLevel(text[open+1:end], ',') {
+		_, annotation, typed := strings.Cut(param, ":")
+		if !typed {
+			continue
+		}
+		annotation, _, _ = strings.Cut(annotation, "=")
+		name, array := pythonTypeName(annotation)
+		if model := x.pythonModel(name, 0); model != nil {
+			model.Array = array
+			bodies.Request = model
+			break
+		}
+	}
+
+	// response_model in the route decorator wins over the return annotation
+	response := ""
+	if match := pyResponseModelPattern.FindStringSubmatch(text[start : start+loc[0]]); match != nil {
+		response = match[1]
+	} else if colon := strings.Index(text[end:], ":"); colon >= 0 {
+		if arrow := strings.Index(text[end:end+colon], "->"); arrow >= 0 {
+			response = text[end+arrow+2 : end+colon]
+		}
+	}
+	if response != "" {
+		name, array := pythonTypeName(response)
+		if model := x.pythonModel(name, 0); model != nil {
+			model.Array = array
+			bodies.Response = model
+		}
+	}
+
+	return bodies
+}
+
+// addJavaFile indexes the fields of the classes and records of a Java file
+func (x *schemaIndex) addJavaFile(path, text string) {
+	for _, match := range javaRecordPattern.FindAllStringSubmatchIndex(text, -1) {
+		open := match[1] - 1
+		end := matchingParen(text, open)
+		if end < 0 {
+			continue
+		}
+		class := &sourceClass{schema: BodySchema{
+			Name: text[match[2]:match[3]],
+			File: path,
+			Line: strings.Count(text[:match[0]], "\n") + 1,
+		}}
+		for _, component := range splitTopLevel(text[open+1:end], ',') {
+			component = strings.TrimSpace(component)
+			rename := ""
+			if property := javaJSONPropertyPattern.FindStringSubmatch(component); property != nil {
+				rename = property[1]
+			}
+			component = stripJavaAnnotations(component)
+			fields := strings.Fields(component)
+			if len(fields) < 2 {
+				continue
+			}
+			name := fields[len(fields)-1]
+			if rename != "" {
+				name = rename
+			}
+			class.schema.Fields = append(class.schema.Fields, SchemaField{Name: name, Type: javaJSONType(strings.Join(fields[:len(fields)-1], " "))})
+		}
+		x.addClass(x.javaClass, class)
+	}
+
+	for _, match := range javaClassPattern.FindAllStringSubmatchIndex(text, -1) {
+		open := match[1] - 1
+		end := matchingBrace(text, open)
+		if end < 0 {
+			continue
+		}
+		class := &sourceClass{schema: BodySchema{
+			Name: text[match[2]:match[3]],
+			File: path,
+			Line: strings.Count(text[:match[0]], "\n") + 1,
+		}}
+		if match[4] >= 0 {
+			base := text[match[4]:match[5]]
+			class.bases = append(class.bases, base[strings.LastIndex(base, ".")+1:])
+		}
+
+		// Only statements directly in the class body are fields
+		body := []byte(text[open+1 : end])
+		depth := 0
+		for i, c := range body {
+			switch {
+			case c == '{':
+				depth++
+			case c == '}':
+				depth--
+				body[i] = ' '
+				continue
+			}
+			if depth > 0 && c != '\n' {
+				body[i] = ' '
+			}
+		}
+
+		rename := ""
+		for _, line := range strings.Split(string(body), "\n") {
+			line = strings.TrimSpace(line)
+			if property := javaJSONPropertyPattern.FindStringSubmatch(line); property != nil {
+				rename = property[1]
+			}
+			field := javaFieldPattern.FindStringSubmatch(line)
+			if field == nil {
+				if line != "" && !strings.HasPrefix(line, "@") {
+					rename = ""
+				}
+				continue
+			}
+			if !strings.Contains(field[2], "static") && !strings.Contains(field[1], "JsonIgnore") {
+				name := field[4]
+				if rename != "" {
+					name = rename
+				}
+				class.schema.Fields = append(class.schema.Fields, SchemaField{Name: name, Type: javaJSONType(field[3])})
+			}
+			rename = ""
+		}
+		x.addClass(x.javaClass, class)
+	}
+}
+
+// stripJavaAnnotations removes annotations from a declaration
+func stripJavaAnnotations(decl string) string {
+	for strings.HasPrefix(decl, "@") {
+		end := strings.IndexAny(decl, " (")
+		if end < 0 {
+			return ""
+		}
+		if decl[end] == '(' {
+			if closeIdx := matchingParen(decl, end); closeIdx >= 0 {
+				end = closeIdx + 1
+			}
+		}
+		decl = strings.TrimSpace(decl[end:])
+	}
+	return strings.TrimPrefix(decl, "final ")
+}
+
+// javaClassSchema returns a class with the fields of its superclasses
+func (x *schemaIndex) javaClassSchema(name string, depth int) *BodySchema {
+	x.load(name)
+	class, ok := x.javaClass[name]
+	if !ok || depth > 5 {
+		return nil
+	}
+	schema := class.schema
+	schema.Fields = nil
+	for _, base := range class.bases {
+		if inherited := x.javaClassSchema(base, depth+1); inherited != nil {
+			schema.Fields = append(schema.Fields, inherited.Fields...)
+		}
+	}
+	schema.Fields = append(schema.Fields, class.schema.Fields...)
+	return &schema
+}
+
+// javaTypeName unwraps ResponseEntity, Optional, Mono and collections to the
+// class they carry, reporting whether it is a list
+func javaTypeName(typ string) (string, bool) {
+	typ = strings.TrimSpace(typ)
+	array := false
+	for {
+		if strings.HasSuffix(typ, "[]") {
+			array = true
+			typ = strings.TrimSpace(strings.TrimSuffix(typ, "[]"))
+			continue
+		}
+		open := strings.Index(typ, "<")
+		if open < 0 || !strings.HasSuffix(typ, ">") {
+			break
+		}
+		outer := typ[strings.LastIndex(typ[:open], ".")+1 : open]
+		switch outer {
+		case "List", "Set", "Collection", "Iterable", "Flux":
+			array = true
+		case "ResponseEntity", "Optional", "Mono", "CompletableFuture", "HttpEntity":
+		default:
+			return outer, array
+		}
+		typ = strings.TrimSpace(typ[open+1 : len(typ)-1])
+	}
+	return typ[strings.LastIndex(typ, ".")+1:], array
+}
+
+// javaJSONType returns the JSON type Jackson serializes a Java type as
+func javaJSONType(typ string) string {
+	name, array := javaTypeName(typ)
+	if array {
+		return "array"
+	}
+	switch name {
+	case "String", "UUID", "LocalDate", "LocalDateTime", "LocalTime", "Instant", "OffsetDateTime", "ZonedDateTime", "Date", "char", "Character":
+		return "string"
+	case "int", "Integer", "long", "Long", "short", "Short", "byte", "Byte", "BigInteger":
+		return "integer"
+	case "double", "Double", "float", "Float", "BigDecimal":
+		return "number"
+	case "boolean", "Boolean":
+		return "boolean"
+	case "Object", "var":
+		return ""
+	}
+	return "object" // Maps, JsonNode and nested classes
+}
+
+// javaHandlerBodies finds the @RequestBody class and the returned class of a
+// Spring handler method
+func (x *schemaIndex) javaHandlerBodies(ep Endpoint) endpointBodies {
+	var bodies endpointBodies
+
+	text, ok := x.sources[ep.File]
+	if !ok {
+		return bodies
+	}
+	start := lineOffset(text, ep.Line)
+	methodPattern := regexp.MustCompile(`([\w.]+(?:<[^()]*>)?(?:\[\])?)\s+` + regexp.QuoteMeta(ep.Handler) + `\s*\(`)
+	match := methodPattern.FindStringSubmatchIndex(text[start:])
+	if match == nil {
+		return bodies
+	}
+	open := start + match[1] - 1
+	end := matchingParen(text, open)
+	if end < 0 {
+		return bodies
+	}
+
+	if param := javaRequestBodyPattern.FindStringSubmatch(text[open:end]); param != nil {
+		name, array := javaTypeName(param[1])
+		if schema := x.javaClassSchema(name, 0); schema != nil {
+			schema.Array = array
+			bodies.Request = schema
+		}
+	}
+
+	name, array := javaTypeName(text[start+match[2] : start+match[3]])
+	if schema := x.javaClassSchema(name, 0); schema != nil {
+		schema.Array = array
+		bodies.Response = schema
+	}
+
+	return bodies
+}
+
+// lineOffset returns the offset at which a 1-based line starts
+func lineOffset(text string, line int) int {
+	offset := 0
+	for i := 1; i < line; i++ {
+		next := strings.IndexByte(text[offset:], '\n')
+		if next < 0 {
+			return len(text)
+		}
+		offset += next + 1
+	}
+	return offset
+}
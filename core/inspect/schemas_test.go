@@ -0,0 +1,283 @@
+package inspect
+
+import (
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestExampleSchema(t *testing.T) {
+	schema := exampleSchema(`[{"id": 1, "name": "Ada", "score": 9.5, "admin": false, "tags": [], "address": {}}]`)
+	if schema == nil || !schema.Array {
+		t.Fatalf("Expected a list schema, got %+v", schema)
+	}
+	want := []SchemaField{
+		{Name: "address", Type: "object"},
+		{Name: "admin", Type: "boolean"},
+		{Name: "id", Type: "integer"},
+		{Name: "name", Type: "string"},
+		{Name: "score", Type: "number"},
+		{Name: "tags", Type: "array"},
+	}
+	if !reflect.DeepEqual(schema.Fields, want) {
+		t.Errorf("Expected %v, got %v", want, schema.Fields)
+	}
+
+	if exampleSchema(`{"id": ...}`) != nil {
+		t.Error("Expected an invalid example to be skipped")
+	}
+}
+
+func TestPythonTypeName(t *testing.T) {
+	tests := []struct {
+		annotation string
+		want       string
+		array      bool
+	}{
+		{"UserIn", "UserIn", false},
+		{"Optional[schemas.UserIn]", "UserIn", false},
+		{"List[UserOut]", "UserOut", true},
+		{"Annotated[UserIn, Body(embed=True)]", "UserIn", false},
+		{"UserIn | None", "UserIn", false},
+	}
+	for _, tt := range tests {
+		name, array := pythonTypeName(tt.annotation)
+		if name != tt.want || array != tt.array {
+			t.Errorf("pythonTypeName(%q) = %q, %v, want %q, %v", tt.annotation, name, array, tt.want, tt.array)
+		}
+	}
+}
+
+func TestInspect_RequestSchemas(t *testing.T) {
+	rootDir := t.TempDir()
+	writeFiles(t, rootDir, map[string]string{
+		".neev/foundation/api.md": "# API\n",
+		".neev/blueprints/users/openapi.yaml": `openapi: 3.0.0
+info:
+  title: Users
+  version: 1.0.0
+paths:
+  /api/users:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/CreateUser'
+      responses:
+        '201':
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+  /api/orders:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                sku: {type: string}
+                quantity: {type: integer}
+      responses:
+        '200':
+          description: OK
+  /api/invoices:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                amount: {type: number}
+                currency: {type: string}
+      responses:
+        '200':
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Invoice'
+components:
+  schemas:
+    CreateUser:
+      type: object
+      properties:
+        email: {type: string}
+        name: {type: string}
+        role: {type: string}
+    User:
+      allOf:
+        - $ref: '#/components/schemas/CreateUser'
+        - type: object
+          properties:
+            id: {type: integer}
+    Invoice:
+      type: object
+      properties:
+        id: {type: string}
+`,
+		"users/handler.go": `package users
+
+import "github.com/gin-gonic/gin"
+
+type Base struct {
+	ID int ` + "`json:\"id\"`" + `
+}
+
+type Role string
+
+type ErrorResp struct {
+	Message string ` + "`json:\"message\"`" + `
+}
+
+type CreateUserReq struct {
+	Email    string ` + "`json:\"email\"`" + `
+	Name     int    ` + "`json:\"name\"`" + `
+	Role     Role   ` + "`json:\"role\"`" + `
+	Password string ` + "`json:\"-\"`" + `
+	internal string
+}
+
+type UserResp struct {
+	Base
+	Email string ` + "`json:\"email\"`" + `
+	Name  string ` + "`json:\"name\"`" + `
+	Role  Role   ` + "`json:\"role\"`" + `
+}
+
+func Register(r *gin.Engine, h *Handler) {
+	r.POST("/api/users", h.CreateUser)
+}
+
+func (h *Handler) CreateUser(c *gin.Context) {
+	var req CreateUserReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResp{Message: err.Error()})
+		return
+	}
+	c.JSON(201, UserResp{Email: req.Email})
+	c.Status(409).JSON(ErrorResp{})
+}
+`,
+		"orders/api.py": `from pydantic import BaseModel
+
+
+class OrderBase(BaseModel):
+    sku: str
+
+
+class OrderIn(OrderBase):
+    quantity: int
+    coupon: Optional[str] = None
+
+
+@app.post("/api/orders")
+def create_order(order: OrderIn):
+    return {}
+`,
+		"billing/InvoiceController.java": `package billing;
+
+public class InvoiceController {
+    @PostMapping("/api/invoices")
+    public ResponseEntity<InvoiceDto> create(@Valid @RequestBody ChargeRequest request) {
+        return null;
+    }
+}
+
+class ChargeRequest {
+    private static final long serialVersionUID = 1L;
+    private double amount;
+    @JsonProperty("currency")
+    private String currencyCode;
+
+    public double getAmount() {
+        return amount;
+    }
+}
+
+record InvoiceDto(String id) {}
+`,
+	})
+
+	result, err := Inspect(InspectOptions{
+		RootDir:        rootDir,
+		FoundationPath: filepath.Join(rootDir, ".neev", "foundation"),
+		IgnoreDirs:     map[string]bool{},
+		Depth:          2,
+	})
+	if err != nil {
+		t.Fatalf("Inspect failed: %v", err)
+	}
+
+	var got []string
+	for _, w := range result.Warnings {
+		if w.Type == WarningSchemaMismatch {
+			got = append(got, w.Subject)
+			if w.Severity != "warning" || w.Type.Check() != CheckEndpoints {
+				t.Errorf("Expected an endpoints-level warning, got %+v", w)
+			}
+		}
+	}
+	sort.Strings(got)
+	want := []string{
+		"POST /api/invoices response",
+		"POST /api/orders request.coupon",
+		"POST /api/users request.name",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	if result.Summary.SchemaMismatches != 3 {
+		t.Errorf("Expected 3 schema mismatches, got %d", result.Summary.SchemaMismatches)
+	}
+}
+
+func TestInspect_RequestSchemasFromArchitecture(t *testing.T) {
+	rootDir := t.TempDir()
+	writeFiles(t, rootDir, map[string]string{
+		".neev/foundation/api.md": "# API\n",
+		".neev/blueprints/users/architecture.md": "# Users\n\n" +
+			"### POST /api/users\n\nCreate a user\n\n" +
+			"```json\n{\"email\": \"ada@example.com\", \"age\": 36}\n```\n",
+		"users/handler.go": `package users
+
+type CreateUserReq struct {
+	Email string ` + "`json:\"email\"`" + `
+	Age   string ` + "`json:\"age\"`" + `
+}
+
+func Register(r *gin.Engine) {
+	r.POST("/api/users", createUser)
+}
+
+func createUser(c *gin.Context) {
+	c.ShouldBindJSON(&CreateUserReq{})
+}
+`,
+	})
+
+	result, err := Inspect(InspectOptions{
+		RootDir:        rootDir,
+		FoundationPath: filepath.Join(rootDir, ".neev", "foundation"),
+		IgnoreDirs:     map[string]bool{},
+		Depth:          2,
+	})
+	if err != nil {
+		t.Fatalf("Inspect failed: %v", err)
+	}
+
+	var mismatches []Warning
+	for _, w := range result.Warnings {
+		if w.Type == WarningSchemaMismatch {
+			mismatches = append(mismatches, w)
+		}
+	}
+	if len(mismatches) != 1 || mismatches[0].Subject != "POST /api/users request.age" || mismatches[0].Line != 3 {
+		t.Errorf("Expected a type mismatch on age at CreateUserReq, got %+v", mismatches)
+	}
+}
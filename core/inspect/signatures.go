@@ -3,11 +3,12 @@ package inspect
 import (
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strings"
 )
 
-// ValidateFunctionSignatures checks if function signatures match specs
-func ValidateFunctionSignatures(opts InspectOptions, analyzer *PolyglotAnalyzer) ([]Warning, error) {
+// ValidateFunctionSignatures checks if the functions found by a repository scan match specs
+func ValidateFunctionSignatures(opts InspectOptions, scan *ScanResult) ([]Warning, error) {
 	var warnings []Warning
 	
 	// Get foundation modules with descriptors
@@ -29,20 +30,20 @@ func ValidateFunctionSignatures(opts InspectOptions, analyzer *PolyglotAnalyzer)
 		return warnings, nil
 	}
 	
-	// Extract all functions from code
-	allFunctions, err := analyzer.ExtractAllFunctions(opts.RootDir, opts.IgnoreDirs)
-	if err != nil {
-		return warnings, fmt.Errorf("failed to extract functions from code: %w", err)
-	}
-	
 	// Create lookup map by function name
 	funcMap := make(map[string][]FunctionSignature)
-	for _, fn := range allFunctions {
+	for _, fn := range scan.Functions {
 		funcMap[fn.Name] = append(funcMap[fn.Name], fn)
 	}
 	
-	// Validate each expected function
-	for moduleName, descriptor := range descriptors {
+	// Validate each expected function, module by module in a stable order
+	moduleNames := make([]string, 0, len(descriptors))
+	for moduleName := range descriptors {
+		moduleNames = append(moduleNames, moduleName)
+	}
+	sort.Strings(moduleNames)
+	for _, moduleName := range moduleNames {
+		descriptor := descriptors[moduleName]
 		for _, expectedFunc := range descriptor.ExpectedFunctions {
 			// Find matching functions
 			actualFuncs, found := funcMap[expectedFunc.Name]
@@ -51,6 +52,7 @@ func ValidateFunctionSignatures(opts InspectOptions, analyzer *PolyglotAnalyzer)
 				warning := Warning{
 					Type:     WarningMissingFunction,
 					Module:   moduleName,
+					Subject:  expectedFunc.Name,
 					Message:  fmt.Sprintf("Expected function '%s' not found in code", expectedFunc.Name),
 					Severity: "error",
 					Remediation: fmt.Sprintf("Implement function '%s' in %s files or update module descriptor", 
@@ -65,12 +67,20 @@ func ValidateFunctionSignatures(opts InspectOptions, analyzer *PolyglotAnalyzer)
 			for _, actualFunc := range actualFuncs {
 				// Check if language matches (if specified)
 				if expectedFunc.Language != "" {
-					actualLang := string(DetectLanguageByExtension(actualFunc.File))
+					actualLang := actualFunc.Language
+					if actualLang == "" {
+						actualLang = string(DetectLanguageByExtension(actualFunc.File))
+					}
 					if actualLang != expectedFunc.Language {
 						continue
 					}
 				}
 				
+				// Check if receiver matches (if specified)
+				if expectedFunc.Receiver != "" && !receiversMatch(expectedFunc.Receiver, actualFunc.Receiver) {
+					continue
+				}
+				
 				// Check if file pattern matches (if specified)
 				if expectedFunc.FilePattern != "" {
 					matched, _ := filepath.Match(expectedFunc.FilePattern, filepath.Base(actualFunc.File))
@@ -114,12 +124,15 @@ func compareSignatures(moduleName string, expected FunctionSpec, actual Function
 		warning := Warning{
 			Type:     WarningSignatureMismatch,
 			Module:   moduleName,
+			Subject:  actual.Name + " parameters",
 			Message:  fmt.Sprintf("Function '%s' has %d parameters but expected %d (in %s:%d)", 
 				actual.Name, len(actual.Parameters), len(expected.Parameters), 
 				filepath.Base(actual.File), actual.Line),
 			Severity: "warning",
 			Remediation: fmt.Sprintf("Update function signature to match spec: %s", 
 				formatExpectedSignature(expected)),
+			File: actual.File,
+			Line: actual.Line,
 		}
 		warnings = append(warnings, warning)
 	} else {
@@ -135,11 +148,14 @@ func compareSignatures(moduleName string, expected FunctionSpec, actual Function
 				warning := Warning{
 					Type:     WarningSignatureMismatch,
 					Module:   moduleName,
+					Subject:  fmt.Sprintf("%s parameter %d name", actual.Name, i+1),
 					Message:  fmt.Sprintf("Function '%s' parameter %d: name '%s' doesn't match expected '%s' (in %s:%d)", 
 						actual.Name, i+1, actualParam.Name, expectedParam.Name, 
 						filepath.Base(actual.File), actual.Line),
 					Severity: "info",
 					Remediation: fmt.Sprintf("Consider renaming parameter to '%s' for consistency", expectedParam.Name),
+					File: actual.File,
+					Line: actual.Line,
 				}
 				warnings = append(warnings, warning)
 			}
@@ -149,11 +165,14 @@ func compareSignatures(moduleName string, expected FunctionSpec, actual Function
 				warning := Warning{
 					Type:     WarningSignatureMismatch,
 					Module:   moduleName,
+					Subject:  fmt.Sprintf("%s parameter %d type", actual.Name, i+1),
 					Message:  fmt.Sprintf("Function '%s' parameter '%s': type '%s' doesn't match expected '%s' (in %s:%d)", 
 						actual.Name, actualParam.Name, actualParam.Type, expectedParam.Type, 
 						filepath.Base(actual.File), actual.Line),
 					Severity: "warning",
 					Remediation: fmt.Sprintf("Update parameter type to '%s'", expectedParam.Type),
+					File: actual.File,
+					Line: actual.Line,
 				}
 				warnings = append(warnings, warning)
 			}
@@ -165,12 +184,15 @@ func compareSignatures(moduleName string, expected FunctionSpec, actual Function
 		warning := Warning{
 			Type:     WarningSignatureMismatch,
 			Module:   moduleName,
+			Subject:  actual.Name + " returns",
 			Message:  fmt.Sprintf("Function '%s' has %d return values but expected %d (in %s:%d)", 
 				actual.Name, len(actual.Returns), len(expected.Returns), 
 				filepath.Base(actual.File), actual.Line),
 			Severity: "warning",
 			Remediation: fmt.Sprintf("Update return types to match spec: %s", 
 				formatExpectedSignature(expected)),
+			File: actual.File,
+			Line: actual.Line,
 		}
 		warnings = append(warnings, warning)
 	} else {
@@ -185,11 +207,14 @@ func compareSignatures(moduleName string, expected FunctionSpec, actual Function
 				warning := Warning{
 					Type:     WarningSignatureMismatch,
 					Module:   moduleName,
+					Subject:  fmt.Sprintf("%s return %d type", actual.Name, i+1),
 					Message:  fmt.Sprintf("Function '%s' return type %d: '%s' doesn't match expected '%s' (in %s:%d)", 
 						actual.Name, i+1, actualReturn.Type, expectedReturn.Type, 
 						filepath.Base(actual.File), actual.Line),
 					Severity: "warning",
 					Remediation: fmt.Sprintf("Update return type to '%s'", expectedReturn.Type),
+					File: actual.File,
+					Line: actual.Line,
 				}
 				warnings = append(warnings, warning)
 			}
@@ -201,11 +226,14 @@ func compareSignatures(moduleName string, expected FunctionSpec, actual Function
 		warning := Warning{
 			Type:     WarningSignatureMismatch,
 			Module:   moduleName,
+			Subject:  actual.Name + " visibility",
 			Message:  fmt.Sprintf("Function '%s' has visibility '%s' but expected '%s' (in %s:%d)", 
 				actual.Name, actual.Visibility, expected.Visibility, 
 				filepath.Base(actual.File), actual.Line),
 			Severity: "info",
 			Remediation: fmt.Sprintf("Change visibility to '%s' if intended for external use", expected.Visibility),
+			File: actual.File,
+			Line: actual.Line,
 		}
 		warnings = append(warnings, warning)
 	}
@@ -244,6 +272,13 @@ func typesMatch(expected, actual string) bool {
 	return false
 }
 
+// receiversMatch checks if two receiver types match, ignoring pointer receivers
+func receiversMatch(expected, actual string) bool {
+	expected = strings.TrimPrefix(strings.TrimSpace(expected), "*")
+	actual = strings.TrimPrefix(strings.TrimSpace(actual), "*")
+	return expected == actual
+}
+
 // normalizeType normalizes type strings for comparison
 func normalizeType(typ string) string {
 	typ = strings.TrimSpace(typ)
@@ -0,0 +1,423 @@
+package inspect
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Change states of warnings in a --since inspection
+const (
+	ChangeNew         = "new"         // Introduced since the base revision
+	ChangePreexisting = "preexisting" // Already present at the base revision
+)
+
+// ChangedFiles returns the merge base of ref and HEAD, and the files changed
+// since it, relative to rootDir. Uncommitted and untracked files are included.
+// Only the local repository is consulted; nothing is fetched.
+func ChangedFiles(rootDir, ref string) (string, []string, error) {
+	base, err := runGit(rootDir, "merge-base", ref, "HEAD")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to find merge base with '%s': %w", ref, err)
+	}
+	base = strings.TrimSpace(base)
+
+	diff, err := runGit(rootDir, "diff", "--name-only", "--relative", "-z", base, "--", ".")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to diff against '%s': %w", ref, err)
+	}
+	untracked, err := runGit(rootDir, "ls-files", "--others", "--exclude-standard", "-z", "--", ".")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to list untracked files: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var files []string
+	for _, name := range strings.Split(diff+untracked, "\x00") {
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		files = append(files, name)
+	}
+
+	return base, files, nil
+}
+
+// InspectSince inspects the working tree, keeping only warnings in the scope of
+// the files changed since ref, and marks each one as new or pre-existing by
+// comparing it with an inspection of the merge base. The base is not checked
+// out: only the changed files are read at the base and analysed again.
+func InspectSince(opts InspectOptions, ref string) (*InspectResult, error) {
+	base, changed, err := ChangedFiles(opts.RootDir, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	scoped := opts
+	scoped.ChangedFiles = changed
+	if scoped.ChangedFiles == nil {
+		scoped.ChangedFiles = []string{} // Nothing changed: nothing is in scope
+	}
+	in, err := newInspection(scoped)
+	if err != nil {
+		return nil, err
+	}
+	result, err := in.run()
+	if err != nil {
+		return nil, err
+	}
+
+	baseInspection, cleanup, err := in.atRevision(base, changed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect '%s': %w", ref, err)
+	}
+	defer cleanup()
+	baseResult, err := baseInspection.run()
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect '%s': %w", ref, err)
+	}
+
+	existing := make(map[string]bool, len(baseResult.Warnings))
+	for _, w := range baseResult.Warnings {
+		existing[Fingerprint(w)] = true
+	}
+	for i, w := range result.Warnings {
+		if existing[Fingerprint(w)] {
+			result.Warnings[i].Change = ChangePreexisting
+		} else {
+			result.Warnings[i].Change = ChangeNew
+		}
+	}
+
+	result.Summary.Since = ref
+	result.Summary.ChangedFiles = len(changed)
+	summarizeWarnings(result)
+
+	return result, nil
+}
+
+// atRevision derives the inspection of a base revision from one of the working
+// tree, reading only the changed files at the base with git show. The scan is
+// updated for them alone; if specs changed, the foundation and blueprints are
+// read from a temporary copy, which the returned function removes.
+func (in *inspection) atRevision(base string, changed []string) (*inspection, func(), error) {
+	opts := in.opts
+	opts.ChangedFiles = nil
+	cleanup := func() {}
+
+	contents := make(map[string][]byte, len(changed)) // Absolute path -> content at the base; nil if absent there
+	specsChanged := false
+	for _, file := range changed {
+		path := filepath.Join(opts.RootDir, filepath.FromSlash(file))
+		contents[path] = showRevisionFile(opts.RootDir, base, file)
+		specsChanged = specsChanged || pathContains(opts.FoundationPath, path) || pathContains(blueprintsDir(opts), path)
+	}
+
+	if specsChanged {
+		dir, err := os.MkdirTemp("", "neev-since-")
+		if err != nil {
+			return nil, cleanup, fmt.Errorf("failed to create temporary directory: %w", err)
+		}
+		cleanup = func() { os.RemoveAll(dir) }
+
+		foundation, blueprints := filepath.Join(dir, "foundation"), filepath.Join(dir, "blueprints")
+		err = copyRevisionSpecs(opts.FoundationPath, foundation, contents)
+		if err == nil {
+			err = copyRevisionSpecs(blueprintsDir(opts), blueprints, contents)
+		}
+		if err != nil {
+			cleanup()
+			return nil, func() {}, fmt.Errorf("failed to copy specs: %w", err)
+		}
+		opts.FoundationPath, opts.blueprintsPath = foundation, blueprints
+	}
+
+	baseInspection := &inspection{opts: opts, analyzer: in.analyzer, plugins: in.plugins, repoWide: in.repoWide}
+	if err := baseInspection.loadSpecs(); err != nil {
+		cleanup()
+		return nil, func() {}, err
+	}
+	baseInspection.codeModules = revisionCodeModules(opts, baseInspection.codeModules, baseInspection.codePaths, base, contents)
+
+	// Base contents must not replace the cached results of the working tree
+	baseInspection.scanOpts = in.scanOpts
+	baseInspection.scanOpts.Cache = nil
+	baseInspection.scan = in.analyzer.Rescan(in.scan, opts.RootDir, opts.IgnoreDirs, baseInspection.scanOpts, contents)
+	if err := baseInspection.closePlugins(); err != nil {
+		cleanup()
+		return nil, func() {}, err
+	}
+
+	return baseInspection, cleanup, nil
+}
+
+// showRevisionFile returns a root-relative file at a revision, or nil if it
+// did not exist there
+func showRevisionFile(rootDir, revision, file string) []byte {
+	out, err := runGit(rootDir, "show", revision+":./"+filepath.ToSlash(file))
+	if err != nil {
+		return nil
+	}
+	return []byte(out)
+}
+
+// copyRevisionSpecs copies a spec directory of the working tree to dest, then
+// applies the contents of the changed files inside it: nil removes a file
+func copyRevisionSpecs(src, dest string, contents map[string][]byte) error {
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		return err
+	}
+
+	err := filepath.WalkDir(src, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if path == src && os.IsNotExist(err) {
+				return filepath.SkipDir // No such specs in the working tree
+			}
+			return err
+		}
+		target := rebasePath(path, src, dest)
+		if entry.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, 0644)
+	})
+	if err != nil {
+		return err
+	}
+
+	for path, content := range contents {
+		if !pathContains(src, path) {
+			continue
+		}
+		target := rebasePath(path, src, dest)
+		if content == nil {
+			if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(target, content, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// revisionCodeModules adjusts the code modules of the working tree to a base
+// revision: directories added since the base are dropped, and discovered
+// module directories that were removed since then are restored
+func revisionCodeModules(opts InspectOptions, codeModules, codePaths map[string][]string, base string, contents map[string][]byte) map[string][]string {
+	modules := make(map[string][]string)
+	for name, dirs := range codeModules {
+		for _, dir := range dirs {
+			if containsChange(dir, contents) && showRevisionPath(opts.RootDir, base, dir) != nil {
+				continue // Added since the base
+			}
+			modules[name] = append(modules[name], dir)
+		}
+	}
+
+	for path, content := range contents {
+		if content == nil {
+			continue
+		}
+		if _, err := os.Stat(path); err == nil {
+			continue // Not removed
+		}
+		for _, root := range moduleRoots(opts) {
+			if strings.ContainsAny(root, "*?[") {
+				continue
+			}
+			rootDir := filepath.Join(opts.RootDir, filepath.FromSlash(root))
+			if !pathContains(rootDir, path) {
+				continue
+			}
+			rel, _ := filepath.Rel(rootDir, path)
+			name, _, nested := strings.Cut(filepath.ToSlash(rel), "/")
+			dir := filepath.Join(rootDir, name)
+			if _, exists := modules[name]; !nested || exists || codePaths[name] != nil || opts.IgnoreDirs[name] || strings.HasPrefix(name, ".") {
+				break
+			}
+			if _, err := os.Stat(dir); os.IsNotExist(err) {
+				modules[name] = []string{dir}
+			}
+			break
+		}
+	}
+
+	return modules
+}
+
+// containsChange reports whether any changed file lies in dir
+func containsChange(dir string, contents map[string][]byte) bool {
+	for path := range contents {
+		if pathContains(dir, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// showRevisionPath returns an error if a path of the working tree did not
+// exist at a revision
+func showRevisionPath(rootDir, revision, path string) error {
+	rel, err := filepath.Rel(rootDir, path)
+	if err != nil {
+		return err
+	}
+	_, err = runGit(rootDir, "cat-file", "-e", revision+":./"+filepath.ToSlash(rel))
+	return err
+}
+
+// runGit runs a git command in dir and returns its standard output
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s", msg)
+		}
+		return "", err
+	}
+	return string(out), nil
+}
+
+// rebasePath moves a path inside oldRoot to the same place inside newRoot.
+// Paths outside oldRoot are returned unchanged.
+func rebasePath(path, oldRoot, newRoot string) string {
+	if path == "" || !pathContains(oldRoot, path) {
+		return path
+	}
+	rel, err := filepath.Rel(oldRoot, path)
+	if err != nil {
+		return path
+	}
+	return filepath.Join(newRoot, rel)
+}
+
+// changeScope decides which warnings concern a set of changed files
+type changeScope struct {
+	files          map[string]bool // Changed paths relative to the root, with forward slashes
+	modules        map[string]bool // Modules whose code or spec changed
+	codeChanged    bool            // A source file of a detected language changed
+	contractChange bool            // An API spec, .proto, feature or SQL file changed
+}
+
+// newChangeScope maps changed files to the modules they affect
+func newChangeScope(opts InspectOptions, codeModules map[string][]string) *changeScope {
+	scope := &changeScope{
+		files:   make(map[string]bool),
+		modules: make(map[string]bool),
+	}
+
+	foundationRel := relativeModuleDir(opts.RootDir, opts.FoundationPath)
+	for _, file := range opts.ChangedFiles {
+		file = filepath.ToSlash(file)
+		scope.files[file] = true
+
+		if isSourceFile(opts, file) {
+			scope.codeChanged = true
+		}
+		if strings.HasPrefix(file, ".neev/") || strings.HasSuffix(file, ".feature") || strings.HasSuffix(file, ".proto") ||
+			strings.HasSuffix(file, ".sql") {
+			scope.contractChange = true
+		}
+
+		// Foundation specs and descriptors affect their module
+		if strings.HasPrefix(file, foundationRel+"/") {
+			name := strings.TrimPrefix(file, foundationRel+"/")
+			name = strings.TrimSuffix(strings.TrimSuffix(name, ".module.yaml"), ".md")
+			scope.modules[name] = true
+		}
+
+		// Code files affect the module whose directory contains them
+		abs := filepath.Join(opts.RootDir, filepath.FromSlash(file))
+		for module, dirs := range codeModules {
+			for _, dir := range dirs {
+				if pathContains(dir, abs) {
+					scope.modules[module] = true
+				}
+			}
+		}
+	}
+
+	return scope
+}
+
+// repoWide reports whether repository-wide findings can concern the changes:
+// a source file or contract changed, a file that one of the extractors reads
+// changed, or the spec of a module such findings are reported against
+func (s *changeScope) repoWide(rootDir string, extractors []FileExtractor) bool {
+	if s.codeChanged || s.contractChange {
+		return true
+	}
+	for _, module := range checkModules {
+		if s.modules[module] {
+			return true
+		}
+	}
+	for file := range s.files {
+		path := filepath.Join(rootDir, filepath.FromSlash(file))
+		for _, extractor := range extractors {
+			if extractor.Match(path) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// affects returns a filter for the files that changed or lie in an affected module
+func (s *changeScope) affects(rootDir string, codeModules map[string][]string) func(path string) bool {
+	var dirs []string
+	for module := range s.modules {
+		dirs = append(dirs, codeModules[module]...)
+	}
+	return func(path string) bool {
+		if s.files[relativeModuleDir(rootDir, path)] {
+			return true
+		}
+		for _, dir := range dirs {
+			if pathContains(dir, path) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// includes reports whether a warning concerns the changed files
+func (s *changeScope) includes(w Warning, rootDir string) bool {
+	if w.File != "" {
+		if s.files[relativeModuleDir(rootDir, w.File)] {
+			return true
+		}
+	}
+	if s.modules[w.Module] {
+		return true
+	}
+
+	// API-level findings depend on every source file and contract
+	switch w.Type.Check() {
+	case CheckEndpoints, CheckTests, CheckEvents, CheckConfig:
+		return s.codeChanged || s.contractChange
+	case CheckDataModel, CheckStack:
+		return s.contractChange
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+package inspect
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// gitRepo initialises a git repository with a base commit for --since tests
+func gitRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+
+	dir := t.TempDir()
+	writeFiles(t, dir, files)
+	git(t, dir, "init", "-q")
+	git(t, dir, "add", "-A")
+	git(t, dir, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "base")
+	return dir
+}
+
+func git(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	if _, err := runGit(dir, args...); err != nil {
+		t.Fatalf("git %v failed: %v", args, err)
+	}
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestChangedFiles(t *testing.T) {
+	dir := gitRepo(t, map[string]string{
+		"auth/auth.go": "package auth\n",
+		"README.md":    "# Project\n",
+	})
+	writeFiles(t, dir, map[string]string{
+		"auth/auth.go":     "package auth\n\nfunc Login() {}\n",
+		"orders/orders.go": "package orders\n",
+	})
+
+	base, files, err := ChangedFiles(dir, "HEAD")
+	if err != nil {
+		t.Fatalf("ChangedFiles failed: %v", err)
+	}
+	if base == "" {
+		t.Error("Expected a merge base")
+	}
+
+	sort.Strings(files)
+	if want := []string{"auth/auth.go", "orders/orders.go"}; !reflect.DeepEqual(files, want) {
+		t.Errorf("Expected %v, got %v", want, files)
+	}
+
+	if _, _, err := ChangedFiles(dir, "no-such-ref"); err == nil {
+		t.Error("Expected an error for an unknown ref")
+	}
+}
+
+func TestInspectSince(t *testing.T) {
+	dir := gitRepo(t, map[string]string{
+		".neev/foundation/auth.md":    "# Auth\n",
+		".neev/foundation/billing.md": "# Billing\n",
+		"auth/auth.go":                "package auth\n",
+		"legacy/legacy.go":            "package legacy\n",
+	})
+
+	// billing was already missing; orders is new and missing too
+	writeFiles(t, dir, map[string]string{
+		".neev/foundation/billing.md": "# Billing\n\nInvoices.\n",
+		".neev/foundation/orders.md":  "# Orders\n",
+	})
+
+	result, err := InspectSince(InspectOptions{
+		RootDir:        dir,
+		FoundationPath: filepath.Join(dir, ".neev", "foundation"),
+		IgnoreDirs:     map[string]bool{".neev": true},
+	}, "HEAD")
+	if err != nil {
+		t.Fatalf("InspectSince failed: %v", err)
+	}
+
+	changes := make(map[string]string)
+	for _, w := range result.Warnings {
+		changes[w.Module] = w.Change
+	}
+	want := map[string]string{"billing": ChangePreexisting, "orders": ChangeNew}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("Expected %v, got %v (legacy is untouched and out of scope)", want, changes)
+	}
+
+	if result.Summary.Since != "HEAD" || result.Summary.ChangedFiles != 2 {
+		t.Errorf("Unexpected since summary: %+v", result.Summary)
+	}
+	if result.Summary.NewWarnings != 1 || result.Summary.PreexistingWarnings != 1 {
+		t.Errorf("Expected 1 new and 1 pre-existing warning, got %d and %d",
+			result.Summary.NewWarnings, result.Summary.PreexistingWarnings)
+	}
+
+	// Only the new warning fails a gate
+	result.Warnings = result.Warnings[:0]
+	result.Warnings = append(result.Warnings, Warning{Severity: SeverityError, Change: ChangePreexisting})
+	if result.ExceedsThreshold(SeverityError) {
+		t.Error("Pre-existing warnings should not exceed the threshold")
+	}
+}
+
+func TestInspectSince_CodeModules(t *testing.T) {
+	dir := gitRepo(t, map[string]string{
+		".neev/foundation/auth.md": "# Auth\n",
+		"auth/auth.go":             "package auth\n",
+		"legacy/legacy.go":         "package legacy\n",
+		"reports/reports.go":       "package reports\n",
+	})
+
+	// legacy was already undocumented; payments is a new undocumented module,
+	// and reports was removed, which the base still has
+	writeFiles(t, dir, map[string]string{
+		"legacy/legacy.go":     "package legacy\n\nfunc Run() {}\n",
+		"payments/payments.go": "package payments\n",
+	})
+	os.RemoveAll(filepath.Join(dir, "reports"))
+
+	result, err := InspectSince(InspectOptions{
+		RootDir:        dir,
+		FoundationPath: filepath.Join(dir, ".neev", "foundation"),
+		IgnoreDirs:     map[string]bool{".neev": true},
+	}, "HEAD")
+	if err != nil {
+		t.Fatalf("InspectSince failed: %v", err)
+	}
+
+	changes := make(map[string]string)
+	for _, w := range result.Warnings {
+		changes[w.Module] = w.Change
+	}
+	want := map[string]string{"legacy": ChangePreexisting, "payments": ChangeNew}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("Expected %v, got %v", want, changes)
+	}
+}
@@ -0,0 +1,182 @@
+package inspect
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/neev-kit/neev/core/frontmatter"
+)
+
+// blueprintsDir returns the directory holding the project's blueprints
+func blueprintsDir(opts InspectOptions) string {
+	if opts.blueprintsPath != "" {
+		return opts.blueprintsPath
+	}
+	return filepath.Join(opts.RootDir, ".neev", "blueprints")
+}
+
+// specFiles returns the files below the spec directories for which match
+// returns true, directory by directory in lexical order. Archive directories
+// and blueprints whose front matter marks them archived are skipped.
+func specFiles(opts InspectOptions, dirs []string, match func(path string) bool) []string {
+	var files []string
+	blueprints := blueprintsDir(opts)
+
+	for _, dir := range dirs {
+		if dir == "" {
+			continue
+		}
+		filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return nil
+			}
+			if info.IsDir() {
+				if info.Name() == "archive" || (filepath.Dir(path) == blueprints && blueprintMetadata(path).Archived()) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if match(path) {
+				files = append(files, path)
+			}
+			return nil
+		})
+	}
+
+	return files
+}
+
+// blueprintMetadata returns the front matter of a blueprint, declared in its intent.md
+func blueprintMetadata(dir string) frontmatter.Metadata {
+	meta, _, err := frontmatter.ParseFile(filepath.Join(dir, "intent.md"))
+	if err != nil {
+		return frontmatter.Metadata{}
+	}
+	return meta
+}
+
+// assignOwners sets the owners of each warning from front matter: those of
+// its foundation module, else those of the module whose code contains its
+// file, else those of the blueprint its file belongs to
+func assignOwners(opts InspectOptions, warnings []Warning, foundationModules map[string]frontmatter.Metadata, codeModules map[string][]string) {
+	blueprints := blueprintsDir(opts)
+	blueprintOwners := make(map[string][]string)
+
+	for i, w := range warnings {
+		if owners := foundationModules[w.Module].Owners; len(owners) > 0 {
+			warnings[i].Owners = owners
+			continue
+		}
+		if w.File == "" {
+			continue
+		}
+		if owners := codeOwners(w.File, foundationModules, codeModules); len(owners) > 0 {
+			warnings[i].Owners = owners
+			continue
+		}
+		if !pathContains(blueprints, w.File) {
+			continue
+		}
+		rel, err := filepath.Rel(blueprints, w.File)
+		if err != nil || !strings.Contains(filepath.ToSlash(rel), "/") {
+			continue
+		}
+		dir := filepath.Join(blueprints, strings.SplitN(filepath.ToSlash(rel), "/", 2)[0])
+		owners, ok := blueprintOwners[dir]
+		if !ok {
+			owners = blueprintMetadata(dir).Owners
+			blueprintOwners[dir] = owners
+		}
+		warnings[i].Owners = owners
+	}
+}
+
+// codeOwners returns the owners of the foundation module whose code contains
+// file, preferring the innermost code directory
+func codeOwners(file string, foundationModules map[string]frontmatter.Metadata, codeModules map[string][]string) []string {
+	var owners []string
+	innermost := ""
+	for module, meta := range foundationModules {
+		if len(meta.Owners) == 0 {
+			continue
+		}
+		for _, dir := range codeModules[module] {
+			if pathContains(dir, file) && len(dir) > len(innermost) {
+				owners, innermost = meta.Owners, dir
+			}
+		}
+	}
+	return owners
+}
+
+// moduleLanguages maps the code directories of foundation modules that declare
+// languages in their front matter to those languages
+func moduleLanguages(codeModules map[string][]string, foundationModules map[string]frontmatter.Metadata) map[string][]string {
+	languages := make(map[string][]string)
+	for module, meta := range foundationModules {
+		if len(meta.Languages) == 0 {
+			continue
+		}
+		for _, dir := range codeModules[module] {
+			languages[dir] = meta.Languages
+		}
+	}
+	return languages
+}
+
+// contractDiff compares the items a contract declares with those found in
+// code. It returns, in input order and once per key, the declared items that no
+// item of code matches and the items of code that no declared item matches.
+// Items match when their keys are equal or, if match is set, when it says so.
+// A contract that declares nothing is not checked.
+func contractDiff[T any](declared, found []T, key func(T) string, match func(declared, found string) bool) (missing, undeclared []T) {
+	if len(declared) == 0 {
+		return nil, nil
+	}
+
+	declaredKeys := make(map[string]bool, len(declared))
+	for _, item := range declared {
+		declaredKeys[key(item)] = true
+	}
+	foundKeys := make(map[string]bool, len(found))
+	for _, item := range found {
+		foundKeys[key(item)] = true
+	}
+
+	// matched reports whether a key of one side matches any key of the other
+	matched := func(k string, others map[string]bool, isDeclared bool) bool {
+		if others[k] {
+			return true
+		}
+		if match == nil {
+			return false
+		}
+		for other := range others {
+			if isDeclared && match(k, other) || !isDeclared && match(other, k) {
+				return true
+			}
+		}
+		return false
+	}
+
+	reported := make(map[string]bool)
+	for _, item := range declared {
+		if k := key(item); !reported[k] {
+			reported[k] = true
+			if !matched(k, foundKeys, true) {
+				missing = append(missing, item)
+			}
+		}
+	}
+	reported = make(map[string]bool)
+	for _, item := range found {
+		if k := key(item); !reported[k] {
+			reported[k] = true
+			if !matched(k, declaredKeys, false) {
+				undeclared = append(undeclared, item)
+			}
+		}
+	}
+	return missing, undeclared
+}
@@ -0,0 +1,406 @@
+package inspect
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/neev-kit/neev/core/frontmatter"
+	"gopkg.in/yaml.v3"
+)
+
+// StackDeclaration is the structured part of the foundation's technology
+// stack, read from stack.yaml or the front matter of stack.md
+type StackDeclaration struct {
+	Allowed   []StackLibrary `yaml:"allowed,omitempty"`
+	Forbidden []StackLibrary `yaml:"forbidden,omitempty"`
+	Strict    bool           `yaml:"strict,omitempty"` // Report every dependency that is not allowed, not only known frameworks
+	File      string         `yaml:"-"`
+}
+
+// StackLibrary is a library of the stack declaration
+type StackLibrary struct {
+	Name    string `yaml:"name"`              // Package name; * matches any characters
+	Version string `yaml:"version,omitempty"` // Version range, e.g. ">=1.9 <2" or "^4.18"
+	Reason  string `yaml:"reason,omitempty"`  // Why a library is forbidden
+}
+
+// IsZero reports whether the declaration lists no libraries
+func (d StackDeclaration) IsZero() bool {
+	return len(d.Allowed) == 0 && len(d.Forbidden) == 0
+}
+
+// stackCategories names well-known HTTP frameworks and ORMs. Once a stack
+// declares allowed libraries, these are reported when they are not among them.
+var stackCategories = map[string]string{
+	// Go (module paths without their /vN suffix)
+	"github.com/gin-gonic/gin":          "HTTP framework",
+	"github.com/labstack/echo":          "HTTP framework",
+	"github.com/gofiber/fiber":          "HTTP framework",
+	"github.com/go-chi/chi":             "HTTP framework",
+	"github.com/gorilla/mux":            "HTTP framework",
+	"github.com/beego/beego":            "HTTP framework",
+	"github.com/valyala/fasthttp":       "HTTP framework",
+	"gorm.io/gorm":                      "ORM",
+	"github.com/jinzhu/gorm":            "ORM",
+	"entgo.io/ent":                      "ORM",
+	"github.com/uptrace/bun":            "ORM",
+	"github.com/volatiletech/sqlboiler": "ORM",
+	"xorm.io/xorm":                      "ORM",
+	// npm
+	"express":         "HTTP framework",
+	"fastify":         "HTTP framework",
+	"koa":             "HTTP framework",
+	"@hapi/hapi":      "HTTP framework",
+	"@nestjs/core":    "HTTP framework",
+	"restify":         "HTTP framework",
+	"typeorm":         "ORM",
+	"sequelize":       "ORM",
+	"prisma":          "ORM",
+	"@prisma/client":  "ORM",
+	"mongoose":        "ORM",
+	"drizzle-orm":     "ORM",
+	"@mikro-orm/core": "ORM",
+	"objection":       "ORM",
+	"bookshelf":       "ORM",
+	// PyPI
+	"django":       "HTTP framework",
+	"flask":        "HTTP framework",
+	"fastapi":      "HTTP framework",
+	"tornado":      "HTTP framework",
+	"pyramid":      "HTTP framework",
+	"sanic":        "HTTP framework",
+	"falcon":       "HTTP framework",
+	"sqlalchemy":   "ORM",
+	"peewee":       "ORM",
+	"tortoise-orm": "ORM",
+	"pony":         "ORM",
+	"sqlmodel":     "ORM",
+	"mongoengine":  "ORM",
+	// Maven
+	"org.springframework.boot:spring-boot-starter-web":      "HTTP framework",
+	"org.springframework.boot:spring-boot-starter-webflux":  "HTTP framework",
+	"io.quarkus:quarkus-resteasy":                           "HTTP framework",
+	"io.quarkus:quarkus-resteasy-reactive":                  "HTTP framework",
+	"io.micronaut:micronaut-http-server-netty":              "HTTP framework",
+	"io.javalin:javalin":                                    "HTTP framework",
+	"com.sparkjava:spark-core":                              "HTTP framework",
+	"io.dropwizard:dropwizard-core":                         "HTTP framework",
+	"org.hibernate:hibernate-core":                          "ORM",
+	"org.hibernate.orm:hibernate-core":                      "ORM",
+	"org.springframework.boot:spring-boot-starter-data-jpa": "ORM",
+	"org.mybatis:mybatis":                                   "ORM",
+	"org.jooq:jooq":                                         "ORM",
+	"org.jdbi:jdbi3-core":                                   "ORM",
+	// RubyGems
+	"rails":        "HTTP framework",
+	"sinatra":      "HTTP framework",
+	"hanami":       "HTTP framework",
+	"grape":        "HTTP framework",
+	"roda":         "HTTP framework",
+	"activerecord": "ORM",
+	"sequel":       "ORM",
+	"mongoid":      "ORM",
+}
+
+// goMajorSuffixPattern matches the major version suffix of a Go module path
+var goMajorSuffixPattern = regexp.MustCompile(`/v\d+$`)
+
+// ValidateStack checks the dependencies of the manifests found by a repository
+// scan against the allowed and forbidden libraries of the stack declaration
+func ValidateStack(scan *ScanResult, declaration StackDeclaration) ([]Warning, error) {
+	var warnings []Warning
+
+	// If no stack is declared, nothing to validate
+	if declaration.IsZero() {
+		return warnings, nil
+	}
+
+	return compareStack(declaration, FindDependencies(scan)), nil
+}
+
+// LoadStackDeclaration reads stack.yaml (or stack.yml) from the foundation,
+// falling back to the front matter of stack.md. A missing declaration is empty.
+func LoadStackDeclaration(foundationPath string) (StackDeclaration, error) {
+	var declaration StackDeclaration
+
+	for _, name := range []string{"stack.yaml", "stack.yml", "stack.md"} {
+		path := filepath.Join(foundationPath, name)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if name == "stack.md" {
+			err = frontmatter.Decode(data, &declaration)
+		} else {
+			err = yaml.Unmarshal(data, &declaration)
+		}
+		if err != nil {
+			return StackDeclaration{}, fmt.Errorf("failed to parse stack declaration %s: %w", name, err)
+		}
+		declaration.File = path
+		return declaration, nil
+	}
+
+	return declaration, nil
+}
+
+// HasStackDeclaration reports whether the foundation declares a stack, which
+// only the structured inspection checks. Unreadable declarations count, so
+// that their error is reported.
+func HasStackDeclaration(foundationPath string) bool {
+	declaration, err := LoadStackDeclaration(foundationPath)
+	return err != nil || !declaration.IsZero()
+}
+
+// compareStack reports forbidden dependencies, allowed dependencies outside
+// their version range and dependencies the declaration does not approve
+func compareStack(declaration StackDeclaration, dependencies []Dependency) []Warning {
+	var warnings []Warning
+	source := filepath.Base(declaration.File)
+
+	reported := make(map[string]bool)
+	for _, dep := range dependencies {
+		key := dep.File + " " + dep.Name
+		if reported[key] {
+			continue
+		}
+		reported[key] = true
+
+		if library, ok := matchStackLibrary(declaration.Forbidden, dep, true); ok {
+			message := fmt.Sprintf("%s depends on '%s', which %s forbids", filepath.Base(dep.File), dep.Name, source)
+			if library.Reason != "" {
+				message += ": " + library.Reason
+			}
+			warnings = append(warnings, Warning{
+				Type:        WarningForbiddenDependency,
+				Module:      "stack",
+				Subject:     dep.Name,
+				Message:     message,
+				Severity:    "error",
+				Remediation: fmt.Sprintf("Remove '%s' or update the forbidden libraries in %s", dep.Name, source),
+				File:        dep.File,
+				Line:        dep.Line,
+			})
+			continue
+		}
+
+		library, allowed := matchStackLibrary(declaration.Allowed, dep, false)
+		if allowed {
+			if library.Version != "" && dep.Version != "" && !versionSatisfies(dep.Version, library.Version) {
+				warnings = append(warnings, Warning{
+					Type:        WarningDependencyVersion,
+					Module:      "stack",
+					Subject:     dep.Name,
+					Message:     fmt.Sprintf("'%s' %s in %s is outside the approved range %s", dep.Name, dep.Version, filepath.Base(dep.File), library.Version),
+					Severity:    "warning",
+					Remediation: fmt.Sprintf("Use a version of '%s' matching %s or widen the range in %s", dep.Name, library.Version, source),
+					File:        dep.File,
+					Line:        dep.Line,
+				})
+			}
+			continue
+		}
+
+		category := stackCategories[normalizeLibraryName(dep.Name)]
+		if !declaration.Strict && (category == "" || len(declaration.Allowed) == 0) {
+			continue
+		}
+		kind := "Library"
+		if category != "" {
+			kind = category
+		}
+		warnings = append(warnings, Warning{
+			Type:        WarningUnapprovedDependency,
+			Module:      "stack",
+			Subject:     dep.Name,
+			Message:     fmt.Sprintf("%s '%s' in %s is not in the approved stack", kind, dep.Name, filepath.Base(dep.File)),
+			Severity:    "warning",
+			Remediation: fmt.Sprintf("Use an approved library or add '%s' to the allowed libraries in %s", dep.Name, source),
+			File:        dep.File,
+			Line:        dep.Line,
+		})
+	}
+
+	return warnings
+}
+
+// matchStackLibrary returns the first library whose name matches the
+// dependency. Forbidden libraries with a version range only match versions
+// inside it.
+func matchStackLibrary(libraries []StackLibrary, dep Dependency, forbidden bool) (StackLibrary, bool) {
+	name := normalizeLibraryName(dep.Name)
+	for _, library := range libraries {
+		if !libraryNameMatches(normalizeLibraryName(library.Name), name) {
+			continue
+		}
+		if forbidden && library.Version != "" && (dep.Version == "" || !versionSatisfies(dep.Version, library.Version)) {
+			continue
+		}
+		return library, true
+	}
+	return StackLibrary{}, false
+}
+
+// normalizeLibraryName lower-cases a name, treats Python's -, _ and . alike
+// and drops the major version suffix of Go module paths
+func normalizeLibraryName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = goMajorSuffixPattern.ReplaceAllString(name, "")
+	if !strings.ContainsAny(name, "/:@") {
+		name = strings.NewReplacer("_", "-", ".", "-").Replace(name)
+	}
+	return name
+}
+
+// libraryNameMatches matches a name against a pattern where * matches any characters
+func libraryNameMatches(pattern, name string) bool {
+	if !strings.Contains(pattern, "*") {
+		return pattern == name
+	}
+	expr := "^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*") + "$"
+	return regexp.MustCompile(expr).MatchString(name)
+}
+
+// versionSatisfies reports whether a version is inside a range. Ranges are
+// alternatives separated by "||", each a list of comparators separated by
+// spaces or commas: >=, <=, >, <, =, ==, !=, ^ and ~ (npm), ~> (RubyGems),
+// ~= (PEP 440), and bare versions, where 1.2 and 1.2.x match any 1.2 release.
+func versionSatisfies(version, constraint string) bool {
+	v := parseVersion(version)
+	if v == nil {
+		return true // Versions we can't read are not reported
+	}
+
+	for _, alternative := range strings.Split(constraint, "||") {
+		comparators := strings.FieldsFunc(alternative, func(r rune) bool { return r == ' ' || r == ',' })
+		// Join operators written apart from their version, e.g. ">= 1.2"
+		var joined []string
+		for i := 0; i < len(comparators); i++ {
+			if strings.Trim(comparators[i], "<>=!~^") == "" && i+1 < len(comparators) {
+				joined = append(joined, comparators[i]+comparators[i+1])
+				i++
+				continue
+			}
+			joined = append(joined, comparators[i])
+		}
+
+		satisfied := true
+		for _, comparator := range joined {
+			if !versionComparatorSatisfied(v, comparator) {
+				satisfied = false
+				break
+			}
+		}
+		if satisfied {
+			return true
+		}
+	}
+	return false
+}
+
+// versionComparatorSatisfied checks a version against one comparator
+func versionComparatorSatisfied(v []int, comparator string) bool {
+	operand := strings.TrimLeft(comparator, "<>=!~^")
+	operator := comparator[:len(comparator)-len(operand)]
+	if operand == "" || operand == "*" || strings.EqualFold(operand, "x") {
+		return true
+	}
+
+	bound := parseVersion(operand)
+	if bound == nil {
+		return true
+	}
+	// Number of parts given, before any wildcard: "1.2" and "1.2.x" give 2
+	given := 0
+	for _, part := range strings.Split(operand, ".") {
+		if part == "x" || part == "X" || part == "*" {
+			break
+		}
+		given++
+	}
+
+	switch operator {
+	case ">=":
+		return compareVersions(v, bound) >= 0
+	case ">":
+		return compareVersions(v, bound) > 0
+	case "<=":
+		return compareVersions(v, bound) <= 0
+	case "<":
+		return compareVersions(v, bound) < 0
+	case "!=":
+		return !versionHasPrefix(v, bound, given)
+	case "^":
+		// Same leftmost non-zero part
+		upper := make([]int, 3)
+		for i := 0; i < 3; i++ {
+			if bound[i] != 0 || i == given-1 || i == 2 {
+				upper[i] = bound[i] + 1
+				break
+			}
+		}
+		return compareVersions(v, bound) >= 0 && compareVersions(v, upper) < 0
+	case "~":
+		// Same minor if given, else same major
+		return compareVersions(v, bound) >= 0 && versionHasPrefix(v, bound, min(given, 2))
+	case "~>", "~=":
+		// All but the last given part must match
+		return compareVersions(v, bound) >= 0 && versionHasPrefix(v, bound, max(given-1, 1))
+	default: // "", "=", "=="
+		return versionHasPrefix(v, bound, given)
+	}
+}
+
+// parseVersion parses the numeric parts of a version, padded to three.
+// Pre-release and build suffixes are ignored.
+func parseVersion(version string) []int {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if end := strings.IndexAny(version, "-+ "); end >= 0 {
+		version = version[:end]
+	}
+
+	parts := []int{0, 0, 0}
+	for i, part := range strings.Split(version, ".") {
+		if part == "x" || part == "X" || part == "*" {
+			break
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			if i == 0 {
+				return nil
+			}
+			break
+		}
+		if i < 3 {
+			parts[i] = n
+		}
+	}
+	return parts
+}
+
+// compareVersions compares two parsed versions
+func compareVersions(a, b []int) int {
+	for i := 0; i < 3; i++ {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// versionHasPrefix reports whether the first n parts of two versions are equal
+func versionHasPrefix(v, prefix []int, n int) bool {
+	for i := 0; i < n && i < 3; i++ {
+		if v[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,248 @@
+package inspect
+
+import (
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestVersionSatisfies(t *testing.T) {
+	tests := []struct {
+		version, constraint string
+		want                bool
+	}{
+		{"1.9.1", ">=1.9 <2", true},
+		{"2.0.0", ">=1.9 <2", false},
+		{"1.9.1", ">= 1.9, < 2", true},
+		{"4.18.2", "^4.17", true},
+		{"5.0.0", "^4.17", false},
+		{"0.2.5", "^0.2.3", true},
+		{"0.3.0", "^0.2.3", false},
+		{"1.2.9", "~1.2.3", true},
+		{"1.3.0", "~1.2.3", false},
+		{"7.1.3", "~> 7.1", true},
+		{"8.0.0", "~> 7.1", false},
+		{"2.0.30", "~=2.0.1", true},
+		{"1.5.0", "1.x", true},
+		{"2.5.0", "1.x || 2.x", true},
+		{"3.0.0", "1.x || 2.x", false},
+		{"1.4.0", "!=1.4", false},
+		{"v1.10.0-rc1", ">=1.9", true},
+	}
+	for _, tt := range tests {
+		if got := versionSatisfies(tt.version, tt.constraint); got != tt.want {
+			t.Errorf("versionSatisfies(%q, %q) = %v, want %v", tt.version, tt.constraint, got, tt.want)
+		}
+	}
+}
+
+func TestParseManifests(t *testing.T) {
+	tests := []struct {
+		name  string
+		parse func(string, string) []Dependency
+		text  string
+		want  []string
+	}{
+		{
+			name:  "go.mod",
+			parse: ParseGoMod,
+			text: `module example.com/app
+
+go 1.22
+
+require github.com/gin-gonic/gin v1.9.1
+
+require (
+	gorm.io/gorm v1.25.5
+	golang.org/x/text v0.14.0 // indirect
+)
+`,
+			want: []string{"github.com/gin-gonic/gin 1.9.1 5", "gorm.io/gorm 1.25.5 8"},
+		},
+		{
+			name:  "package.json",
+			parse: ParsePackageJSON,
+			text: `{
+  "name": "app",
+  "dependencies": {
+    "express": "^4.18.2"
+  },
+  "devDependencies": {
+    "jest": "~29.7.0"
+  }
+}`,
+			want: []string{"express 4.18.2 4", "jest 29.7.0 7"},
+		},
+		{
+			name:  "requirements.txt",
+			parse: ParseRequirements,
+			text:  "# Web\nDjango==4.2.7\nrequests>=2.31\n-r base.txt\n",
+			want:  []string{"Django 4.2.7 2", "requests 2.31 3"},
+		},
+		{
+			name:  "pyproject.toml",
+			parse: ParsePyproject,
+			text: `[project]
+name = "app"
+dependencies = [
+    "fastapi>=0.110",
+    "sqlalchemy[asyncio]==2.0.25",
+]
+
+[tool.poetry.dependencies]
+python = "^3.11"
+peewee = "^3.17"
+`,
+			want: []string{"fastapi 0.110 4", "sqlalchemy 2.0.25 5", "peewee 3.17 10"},
+		},
+		{
+			name:  "pom.xml",
+			parse: ParsePOM,
+			text: `<project>
+  <properties>
+    <hibernate.version>6.4.1.Final</hibernate.version>
+  </properties>
+  <dependencies>
+    <dependency>
+      <groupId>org.hibernate.orm</groupId>
+      <artifactId>hibernate-core</artifactId>
+      <version>${hibernate.version}</version>
+    </dependency>
+  </dependencies>
+</project>
+`,
+			want: []string{"org.hibernate.orm:hibernate-core 6.4.1 8"},
+		},
+		{
+			name:  "Gemfile.lock",
+			parse: ParseGemfileLock,
+			text: `GEM
+  remote: https://rubygems.org/
+  specs:
+    rack (3.0.8)
+    sinatra (4.0.0)
+      rack (>= 3.0.0)
+
+PLATFORMS
+  ruby
+
+DEPENDENCIES
+  sinatra (~> 4.0)
+`,
+			want: []string{"sinatra 4.0.0 12"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, dep := range tt.parse(tt.name, tt.text) {
+				got = append(got, dep.Name+" "+dep.Version+" "+strconv.Itoa(dep.Line))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestInspect_Stack(t *testing.T) {
+	rootDir := t.TempDir()
+	writeFiles(t, rootDir, map[string]string{
+		".neev/foundation/stack.md": `---
+allowed:
+  - name: github.com/go-chi/chi
+    version: ">=5 <6"
+  - name: express
+    version: ^4
+forbidden:
+  - name: github.com/jinzhu/gorm
+    reason: unmaintained, use sqlc
+---
+# Technology Stack
+
+We use Go and PostgreSQL.
+`,
+		"go.mod": `module example.com/app
+
+go 1.22
+
+require (
+	github.com/go-chi/chi/v5 v5.0.12
+	github.com/jinzhu/gorm v1.9.16
+	github.com/gin-gonic/gin v1.9.1
+	github.com/google/uuid v1.6.0
+)
+`,
+		"web/package.json":                  "{\n  \"dependencies\": {\n    \"express\": \"^3.21.2\",\n    \"prisma\": \"^5.0.0\"\n  }\n}\n",
+		"web/node_modules/koa/package.json": "{\n  \"dependencies\": {\n    \"koa-compose\": \"^4.1.0\"\n  }\n}\n",
+	})
+
+	result, err := Inspect(InspectOptions{
+		RootDir:        rootDir,
+		FoundationPath: filepath.Join(rootDir, ".neev", "foundation"),
+		IgnoreDirs:     map[string]bool{},
+		Depth:          1,
+	})
+	if err != nil {
+		t.Fatalf("Inspect failed: %v", err)
+	}
+
+	bySubject := make(map[string]Warning)
+	for _, w := range result.Warnings {
+		if w.Type.Check() == CheckStack {
+			bySubject[w.Subject] = w
+		}
+	}
+	if w, ok := bySubject["github.com/jinzhu/gorm"]; !ok || w.Type != WarningForbiddenDependency || w.Severity != "error" || w.Line != 7 {
+		t.Errorf("Expected jinzhu/gorm to be forbidden, got %+v", w)
+	}
+	if w, ok := bySubject["github.com/gin-gonic/gin"]; !ok || w.Type != WarningUnapprovedDependency || w.Module != "stack" {
+		t.Errorf("Expected gin to be an unapproved HTTP framework, got %+v", w)
+	}
+	if w, ok := bySubject["prisma"]; !ok || w.Type != WarningUnapprovedDependency || filepath.Base(w.File) != "package.json" {
+		t.Errorf("Expected prisma to be an unapproved ORM, got %+v", w)
+	}
+	if w, ok := bySubject["express"]; !ok || w.Type != WarningDependencyVersion || w.Line != 3 {
+		t.Errorf("Expected express to be outside its range, got %+v", w)
+	}
+	if len(bySubject) != 4 {
+		t.Errorf("Expected 4 stack warnings, got %v", bySubject)
+	}
+	if result.Summary.ForbiddenDependencies != 1 || result.Summary.UnapprovedDependencies != 2 || result.Summary.DependencyVersionMismatches != 1 {
+		t.Errorf("Unexpected summary %+v", result.Summary)
+	}
+
+	ran := false
+	for _, check := range result.Checks {
+		ran = ran || check == CheckStack
+	}
+	if !ran {
+		t.Errorf("Expected the stack check to run, got %v", result.Checks)
+	}
+}
+
+func TestLoadStackDeclaration(t *testing.T) {
+	rootDir := t.TempDir()
+	writeFiles(t, rootDir, map[string]string{
+		"stack.yaml": "strict: true\nallowed:\n  - name: flask\n",
+		"stack.md":   "---\nforbidden:\n  - name: django\n---\n# Stack\n",
+	})
+
+	declaration, err := LoadStackDeclaration(rootDir)
+	if err != nil {
+		t.Fatalf("LoadStackDeclaration failed: %v", err)
+	}
+	if !declaration.Strict || len(declaration.Allowed) != 1 || len(declaration.Forbidden) != 0 {
+		t.Errorf("Expected stack.yaml to take precedence, got %+v", declaration)
+	}
+
+	warnings := compareStack(declaration, []Dependency{
+		{Name: "Flask", Version: "3.0.0", File: "requirements.txt", Line: 1},
+		{Name: "requests", Version: "2.31.0", File: "requirements.txt", Line: 2},
+	})
+	if len(warnings) != 1 || warnings[0].Subject != "requests" || warnings[0].Type != WarningUnapprovedDependency {
+		t.Errorf("Expected only requests to be unapproved in strict mode, got %+v", warnings)
+	}
+}
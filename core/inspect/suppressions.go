@@ -0,0 +1,162 @@
+package inspect
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// timeNow is replaced in tests to check suppression expiry
+var timeNow = time.Now
+
+// suppressionDateLayout is the format of Suppression.Expires
+const suppressionDateLayout = "2006-01-02"
+
+// descriptorSuppression is a suppression together with the descriptor that declared it
+type descriptorSuppression struct {
+	Suppression
+	source        string // Descriptor file path
+	defaultModule bool   // Module was not set and defaults to the descriptor's
+}
+
+// loadSuppressions reads the suppressions of every module descriptor in the foundation.
+// Descriptors are read regardless of --use-descriptors so suppressions always apply.
+func loadSuppressions(foundationPath string) ([]descriptorSuppression, error) {
+	var suppressions []descriptorSuppression
+
+	matches, err := filepath.Glob(filepath.Join(foundationPath, "*.module.yaml"))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, descriptorPath := range matches {
+		descriptor, err := loadModuleDescriptor(descriptorPath)
+		if err != nil {
+			continue // Unreadable descriptors are skipped, as in getFoundationModules
+		}
+
+		module := strings.TrimSuffix(filepath.Base(descriptorPath), ".module.yaml")
+		for _, s := range descriptor.Suppressions {
+			defaultModule := s.Module == ""
+			if defaultModule {
+				s.Module = module
+			}
+			suppressions = append(suppressions, descriptorSuppression{Suppression: s, source: descriptorPath, defaultModule: defaultModule})
+		}
+	}
+
+	return suppressions, nil
+}
+
+// applySuppressions returns the warnings of result not hidden by an active
+// suppression, records the hidden count in result.Summary.Suppressed and adds
+// an INVALID_SUPPRESSION warning for each expired or malformed suppression.
+// Warnings that only an expired suppression matches are marked, so that they
+// are never written to a baseline.
+func applySuppressions(result *InspectResult, suppressions []descriptorSuppression, now time.Time) []Warning {
+	var active, expired []descriptorSuppression
+	var invalid []Warning
+
+	for _, s := range suppressions {
+		problem := ""
+		expires, err := time.Parse(suppressionDateLayout, s.Expires)
+		switch {
+		case strings.TrimSpace(s.Reason) == "":
+			problem = "has no reason"
+		case s.Expires == "":
+			problem = "has no expiry date"
+		case err != nil:
+			problem = fmt.Sprintf("has an invalid expiry date '%s' (expected YYYY-MM-DD)", s.Expires)
+		case !now.Before(expires.AddDate(0, 0, 1)):
+			problem = fmt.Sprintf("expired on %s", s.Expires)
+			expired = append(expired, s)
+		}
+
+		if problem == "" {
+			active = append(active, s)
+			continue
+		}
+
+		subject := string(s.Type)
+		if s.Match != "" {
+			subject += " " + s.Match
+		}
+		invalid = append(invalid, Warning{
+			Type:        WarningInvalidSuppression,
+			Module:      s.Module,
+			Subject:     strings.TrimSpace(subject),
+			Message:     fmt.Sprintf("Suppression of %s in '%s' %s", describeSuppression(s.Suppression), filepath.Base(s.source), problem),
+			Severity:    "warning",
+			Remediation: "Fix the drift and remove the suppression, or renew it with a reason and a new expiry date",
+			File:        s.source,
+		})
+	}
+
+	remaining := []Warning{}
+	for _, w := range result.Warnings {
+		if suppressed(w, active) {
+			result.Summary.Suppressed++
+			continue
+		}
+		w.suppressionExpired = suppressed(w, expired)
+		remaining = append(remaining, w)
+	}
+
+	return append(remaining, invalid...)
+}
+
+// suppressed reports whether any of the suppressions matches the warning.
+// Repository-wide checks report against modules of their own, e.g. "api" for
+// endpoints; a suppression without a module matches their warnings of its
+// type by subject alone.
+func suppressed(w Warning, suppressions []descriptorSuppression) bool {
+	for _, s := range suppressions {
+		if s.Type != "" && s.Type != w.Type {
+			continue
+		}
+		repositoryWide := s.defaultModule && s.Type != "" && !w.Type.Check().PerModule()
+		if s.Module != w.Module && !repositoryWide {
+			continue
+		}
+		if s.Match != "" && !matchSubject(s.Match, warningSubject(w)) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// matchSubject matches a glob against a warning subject. Unlike path.Match,
+// '*' also matches '/', so "GET /api/*" covers every endpoint under /api.
+func matchSubject(pattern, subject string) bool {
+	var expr strings.Builder
+	expr.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			expr.WriteString(".*")
+		case '?':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	expr.WriteString("$")
+
+	matched, err := regexp.MatchString(expr.String(), subject)
+	return err == nil && matched
+}
+
+// describeSuppression renders a suppression for messages
+func describeSuppression(s Suppression) string {
+	what := "all warnings"
+	if s.Type != "" {
+		what = string(s.Type)
+	}
+	if s.Match != "" {
+		what += fmt.Sprintf(" matching '%s'", s.Match)
+	}
+	return what
+}
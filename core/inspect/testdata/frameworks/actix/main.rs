@@ -0,0 +1,30 @@
+use actix_web::{get, post, web, App, HttpResponse, HttpServer, Responder};
+
+#[get("/users")]
+async fn list_users() -> impl Responder {
+    HttpResponse::Ok().json(vec!["alice"])
+}
+
+#[post("/users")]
+async fn create_user(user: web::Json<User>) -> impl Responder {
+    HttpResponse::Created().json(user.into_inner())
+}
+
+#[route("/ping", method = "GET", method = "HEAD")]
+async fn ping() -> &'static str {
+    "pong"
+}
+
+#[actix_web::main]
+async fn main() -> std::io::Result<()> {
+    HttpServer::new(|| {
+        App::new()
+            .service(ping)
+            .service(web::scope("/api").service(list_users).service(create_user))
+            .service(web::resource("/orders/{id}").route(web::get().to(get_order)).route(web::delete().to(delete_order)))
+            .route("/version", web::get().to(version))
+    })
+    .bind(("127.0.0.1", 8080))?
+    .run()
+    .await
+}
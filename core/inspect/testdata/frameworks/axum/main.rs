@@ -0,0 +1,40 @@
+use axum::{routing::{get, post}, Router};
+
+#[tokio::main]
+async fn main() {
+    let api = Router::new()
+        .route("/users", get(list_users).post(create_user))
+        .route("/users/:id", get(get_user).delete(handlers::delete_user));
+
+    let app = Router::new()
+        .route("/health", get(|| async { "OK" }))
+        .nest("/api", api)
+        .nest("/admin", Router::new().route("/stats", get(stats)));
+
+    let listener = tokio::net::TcpListener::bind("0.0.0.0:3000").await.unwrap();
+    axum::serve(listener, app).await.unwrap();
+}
+
+pub async fn list_users(State(state): State<AppState>) -> Json<Vec<User>> {
+    Json(state.users.all())
+}
+
+pub async fn get_user<'a>(
+    Path(id): Path<u64>,
+    State(state): State<AppState>,
+) -> Result<Json<User>, StatusCode> {
+    state.users.find(id).map(Json).ok_or(StatusCode::NOT_FOUND)
+}
+
+async fn create_user(Json(payload): Json<CreateUser>) -> impl IntoResponse {
+    (StatusCode::CREATED, Json(payload))
+}
+
+impl UserStore {
+    pub fn find(&self, id: u64) -> Option<User> {
+        self.users.get(&id).cloned()
+    }
+
+    fn insert(&mut self, name: &'static str) {
+    }
+}
@@ -0,0 +1,52 @@
+package com.example.plugins
+
+import io.ktor.server.application.*
+import io.ktor.server.response.*
+import io.ktor.server.routing.*
+
+fun Application.configureRouting() {
+    routing {
+        get("/health") {
+            call.respondText("OK")
+        }
+        route("/api/users") {
+            get {
+                call.respond(userService.list())
+            }
+            post {
+                call.respond(userService.create(call.receive()))
+            }
+            route("/{id}") {
+                get {
+                    call.respond(userService.find(call.parameters["id"]!!))
+                }
+                delete {
+                    userService.delete(call.parameters["id"]!!)
+                }
+            }
+        }
+        put("/settings") {
+            call.respond(HttpStatusCode.NoContent)
+        }
+    }
+}
+
+class UserService(private val repository: UserRepository) {
+    suspend fun find(
+        id: String,
+        includeDeleted: Boolean = false,
+    ): User? {
+        return repository.find(id, includeDeleted)
+    }
+
+    fun list(): List<User> = repository.all()
+
+    internal fun create(user: User, onCreated: (User) -> Unit = {}): User {
+        return repository.save(user).also(onCreated)
+    }
+
+    private fun audit(vararg events: String) {
+    }
+}
+
+fun String.toUserId(): Long = toLong()
@@ -0,0 +1,38 @@
+<?php
+
+namespace App\Http\Controllers;
+
+use App\Models\User;
+use Illuminate\Http\Request;
+
+class UserController extends Controller
+{
+    public function __construct(private readonly UserService $users)
+    {
+    }
+
+    public function index(Request $request): JsonResponse
+    {
+        return response()->json($this->users->all());
+    }
+
+    public function store(
+        Request $request,
+        ?string $role = null,
+    ): JsonResponse {
+        return response()->json($this->users->create($request->all(), $role), 201);
+    }
+
+    protected function authorizeUser(User $user): void
+    {
+    }
+
+    function helper(...$args)
+    {
+    }
+}
+
+function format_name(string $first, string $last = ''): string
+{
+    return trim("$first $last");
+}
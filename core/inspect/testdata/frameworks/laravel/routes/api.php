@@ -0,0 +1,23 @@
+<?php
+
+use App\Http\Controllers\PhotoController;
+use App\Http\Controllers\UserController;
+use Illuminate\Support\Facades\Route;
+
+Route::get('/users', [UserController::class, 'index']);
+Route::post('/users', 'UserController@store');
+Route::match(['get', 'post'], '/search', SearchController::class);
+Route::any('/ping', function () {
+    return 'pong';
+});
+
+Route::prefix('admin')->middleware('auth')->group(function () {
+    Route::delete('/users/{user}', [UserController::class, 'destroy']);
+
+    Route::group(['prefix' => 'reports'], function () {
+        Route::get('/daily', [ReportController::class, 'daily']);
+    });
+});
+
+Route::apiResource('photos', PhotoController::class)->only(['index', 'show']);
+Route::resource('photos.comments', CommentController::class)->except(['create', 'edit']);
@@ -0,0 +1,29 @@
+defmodule MyApp.Accounts do
+  alias MyApp.Accounts.User
+
+  @spec get_user(integer(), keyword()) :: User.t() | nil
+  def get_user(id, opts \\ []) do
+    Repo.get(User, id, opts)
+  end
+
+  @spec create_user(map()) :: {:ok, User.t()} | {:error, Ecto.Changeset.t()}
+  def create_user(%{"email" => _} = attrs) do
+    %User{} |> User.changeset(attrs) |> Repo.insert()
+  end
+
+  def create_user(attrs) do
+    {:error, attrs}
+  end
+
+  def list_users, do: Repo.all(User)
+
+  defp normalize(email), do: String.downcase(email)
+
+  defmodule Token do
+    def sign(user) do
+      Enum.map([user], fn u ->
+        u.id
+      end)
+    end
+  end
+end
@@ -0,0 +1,29 @@
+defmodule MyAppWeb.Router do
+  use MyAppWeb, :router
+
+  pipeline :api do
+    plug :accepts, ["json"]
+  end
+
+  scope "/", MyAppWeb do
+    get "/health", HealthController, :show
+  end
+
+  scope "/api", MyAppWeb do
+    pipe_through :api
+
+    get "/users/:id", UserController, :show
+    post "/users", UserController, :create
+    match :*, "/echo", EchoController, :echo
+
+    resources "/posts", PostController, only: [:index, :show] do
+      resources "/comments", CommentController, except: [:new, :edit]
+    end
+
+    resources "/account", AccountController, singleton: true, only: [:show, :update]
+
+    scope "/admin", Admin do
+      delete "/cache", CacheController, :clear
+    end
+  end
+end
@@ -0,0 +1,24 @@
+package com.example.web
+
+import org.springframework.web.bind.annotation.*
+
+@RestController
+@RequestMapping("/api/users")
+class UserController(private val service: UserService) {
+
+    @GetMapping
+    fun list(): List<User> = service.list()
+
+    @GetMapping("/{id}")
+    fun get(@PathVariable id: Long): User = service.find(id)
+
+    @PostMapping
+    @ResponseStatus(HttpStatus.CREATED)
+    fun create(@RequestBody user: User): User = service.create(user)
+
+    @DeleteMapping("/{id}")
+    fun delete(@PathVariable id: Long) = service.delete(id)
+
+    @RequestMapping("/search")
+    fun search(@RequestParam q: String): List<User> = service.search(q)
+}
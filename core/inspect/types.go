@@ -18,19 +18,130 @@ const (
 	WarningMissingEndpoint WarningType = "MISSING_ENDPOINT"
 	// WarningUndocumentedEndpoint indicates an endpoint exists but is not documented
 	WarningUndocumentedEndpoint WarningType = "UNDOCUMENTED_ENDPOINT"
+	// WarningSchemaMismatch indicates a handler's request or response type differs from the documented body
+	WarningSchemaMismatch WarningType = "SCHEMA_MISMATCH"
+	// WarningMissingRPC indicates a gRPC method is defined in a .proto contract but not implemented
+	WarningMissingRPC WarningType = "MISSING_RPC"
+	// WarningUndocumentedRPC indicates a gRPC method is implemented but not defined in a .proto contract
+	WarningUndocumentedRPC WarningType = "UNDOCUMENTED_RPC"
+	// WarningUnresolvedField indicates a GraphQL schema field has no resolver
+	WarningUnresolvedField WarningType = "UNRESOLVED_FIELD"
+	// WarningUndocumentedResolver indicates a GraphQL resolver is not declared in the schema
+	WarningUndocumentedResolver WarningType = "UNDOCUMENTED_RESOLVER"
+	// WarningMissingTopic indicates a documented message channel is never produced or consumed
+	WarningMissingTopic WarningType = "MISSING_TOPIC"
+	// WarningUndocumentedTopic indicates a topic is produced or consumed but not documented
+	WarningUndocumentedTopic WarningType = "UNDOCUMENTED_TOPIC"
+	// WarningMissingTable indicates a documented table is not created by any migration
+	WarningMissingTable WarningType = "MISSING_TABLE"
+	// WarningMissingColumn indicates a documented column is not created by migrations
+	WarningMissingColumn WarningType = "MISSING_COLUMN"
+	// WarningColumnTypeMismatch indicates a migrated column's type differs from the data model
+	WarningColumnTypeMismatch WarningType = "COLUMN_TYPE_MISMATCH"
+	// WarningUndocumentedEnvVar indicates code reads an environment variable that no spec declares
+	WarningUndocumentedEnvVar WarningType = "UNDOCUMENTED_ENV_VAR"
+	// WarningUnusedEnvVar indicates a declared environment variable is never read
+	WarningUnusedEnvVar WarningType = "UNUSED_ENV_VAR"
+	// WarningForbiddenDependency indicates a manifest depends on a library the stack forbids
+	WarningForbiddenDependency WarningType = "FORBIDDEN_DEPENDENCY"
+	// WarningUnapprovedDependency indicates a manifest depends on a framework the stack does not allow
+	WarningUnapprovedDependency WarningType = "UNAPPROVED_DEPENDENCY"
+	// WarningDependencyVersion indicates an allowed library is outside its approved version range
+	WarningDependencyVersion WarningType = "DEPENDENCY_VERSION_MISMATCH"
 	// WarningSignatureMismatch indicates function signature doesn't match spec
 	WarningSignatureMismatch WarningType = "SIGNATURE_MISMATCH"
 	// WarningMissingFunction indicates an expected function is not found
 	WarningMissingFunction WarningType = "MISSING_FUNCTION"
+	// WarningUntestedEndpoint indicates a documented endpoint has no BDD scenario
+	WarningUntestedEndpoint WarningType = "UNTESTED_ENDPOINT"
+	// WarningOrphanedScenario indicates a BDD scenario references an undocumented endpoint
+	WarningOrphanedScenario WarningType = "ORPHANED_SCENARIO"
+	// WarningInvalidSuppression indicates a descriptor suppression is expired or malformed
+	WarningInvalidSuppression WarningType = "INVALID_SUPPRESSION"
 )
 
+// Description returns a short human-readable description of the warning type
+func (t WarningType) Description() string {
+	switch t {
+	case WarningMissingModule:
+		return "Foundation spec has no matching code module"
+	case WarningExtraCode:
+		return "Code module has no foundation spec"
+	case WarningMismatchedName:
+		return "Module name differs between spec and code"
+	case WarningMissingFile:
+		return "File or directory expected by the module descriptor is missing"
+	case WarningUnexpectedFile:
+		return "File exists that the module descriptor does not expect"
+	case WarningMissingEndpoint:
+		return "Documented API endpoint is not implemented"
+	case WarningUndocumentedEndpoint:
+		return "Implemented API endpoint is not documented"
+	case WarningSchemaMismatch:
+		return "Handler request or response type differs from the documented body"
+	case WarningMissingRPC:
+		return "gRPC method in the .proto contract is not implemented"
+	case WarningUndocumentedRPC:
+		return "Implemented gRPC method is not in the .proto contract"
+	case WarningUnresolvedField:
+		return "GraphQL schema field has no resolver"
+	case WarningUndocumentedResolver:
+		return "GraphQL resolver is not declared in the schema"
+	case WarningMissingTopic:
+		return "Documented message channel is never produced or consumed"
+	case WarningUndocumentedTopic:
+		return "Message topic is used in code but not documented"
+	case WarningMissingTable:
+		return "Documented table is not created by migrations"
+	case WarningMissingColumn:
+		return "Documented column is not created by migrations"
+	case WarningColumnTypeMismatch:
+		return "Migrated column type differs from the data model"
+	case WarningUndocumentedEnvVar:
+		return "Environment variable is read but not documented"
+	case WarningUnusedEnvVar:
+		return "Documented environment variable is never read"
+	case WarningForbiddenDependency:
+		return "Dependency is forbidden by the stack declaration"
+	case WarningUnapprovedDependency:
+		return "Dependency is not in the approved stack"
+	case WarningDependencyVersion:
+		return "Dependency version is outside the approved range"
+	case WarningSignatureMismatch:
+		return "Function signature does not match the spec"
+	case WarningMissingFunction:
+		return "Function expected by the spec is not implemented"
+	case WarningUntestedEndpoint:
+		return "Documented API endpoint has no BDD scenario"
+	case WarningOrphanedScenario:
+		return "BDD scenario references an undocumented API endpoint"
+	case WarningInvalidSuppression:
+		return "Suppression in a module descriptor is expired or malformed"
+	default:
+		return string(t)
+	}
+}
+
+// Known reports whether t is one of the warning types above. Every known
+// type has a description of its own.
+func (t WarningType) Known() bool {
+	return t.Description() != string(t)
+}
+
 // Warning represents a single drift detection warning
 type Warning struct {
 	Type        WarningType `json:"type"`
 	Module      string      `json:"module"`
 	Message     string      `json:"message"`
+	Subject     string      `json:"subject,omitempty"` // Stable identifier of what drifted, e.g. "GET /api/users"
 	Severity    string      `json:"severity"`    // "error", "warning", "info"
 	Remediation string      `json:"remediation"` // Suggested fix
+	File        string      `json:"file,omitempty"` // Source location, if known
+	Line        int         `json:"line,omitempty"`
+	Change      string      `json:"change,omitempty"` // "new" or "preexisting" when inspecting --since a revision
+	Owners      []string    `json:"owners,omitempty"` // Owners of the spec, from its front matter
+
+	suppressionExpired bool // Matched by an expired suppression, so never baselined
 }
 
 // InspectResult contains the complete result of an inspection
@@ -38,6 +149,80 @@ type InspectResult struct {
 	Success  bool      `json:"success"`
 	Warnings []Warning `json:"warnings"`
 	Summary  Summary   `json:"summary"`
+	Modules  []string  `json:"modules,omitempty"` // Foundation modules that were checked
+	Checks   []Check   `json:"checks,omitempty"`  // Checks that ran
+}
+
+// Check identifies a group of inspection checks
+type Check string
+
+const (
+	// CheckModule verifies that each foundation module has matching code
+	CheckModule Check = "module exists"
+	// CheckFiles verifies descriptor expected files, directories and patterns
+	CheckFiles Check = "expected files"
+	// CheckEndpoints verifies API endpoints, gRPC methods and GraphQL fields against the documented contract (Level 2)
+	CheckEndpoints Check = "endpoints"
+	// CheckSignatures verifies function signatures against descriptors (Level 3)
+	CheckSignatures Check = "signatures"
+	// CheckTests verifies BDD scenarios against documented endpoints
+	CheckTests Check = "tests"
+	// CheckEvents verifies message topics against AsyncAPI channels (Level 2)
+	CheckEvents Check = "events"
+	// CheckDataModel verifies documented tables against SQL migrations
+	CheckDataModel Check = "data model"
+	// CheckConfig verifies environment variable reads against the documented configuration (Level 2)
+	CheckConfig Check = "config"
+	// CheckStack verifies manifest dependencies against the declared stack
+	CheckStack Check = "stack"
+)
+
+// checkModules names the module that each repository-wide check reports against
+var checkModules = map[Check]string{
+	CheckEndpoints: "api",
+	CheckTests:     "api",
+	CheckEvents:    "api",
+	CheckDataModel: "database",
+	CheckConfig:    "config",
+	CheckStack:     "stack",
+}
+
+// PerModule reports whether the check runs once per foundation module.
+// Other checks report against a module of their own, see Module.
+func (c Check) PerModule() bool {
+	_, repositoryWide := checkModules[c]
+	return !repositoryWide
+}
+
+// Module returns the module a repository-wide check reports against, e.g.
+// "api" for endpoints, or "" for per-module checks
+func (c Check) Module() string {
+	return checkModules[c]
+}
+
+// Check returns the check a warning type belongs to
+func (t WarningType) Check() Check {
+	switch t {
+	case WarningMissingFile, WarningUnexpectedFile:
+		return CheckFiles
+	case WarningMissingEndpoint, WarningUndocumentedEndpoint, WarningSchemaMismatch, WarningMissingRPC, WarningUndocumentedRPC,
+		WarningUnresolvedField, WarningUndocumentedResolver:
+		return CheckEndpoints
+	case WarningSignatureMismatch, WarningMissingFunction:
+		return CheckSignatures
+	case WarningUntestedEndpoint, WarningOrphanedScenario:
+		return CheckTests
+	case WarningMissingTopic, WarningUndocumentedTopic:
+		return CheckEvents
+	case WarningMissingTable, WarningMissingColumn, WarningColumnTypeMismatch:
+		return CheckDataModel
+	case WarningUndocumentedEnvVar, WarningUnusedEnvVar:
+		return CheckConfig
+	case WarningForbiddenDependency, WarningUnapprovedDependency, WarningDependencyVersion:
+		return CheckStack
+	default:
+		return CheckModule
+	}
 }
 
 // Summary provides high-level statistics about the inspection
@@ -52,7 +237,32 @@ type Summary struct {
 	Languages          map[string]int     `json:"languages,omitempty"`           // Language name -> file count
 	MissingEndpoints   int                `json:"missing_endpoints,omitempty"`   // Level 2
 	UndocumentedEnds   int                `json:"undocumented_endpoints,omitempty"` // Level 2
+	SchemaMismatches   int                `json:"schema_mismatches,omitempty"`   // Level 2
+	MissingRPCs        int                `json:"missing_rpcs,omitempty"`        // Level 2, gRPC
+	UndocumentedRPCs   int                `json:"undocumented_rpcs,omitempty"`   // Level 2, gRPC
+	UnresolvedFields   int                `json:"unresolved_fields,omitempty"`   // Level 2, GraphQL
+	UndocumentedResolvers int             `json:"undocumented_resolvers,omitempty"` // Level 2, GraphQL
+	MissingTopics       int               `json:"missing_topics,omitempty"`      // Level 2, AsyncAPI
+	UndocumentedTopics  int               `json:"undocumented_topics,omitempty"` // Level 2, AsyncAPI
+	UndocumentedEnvVars int               `json:"undocumented_env_vars,omitempty"` // Level 2, config
+	UnusedEnvVars       int               `json:"unused_env_vars,omitempty"`     // Level 2, config
+	ForbiddenDependencies int             `json:"forbidden_dependencies,omitempty"` // Stack
+	UnapprovedDependencies int            `json:"unapproved_dependencies,omitempty"` // Stack
+	DependencyVersionMismatches int       `json:"dependency_version_mismatches,omitempty"` // Stack
+	MissingTables       int               `json:"missing_tables,omitempty"`      // Data model
+	MissingColumns      int               `json:"missing_columns,omitempty"`     // Data model
+	ColumnTypeMismatches int              `json:"column_type_mismatches,omitempty"` // Data model
 	SignatureMismatches int               `json:"signature_mismatches,omitempty"` // Level 3
+	TestScenarios       int               `json:"test_scenarios,omitempty"`     // BDD coverage
+	TestedEndpoints     int               `json:"tested_endpoints,omitempty"`   // BDD coverage
+	UntestedEndpoints   int               `json:"untested_endpoints,omitempty"` // BDD coverage
+	OrphanedScenarios   int               `json:"orphaned_scenarios,omitempty"` // BDD coverage
+	Suppressed          int               `json:"suppressed,omitempty"`           // Hidden by descriptor suppressions
+	Baselined           int               `json:"baselined,omitempty"`            // Hidden by the inspect baseline
+	Since               string            `json:"since,omitempty"`                // Revision a --since inspection compared against
+	ChangedFiles        int               `json:"changed_files,omitempty"`        // Files changed since that revision
+	NewWarnings         int               `json:"new_warnings,omitempty"`         // Warnings introduced since that revision
+	PreexistingWarnings int               `json:"preexisting_warnings,omitempty"` // Warnings already present at that revision
 }
 
 // ModuleDescriptor defines the expected structure of a module
@@ -62,13 +272,25 @@ type ModuleDescriptor struct {
 	ExpectedFiles     []string           `yaml:"expected_files"`
 	ExpectedDirs      []string           `yaml:"expected_dirs"`
 	Patterns          []string           `yaml:"patterns"` // Glob patterns for files
+	Env               []string           `yaml:"env,omitempty"` // Environment variables the module reads
 	ExpectedFunctions []FunctionSpec     `yaml:"expected_functions,omitempty"` // Level 3
+	Suppressions      []Suppression      `yaml:"suppressions,omitempty"`
+}
+
+// Suppression hides matching warnings until it expires
+type Suppression struct {
+	Type    WarningType `yaml:"type,omitempty"`    // Warning type to suppress; empty matches any type
+	Module  string      `yaml:"module,omitempty"`  // Defaults to the descriptor's module
+	Match   string      `yaml:"match,omitempty"`   // Glob matched against the warning subject; empty matches all
+	Reason  string      `yaml:"reason"`            // Why the drift is accepted (required)
+	Expires string      `yaml:"expires"`           // Expiry date, YYYY-MM-DD (required)
 }
 
 // FunctionSpec defines expected function/method signatures
 type FunctionSpec struct {
 	Name        string          `yaml:"name"`
-	Language    string          `yaml:"language"` // go, python, javascript, java, csharp, ruby
+	Language    string          `yaml:"language"` // go, python, javascript, typescript, java, csharp, ruby, kotlin, rust, php, elixir
+	Receiver    string          `yaml:"receiver,omitempty"` // Owning type for methods, e.g. "*UserService"
 	FilePattern string          `yaml:"file_pattern,omitempty"` // Where to find it
 	Parameters  []ParameterSpec `yaml:"parameters,omitempty"`
 	Returns     []ReturnSpec    `yaml:"returns,omitempty"`
@@ -108,4 +330,8 @@ const (
 	LangJava       Language = "java"
 	LangCSharp     Language = "csharp"
 	LangRuby       Language = "ruby"
+	LangKotlin     Language = "kotlin"
+	LangRust       Language = "rust"
+	LangPHP        Language = "php"
+	LangElixir     Language = "elixir"
 )
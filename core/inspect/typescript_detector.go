@@ -0,0 +1,399 @@
+package inspect
+
+import (
+	"regexp"
+	"strings"
+)
+
+// TypeScriptDetector handles TypeScript analysis, including .tsx files
+type TypeScriptDetector struct{}
+
+// NewTypeScriptDetector creates a new TypeScript language detector
+func NewTypeScriptDetector() *TypeScriptDetector {
+	return &TypeScriptDetector{}
+}
+
+// Detect returns true if this is a TypeScript source file. Declaration
+// files (.d.ts) contain no handlers and are skipped.
+func (d *TypeScriptDetector) Detect(filePath string) bool {
+	lower := strings.ToLower(filePath)
+	if strings.HasSuffix(lower, ".d.ts") {
+		return false
+	}
+	return strings.HasSuffix(lower, ".ts") ||
+		strings.HasSuffix(lower, ".tsx") ||
+		strings.HasSuffix(lower, ".mts") ||
+		strings.HasSuffix(lower, ".cts")
+}
+
+// Language returns the language identifier
+func (d *TypeScriptDetector) Language() Language {
+	return LangTypeScript
+}
+
+var (
+	// @Controller('users') or @Controller({ path: 'users' })
+	tsControllerPattern = regexp.MustCompile(`^@Controller\s*\(\s*(?:["'\x60]([^"'\x60]*)["'\x60]|\{[^}]*\bpath\s*:\s*["'\x60]([^"'\x60]*)["'\x60])?`)
+
+	// @Get(), @Post(':id'), ...
+	tsRouteDecoratorPattern = regexp.MustCompile(`^@(Get|Post|Put|Delete|Patch|Options|Head|All)\s*\(\s*(?:["'\x60]([^"'\x60]*)["'\x60])?[^)]*\)\s*(.*)$`)
+
+	// Express/Fastify: router.get('/path', ...) or fastify.get<{ Params: P }>('/path', ...)
+	tsRoutePattern = regexp.MustCompile(`\b(app|router|server|fastify|api|\w+Router)\.(get|post|put|delete|patch|options|head|all)\s*(?:<.*?>)?\s*\(\s*["'\x60]([^"'\x60]+)["'\x60]\s*(.*)$`)
+
+	// Method declaration, optionally with modifiers and type parameters
+	tsMethodPattern = regexp.MustCompile(`^((?:(?:public|private|protected|static|async|readonly|override|abstract)\s+)*)(#?[A-Za-z_$][\w$]*)\s*(?:<[^(]*>)?\s*\(`)
+
+	// function name<T>(...) and const name = async <T>(...) =>
+	tsFunctionPattern = regexp.MustCompile(`^(?:export\s+)?(?:default\s+)?(?:async\s+)?function\s*\*?\s*([A-Za-z_$][\w$]*)\s*(?:<[^(]*>)?\s*\(`)
+	tsArrowPattern    = regexp.MustCompile(`^(?:export\s+)?(?:const|let)\s+([A-Za-z_$][\w$]*)\s*(?::[^=]+)?=\s*(?:async\s+)?(?:<[^(]*>)?\s*\(`)
+
+	tsClassPattern      = regexp.MustCompile(`^(?:export\s+)?(?:default\s+)?(?:abstract\s+)?class\s+([A-Za-z_$][\w$]*)`)
+	tsIdentifierPattern = regexp.MustCompile(`^[A-Za-z_$][\w$.]*$`)
+)
+
+// tsKeywords are words followed by "(" that never start a method declaration
+var tsKeywords = map[string]bool{
+	"if": true, "for": true, "while": true, "switch": true, "catch": true,
+	"return": true, "function": true, "constructor": true, "super": true,
+	"await": true, "typeof": true, "new": true, "with": true,
+}
+
+// ExtractEndpoints finds HTTP endpoints in TypeScript code
+// Supports: NestJS decorators (with controller prefixes), Express, Fastify, and
+// file-system routing (Next.js, SvelteKit, Nuxt)
+func (d *TypeScriptDetector) ExtractEndpoints(filePath string, content []byte) ([]Endpoint, error) {
+	var endpoints []Endpoint
+	lines := strings.Split(string(content), "\n")
+
+	prefix := ""
+	var pending *Endpoint // NestJS route decorator waiting for its method
+
+	for lineNum, line := range lines {
+		trimmedLine := strings.TrimSpace(line)
+
+		if match := tsControllerPattern.FindStringSubmatch(trimmedLine); match != nil {
+			prefix = match[1] + match[2]
+			continue
+		}
+
+		if match := tsRouteDecoratorPattern.FindStringSubmatch(trimmedLine); match != nil {
+			pending = &Endpoint{
+				Method:   strings.ToUpper(match[1]),
+				Path:     controllerRoutePath(prefix, match[2]),
+				File:     filePath,
+				Line:     lineNum + 1,
+				Language: string(LangTypeScript),
+			}
+			// The method may follow the decorator on the same line
+			trimmedLine = strings.TrimSpace(match[3])
+		}
+
+		if pending != nil {
+			if trimmedLine == "" || strings.HasPrefix(trimmedLine, "@") || strings.HasPrefix(trimmedLine, "//") {
+				continue // Other decorators and comments between the route and the method
+			}
+			if match := tsMethodPattern.FindStringSubmatch(trimmedLine); match != nil && !tsKeywords[match[2]] {
+				pending.Handler = match[2]
+				endpoints = append(endpoints, *pending)
+			}
+			pending = nil
+			continue
+		}
+
+		if match := tsRoutePattern.FindStringSubmatch(trimmedLine); match != nil {
+			endpoints = append(endpoints, Endpoint{
+				Method:   strings.ToUpper(match[2]),
+				Path:     match[3],
+				Handler:  tsRouteHandler(match[4]),
+				File:     filePath,
+				Line:     lineNum + 1,
+				Language: string(LangTypeScript),
+			})
+		}
+	}
+
+	// File-routed handlers (Next.js, SvelteKit, Nuxt)
+	endpoints = append(endpoints, extractFileRoutes(filePath, content, LangTypeScript)...)
+
+	return endpoints, nil
+}
+
+// tsRouteHandler returns the named handler among the remaining route
+// arguments, i.e. the last one after any middleware. Inline handlers have no name.
+func tsRouteHandler(args string) string {
+	if strings.Contains(args, "=>") || strings.Contains(args, "function") {
+		return ""
+	}
+	args = strings.TrimSpace(strings.TrimLeft(args, ","))
+	args = strings.TrimRight(args, " ;)")
+	parts := splitTopLevel(args, ',')
+	if len(parts) == 0 {
+		return ""
+	}
+	handler := strings.TrimSpace(parts[len(parts)-1])
+	if !tsIdentifierPattern.MatchString(handler) {
+		return ""
+	}
+	return handler
+}
+
+// ExtractFunctions finds function and method signatures in TypeScript code.
+// Parameter lists may span several lines; parameter decorators are ignored.
+func (d *TypeScriptDetector) ExtractFunctions(filePath string, content []byte) ([]FunctionSignature, error) {
+	var functions []FunctionSignature
+	text := string(content)
+
+	className := ""
+	offset := 0
+	for lineNum, line := range strings.Split(text, "\n") {
+		lineStart := offset
+		offset += len(line) + 1
+
+		trimmedLine := strings.TrimSpace(line)
+		indent := strings.Index(line, trimmedLine)
+
+		if match := tsClassPattern.FindStringSubmatch(trimmedLine); match != nil {
+			className = match[1]
+			continue
+		}
+
+		var name, receiver, visibility string
+		var loc []int
+		arrow := false
+		if match := tsFunctionPattern.FindStringSubmatchIndex(trimmedLine); match != nil {
+			name = trimmedLine[match[2]:match[3]]
+			visibility = "public"
+			loc = match
+		} else if match := tsArrowPattern.FindStringSubmatchIndex(trimmedLine); match != nil {
+			name = trimmedLine[match[2]:match[3]]
+			visibility = "public"
+			loc = match
+			arrow = true
+		} else if match := tsMethodPattern.FindStringSubmatchIndex(trimmedLine); match != nil && className != "" {
+			name = trimmedLine[match[4]:match[5]]
+			if tsKeywords[name] {
+				continue
+			}
+			receiver = className
+			visibility = tsVisibility(trimmedLine[match[2]:match[3]], name)
+			loc = match
+		} else {
+			continue
+		}
+
+		// The match ends just after the opening parenthesis
+		open := lineStart + indent + loc[1] - 1
+		closeIdx := matchingParen(text, open)
+		if closeIdx < 0 {
+			continue
+		}
+
+		// Calls inside method bodies look like declarations up to here; only a
+		// body, or a typed abstract or overload signature, makes a declaration
+		returnType, end, ok := tsReturnType(text[closeIdx+1:])
+		if !ok || (arrow && end != "=>") || (!arrow && end == "=>") || (end == ";" && returnType == "") {
+			continue
+		}
+
+		var returns []ReturnSpec
+		if returnType != "" && returnType != "void" {
+			returns = append(returns, ReturnSpec{Type: returnType})
+		}
+
+		functions = append(functions, FunctionSignature{
+			Name:       name,
+			Receiver:   receiver,
+			Parameters: d.parseTSParameters(text[open+1 : closeIdx]),
+			Returns:    returns,
+			File:       filePath,
+			Line:       lineNum + 1,
+			Visibility: visibility,
+		})
+	}
+
+	return functions, nil
+}
+
+// tsVisibility derives a method's visibility from its modifiers and name
+func tsVisibility(modifiers, name string) string {
+	fields := strings.Fields(modifiers)
+	for _, modifier := range fields {
+		if modifier == "private" || modifier == "protected" {
+			return modifier
+		}
+	}
+	if strings.HasPrefix(name, "#") {
+		return "private"
+	}
+	return "public"
+}
+
+// tsReturnType reads an optional ": Type" annotation after a parameter list
+// and returns it with the token that ends the signature: "{", "=>" or ";"
+func tsReturnType(rest string) (string, string, bool) {
+	trimmed := strings.TrimLeft(rest, " \t\r\n")
+	if !strings.HasPrefix(trimmed, ":") {
+		for _, end := range []string{"{", "=>", ";"} {
+			if strings.HasPrefix(trimmed, end) {
+				return "", end, true
+			}
+		}
+		return "", "", false
+	}
+
+	trimmed = trimmed[1:]
+	depth := 0
+	for i := 0; i < len(trimmed); i++ {
+		switch c := trimmed[i]; c {
+		case '<', '(', '[':
+			depth++
+		case ')', ']':
+			depth--
+		case '>':
+			if i > 0 && trimmed[i-1] == '=' {
+				// "=>" inside a function type, or ending an arrow function
+				if depth == 0 {
+					return strings.TrimSpace(trimmed[:i-1]), "=>", true
+				}
+				continue
+			}
+			depth--
+		case '{':
+			// At the top level, "{" opens the body unless no type was read yet
+			if depth == 0 && strings.TrimSpace(trimmed[:i]) != "" {
+				return strings.TrimSpace(trimmed[:i]), "{", true
+			}
+			depth++
+		case '}':
+			depth--
+		case ';':
+			if depth == 0 {
+				return strings.TrimSpace(trimmed[:i]), ";", true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// parseTSParameters parses TypeScript parameters, keeping generic and
+// function types intact
+func (d *TypeScriptDetector) parseTSParameters(paramsStr string) []ParameterSpec {
+	var params []ParameterSpec
+
+	for _, part := range splitTopLevel(paramsStr, ',') {
+		part = stripDecorators(strings.TrimSpace(part))
+		if part == "" {
+			continue
+		}
+
+		// Parameter properties: constructor(private readonly repo: Repo)
+		for _, modifier := range []string{"public ", "private ", "protected ", "readonly "} {
+			part = strings.TrimPrefix(part, modifier)
+		}
+
+		// Remove default values
+		if pieces := splitTopLevel(part, '='); len(pieces) > 0 {
+			part = strings.TrimSpace(pieces[0])
+		}
+
+		paramName, paramType := part, "any"
+		if pieces := splitTopLevel(part, ':'); len(pieces) > 1 {
+			paramName = strings.TrimSpace(pieces[0])
+			paramType = strings.TrimSpace(strings.Join(pieces[1:], ":"))
+		}
+		paramName = strings.TrimSuffix(strings.TrimPrefix(paramName, "..."), "?")
+
+		params = append(params, ParameterSpec{
+			Name: paramName,
+			Type: paramType,
+		})
+	}
+
+	return params
+}
+
+// stripDecorators removes leading decorators such as @Param('id') or @Body()
+func stripDecorators(s string) string {
+	for strings.HasPrefix(s, "@") {
+		i := 1
+		for i < len(s) && (s[i] == '_' || s[i] == '$' || s[i] == '.' || isAlnum(s[i])) {
+			i++
+		}
+		if i < len(s) && s[i] == '(' {
+			closeIdx := matchingParen(s, i)
+			if closeIdx < 0 {
+				return s
+			}
+			i = closeIdx + 1
+		}
+		s = strings.TrimSpace(s[i:])
+	}
+	return s
+}
+
+// isAlnum reports whether c is an ASCII letter or digit
+func isAlnum(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// matchingParen returns the index of the parenthesis closing the one at open,
+// skipping quoted strings, or -1 if it is unbalanced
+func matchingParen(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"', '\'', '`':
+			for i++; i < len(s) && s[i] != c; i++ {
+				if s[i] == '\\' {
+					i++
+				}
+			}
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// splitTopLevel splits s on sep, ignoring separators nested in brackets,
+// generics and quoted strings. The ">" of "=>" and "->" does not close a generic.
+func splitTopLevel(s string, sep byte) []string {
+	var parts []string
+	depth := 0
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '"' || c == '\'' || c == '`':
+			for i++; i < len(s) && s[i] != c; i++ {
+				if s[i] == '\\' {
+					i++
+				}
+			}
+		case c == '(' || c == '[' || c == '{' || c == '<':
+			depth++
+		case c == ')' || c == ']' || c == '}':
+			depth--
+		case c == '>':
+			if i > 0 && (s[i-1] == '=' || s[i-1] == '-') {
+				continue
+			}
+			depth--
+		case c == sep && depth == 0:
+			if sep == '=' && i+1 < len(s) && s[i+1] == '>' {
+				continue // Arrow of a function type
+			}
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	if strings.TrimSpace(s[start:]) != "" {
+		parts = append(parts, s[start:])
+	}
+	return parts
+}
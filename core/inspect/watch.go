@@ -0,0 +1,309 @@
+package inspect
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// DefaultWatchDebounce is the quiet period of a Watcher before it reports a
+// burst of changes
+const DefaultWatchDebounce = 500 * time.Millisecond
+
+// Watcher reports changes to code and to .neev/ from file system events
+type Watcher struct {
+	RootDir    string
+	IgnoreDirs map[string]bool
+	Debounce   time.Duration // Quiet period before reporting a burst of changes (default DefaultWatchDebounce)
+}
+
+// Run watches until ctx is done, calling onChange with the sorted root-relative
+// paths that were created, modified or deleted once no further change has been
+// seen for the debounce period. Files inside a new directory are reported too.
+func (w *Watcher) Run(ctx context.Context, onChange func(changed []string)) error {
+	debounce := w.Debounce
+	if debounce <= 0 {
+		debounce = DefaultWatchDebounce
+	}
+
+	events, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to watch %s: %w", w.RootDir, err)
+	}
+	defer events.Close()
+	if _, err := w.watchTree(events, w.RootDir); err != nil {
+		return fmt.Errorf("failed to watch %s: %w", w.RootDir, err)
+	}
+
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	pending := make(map[string]bool)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err, ok := <-events.Errors:
+			if !ok {
+				return nil
+			}
+			return fmt.Errorf("failed to watch %s: %w", w.RootDir, err)
+		case event, ok := <-events.Events:
+			if !ok {
+				return nil
+			}
+			if event.Op == fsnotify.Chmod || !w.watched(event.Name) {
+				continue
+			}
+			pending[w.relative(event.Name)] = true
+			if event.Has(fsnotify.Create) {
+				// Watch a new directory; files created before the watch was added are
+				// only seen by walking it
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					files, _ := w.watchTree(events, event.Name)
+					for _, file := range files {
+						pending[file] = true
+					}
+				}
+			}
+			timer.Reset(debounce)
+		case <-timer.C:
+			files := make([]string, 0, len(pending))
+			for file := range pending {
+				files = append(files, file)
+			}
+			sort.Strings(files)
+			pending = make(map[string]bool)
+			onChange(files)
+		}
+	}
+}
+
+// watchTree watches dir and the directories below it that inspection looks at,
+// returning the root-relative files found in them
+func (w *Watcher) watchTree(events *fsnotify.Watcher, dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if path == dir {
+				return err
+			}
+			return nil // Files may disappear while walking
+		}
+		if !entry.IsDir() {
+			files = append(files, w.relative(path))
+			return nil
+		}
+		if path != w.RootDir && !w.watched(path) {
+			return filepath.SkipDir
+		}
+		if err := events.Add(path); err != nil && path == dir {
+			return err
+		}
+		return nil
+	})
+	return files, err
+}
+
+// watched reports whether inspection looks at a path. Hidden and ignored
+// directories are skipped, except .neev/ itself; the analysis cache is skipped
+// so that saving it does not trigger another run.
+func (w *Watcher) watched(path string) bool {
+	cacheDir := filepath.Join(w.RootDir, filepath.FromSlash(DefaultCacheDir))
+	if pathContains(cacheDir, path) {
+		return false
+	}
+	parts := strings.Split(w.relative(path), "/")
+	for i, name := range parts {
+		if name == ".neev" {
+			continue
+		}
+		if w.IgnoreDirs[name] {
+			return false
+		}
+		if strings.HasPrefix(name, ".") {
+			if i < len(parts)-1 {
+				return false
+			}
+			// Hidden files count, hidden directories do not
+			if info, err := os.Stat(path); err == nil && info.IsDir() {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// relative returns a path inside the watched tree relative to its root
+func (w *Watcher) relative(path string) string {
+	rel, err := filepath.Rel(w.RootDir, path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// Session keeps an inspection so that it can be brought up to date after files
+// change, extracting only the changed files again
+type Session struct {
+	in     *inspection
+	result *InspectResult
+}
+
+// NewSession runs a first inspection
+func NewSession(opts InspectOptions) (*Session, error) {
+	in, err := newInspection(opts)
+	if err != nil {
+		return nil, err
+	}
+	result, err := in.run()
+	if err != nil {
+		return nil, err
+	}
+	return &Session{in: in, result: result}, nil
+}
+
+// Result returns the latest result of the session
+func (s *Session) Result() *InspectResult {
+	return s.result
+}
+
+// Reinspect updates the session after the root-relative files changed. Specs
+// are read again, but only the changed files are extracted again. Checks that
+// the changes cannot affect are skipped and their warnings are carried over:
+// API checks only run again when source files or contracts changed.
+func (s *Session) Reinspect(changed []string) (*InspectResult, error) {
+	in, prev := s.in, s.result
+	opts := in.opts
+	if err := in.loadSpecs(); err != nil {
+		return nil, err
+	}
+
+	scanOpts := in.scanOptions()
+	scanOpts.Cache = in.scanOpts.Cache
+	if scanOpts.Cache == nil && opts.CacheDir != "" && scanOpts.extracts() {
+		scanOpts.Cache = LoadAnalysisCache(opts.CacheDir)
+	}
+	if scanOpts.sameExtraction(in.scanOpts) {
+		in.scan = in.analyzer.Rescan(in.scan, opts.RootDir, opts.IgnoreDirs, scanOpts, readChanges(opts.RootDir, in.scan, changed))
+	} else {
+		// The specs changed what is extracted, so earlier results do not apply
+		scan, err := in.analyzer.Scan(opts.RootDir, opts.IgnoreDirs, scanOpts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan codebase: %w", err)
+		}
+		in.scan = scan
+	}
+	in.scanOpts = scanOpts
+	if err := in.closePlugins(); err != nil {
+		return nil, err
+	}
+	if scanOpts.Cache != nil {
+		_ = scanOpts.Cache.Save()
+	}
+
+	if affectsAPI(opts, changed) {
+		result, err := in.run()
+		if err != nil {
+			return nil, err
+		}
+		s.result = result
+		return result, nil
+	}
+
+	structural := *in
+	structural.opts.Depth = 1
+	structural.opts.CheckAPI = false
+	structural.opts.CheckSignatures = false
+	structural.opts.CheckTests = false
+	structural.opts.CheckDataModel = false
+	result, err := structural.run()
+	if err != nil {
+		return nil, err
+	}
+
+	// The stack check reads manifests only and runs again in the structural pass
+	for _, w := range prev.Warnings {
+		if check := w.Type.Check(); check != CheckStack && (!check.PerModule() || check == CheckSignatures) {
+			result.Warnings = append(result.Warnings, w)
+		}
+	}
+	result.Summary.TestScenarios = prev.Summary.TestScenarios
+	result.Summary.TestedEndpoints = prev.Summary.TestedEndpoints
+	summarizeWarnings(result)
+	s.result = result
+	return result, nil
+}
+
+// readChanges reads the changed root-relative files for a rescan. A removed
+// path maps to nil, and so do the scanned files below it if it was a directory.
+func readChanges(rootDir string, scan *ScanResult, changed []string) map[string][]byte {
+	changes := make(map[string][]byte, len(changed))
+	for _, file := range changed {
+		path := filepath.Join(rootDir, filepath.FromSlash(file))
+		info, err := os.Stat(path)
+		if err != nil {
+			changes[path] = nil
+			for _, scanned := range scan.files {
+				if pathContains(path, scanned.path) {
+					changes[scanned.path] = nil
+				}
+			}
+			continue
+		}
+		if info.IsDir() {
+			continue // The watcher reports the files of a new directory
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			content = nil
+		}
+		changes[path] = content
+	}
+	return changes
+}
+
+// affectsAPI reports whether changed files can alter endpoint, signature or
+// BDD coverage findings
+func affectsAPI(opts InspectOptions, changed []string) bool {
+	for _, file := range changed {
+		if isSourceFile(opts, file) || strings.HasSuffix(file, ".feature") || strings.HasSuffix(file, ".proto") || strings.HasSuffix(file, ".sql") ||
+			strings.HasPrefix(file, ".neev/") || file == ".neev" {
+			return true
+		}
+	}
+	return false
+}
+
+// DiffWarnings returns the warnings of next that are not in prev, and those of
+// prev that are no longer in next, compared by fingerprint
+func DiffWarnings(prev, next []Warning) (added, resolved []Warning) {
+	prevKeys := make(map[string]bool, len(prev))
+	for _, w := range prev {
+		prevKeys[Fingerprint(w)] = true
+	}
+	nextKeys := make(map[string]bool, len(next))
+	for _, w := range next {
+		nextKeys[Fingerprint(w)] = true
+	}
+
+	for _, w := range next {
+		if !prevKeys[Fingerprint(w)] {
+			added = append(added, w)
+		}
+	}
+	for _, w := range prev {
+		if !nextKeys[Fingerprint(w)] {
+			resolved = append(resolved, w)
+		}
+	}
+	return added, resolved
+}
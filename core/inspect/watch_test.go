@@ -0,0 +1,132 @@
+package inspect
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestWatcher_ReportsDebouncedChanges(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFiles(t, tmpDir, map[string]string{
+		"auth/auth.go":              "package auth\n",
+		".neev/foundation/auth.md":  "# Auth\n",
+		"node_modules/lib/index.js": "module.exports = {}\n",
+	})
+
+	watcher := &Watcher{
+		RootDir:    tmpDir,
+		IgnoreDirs: map[string]bool{"node_modules": true},
+		Debounce:   50 * time.Millisecond,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	batches := make(chan []string, 4)
+	done := make(chan error)
+	go func() {
+		done <- watcher.Run(ctx, func(changed []string) { batches <- changed })
+	}()
+
+	// Let the watcher take its first snapshot, then change several files in a burst
+	time.Sleep(50 * time.Millisecond)
+	writeFiles(t, tmpDir, map[string]string{
+		"auth/auth.go":               "package auth\n\nfunc Login() {}\n",
+		".neev/foundation/orders.md": "# Orders\n",
+		"node_modules/lib/index.js":  "module.exports = { changed: true }\n",
+		".neev/cache/inspect.json":   "{}",
+	})
+	os.Remove(filepath.Join(tmpDir, ".neev", "foundation", "auth.md"))
+
+	select {
+	case changed := <-batches:
+		want := []string{".neev/foundation/auth.md", ".neev/foundation/orders.md", "auth/auth.go"}
+		if !reflect.DeepEqual(changed, want) {
+			t.Errorf("Expected %v, got %v", want, changed)
+		}
+	case <-ctx.Done():
+		t.Fatal("Timed out waiting for changes")
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Run failed: %v", err)
+	}
+}
+
+func TestReinspect_CarriesOverAPIWarnings(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFiles(t, tmpDir, map[string]string{
+		".neev/foundation/auth.md": "# Auth\n",
+	})
+	opts := InspectOptions{
+		RootDir:        tmpDir,
+		FoundationPath: filepath.Join(tmpDir, ".neev", "foundation"),
+		IgnoreDirs:     map[string]bool{".neev": true},
+		CheckAPI:       true,
+	}
+
+	session, err := NewSession(opts)
+	if err != nil {
+		t.Fatalf("NewSession failed: %v", err)
+	}
+	prev := session.Result()
+	apiWarning := Warning{Type: WarningMissingEndpoint, Module: "api", Message: "GET /users", Severity: SeverityError}
+	prev.Warnings = append(prev.Warnings, apiWarning)
+
+	// Creating the module directory only affects structural checks
+	os.MkdirAll(filepath.Join(tmpDir, "auth"), 0755)
+	next, err := session.Reinspect([]string{"auth"})
+	if err != nil {
+		t.Fatalf("Reinspect failed: %v", err)
+	}
+
+	added, resolved := DiffWarnings(prev.Warnings, next.Warnings)
+	if len(added) != 0 || len(resolved) != 1 || resolved[0].Type != WarningMissingModule {
+		t.Errorf("Expected only the missing module to be resolved, got added %+v resolved %+v", added, resolved)
+	}
+	if next.Summary.MissingEndpoints != 1 {
+		t.Errorf("Expected the carried-over endpoint warning to be counted, got %+v", next.Summary)
+	}
+}
+
+func TestSession_ReextractsChangedFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFiles(t, tmpDir, map[string]string{
+		".neev/foundation/auth.md": "# Auth\n",
+		"auth/auth.go":             "package auth\n\nfunc Login() {}\n",
+		"auth/token.go":            "package auth\n\nfunc Issue() {}\n",
+	})
+	opts := InspectOptions{
+		RootDir:         tmpDir,
+		FoundationPath:  filepath.Join(tmpDir, ".neev", "foundation"),
+		IgnoreDirs:      map[string]bool{".neev": true},
+		CheckSignatures: true,
+	}
+
+	session, err := NewSession(opts)
+	if err != nil {
+		t.Fatalf("NewSession failed: %v", err)
+	}
+
+	writeFiles(t, tmpDir, map[string]string{
+		"auth/auth.go": "package auth\n\nfunc Login() {}\n\nfunc Logout() {}\n",
+	})
+	os.Remove(filepath.Join(tmpDir, "auth", "token.go"))
+	if _, err := session.Reinspect([]string{"auth/auth.go", "auth/token.go"}); err != nil {
+		t.Fatalf("Reinspect failed: %v", err)
+	}
+
+	var names []string
+	for _, fn := range session.in.scan.Functions {
+		names = append(names, fn.Name)
+	}
+	sort.Strings(names)
+	if want := []string{"Login", "Logout"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Expected functions %v after the rescan, got %v", want, names)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/neev-kit/neev/core/frontmatter"
 )
 
 // CopilotInstructions generates GitHub Copilot instructions based on foundation and active blueprints
@@ -29,7 +31,17 @@ func CopilotInstructions(rootDir string) (string, error) {
 		for _, entry := range entries {
 			if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".md") {
 				moduleName := strings.TrimSuffix(entry.Name(), ".md")
-				builder.WriteString(fmt.Sprintf("- %s\n", moduleName))
+
+				// Front matter adds code paths and owners; archived specs are skipped
+				meta, _, _ := frontmatter.ParseFile(filepath.Join(foundationPath, entry.Name()))
+				if meta.Archived() {
+					continue
+				}
+				if meta.IsZero() {
+					builder.WriteString(fmt.Sprintf("- %s\n", moduleName))
+				} else {
+					builder.WriteString(fmt.Sprintf("- %s (%s)\n", moduleName, meta.Summary()))
+				}
 			}
 		}
 		builder.WriteString("\n")
@@ -57,10 +69,21 @@ func CopilotInstructions(rootDir string) (string, error) {
 				// Read intent if available
 				intentPath := filepath.Join(blueprintPath, "intent.md")
 				if intentData, err := os.ReadFile(intentPath); err == nil {
+					meta, body, err := frontmatter.Parse(intentData)
+					if err != nil {
+						meta, body = frontmatter.Metadata{}, intentData
+					}
+					if meta.Archived() {
+						continue
+					}
+
 					builder.WriteString(fmt.Sprintf("### Blueprint: %s\n\n", blueprintName))
+					if !meta.IsZero() {
+						builder.WriteString(fmt.Sprintf("_%s_\n\n", meta.Summary()))
+					}
 					
 					// Extract first paragraph or first 200 chars as summary
-					content := string(intentData)
+					content := string(body)
 					lines := strings.Split(content, "\n")
 					summary := ""
 					for _, line := range lines {
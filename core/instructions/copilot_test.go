@@ -81,6 +81,33 @@ Implement secure user authentication with JWT tokens.`
 	}
 }
 
+func TestCopilotInstructions_FrontMatter(t *testing.T) {
+	tmpDir := t.TempDir()
+	foundationDir := filepath.Join(tmpDir, ".neev", "foundation")
+	blueprintDir := filepath.Join(tmpDir, ".neev", "blueprints", "invoices")
+	os.MkdirAll(foundationDir, 0755)
+	os.MkdirAll(blueprintDir, 0755)
+
+	os.WriteFile(filepath.Join(foundationDir, "billing.md"), []byte("---\ncode_paths: [services/billing]\nowners: [\"@payments\"]\n---\n# Billing"), 0644)
+	os.WriteFile(filepath.Join(foundationDir, "legacy.md"), []byte("---\nstatus: archived\n---\n# Legacy"), 0644)
+	os.WriteFile(filepath.Join(blueprintDir, "intent.md"), []byte("---\nstatus: draft\n---\n# Invoices\n\nGenerate monthly invoices."), 0644)
+
+	instructions, err := CopilotInstructions(tmpDir)
+	if err != nil {
+		t.Fatalf("CopilotInstructions failed: %v", err)
+	}
+
+	if !strings.Contains(instructions, "- billing (code: services/billing | owners: @payments)") {
+		t.Errorf("Expected billing module with code paths and owners, got:\n%s", instructions)
+	}
+	if strings.Contains(instructions, "legacy") {
+		t.Errorf("Did not expect archived module in instructions")
+	}
+	if !strings.Contains(instructions, "**Intent**: Generate monthly invoices.") {
+		t.Errorf("Expected intent summary to skip front matter, got:\n%s", instructions)
+	}
+}
+
 func TestSaveCopilotInstructions(t *testing.T) {
 	tmpDir := t.TempDir()
 	foundationDir := filepath.Join(tmpDir, ".neev", "foundation")